@@ -18,7 +18,14 @@ package v1
 
 import (
 	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,18 +40,66 @@ type RedisClusterSpec struct {
 	// +kubebuilder:default=3
 	MinMasters int32 `json:"minMasters"`
 
+	// MaxMasters is the maximum number of masters the cluster can scale up to, bounding runaway
+	// scale-ups driven by a sustained overload or a bad metric. 0 (default) means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMasters int32 `json:"maxMasters,omitempty"`
+
 	// ReplicasPerMaster is the number of replica nodes per master for high availability.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:default=1
 	ReplicasPerMaster int32 `json:"replicasPerMaster"`
 
+	// StandbyReplicasPerMaster is the number of replicas attached to the standby master.
+	// The standby holds no slots, so it can run with fewer replicas (or none) to save resources.
+	// When the standby is activated it is topped up to ReplicasPerMaster.
+	// Defaults to ReplicasPerMaster and must not exceed it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StandbyReplicasPerMaster *int32 `json:"standbyReplicasPerMaster,omitempty"`
+
+	// StandbyCount is the number of hot-standby masters (0 hash slots) kept warm. Standby i sits at
+	// index (Masters+i)*(1+ReplicasPerMaster); scale-up activates the first one and provisions a
+	// new one at the end of the pool, so bursts of up to StandbyCount overloads can be absorbed
+	// without waiting for new pods. Every standby runs StandbyReplicasPerMaster replicas; the
+	// remaining pods of a standby shard other than the last stay idle until it is activated.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default=1
+	// +optional
+	StandbyCount int32 `json:"standbyCount,omitempty"`
+
 	// RedisVersion specifies the Redis Docker image version to use.
 	// +kubebuilder:default="7.2"
 	RedisVersion string `json:"redisVersion,omitempty"`
 
+	// Port is the port Redis listens on, and the cluster bus listens on Port+10000. When TLS is
+	// enabled, TLS.Port is used instead. Changing it rolls the StatefulSet, and every node must
+	// rejoin on the new port, so it is best fixed at creation.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=55535
+	// +kubebuilder:default=6379
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// IPFamily is the address family Redis nodes report in CLUSTER NODES. On dual-stack clusters,
+	// Jobs resolve pod names to an address of this family so they match the nodes' entries.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +kubebuilder:default=IPv4
+	// +optional
+	IPFamily IPFamily `json:"ipFamily,omitempty"`
+
 	// AutoScaleEnabled enables or disables the autoscaling feature.
 	AutoScaleEnabled bool `json:"autoScaleEnabled"`
 
+	// Paused temporarily stops new scaling decisions while the infrastructure is still reconciled.
+	// Unlike setting AutoScaleEnabled to false, a scaling operation already in progress is carried
+	// through to completion, so no half-finished state is left behind, and nothing else changes
+	// when it is unset. Manual scaling and rebalance requests wait until the cluster is unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
 	// CpuThreshold is the CPU usage percentage that triggers scale-up (0-100).
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=100
@@ -68,28 +123,184 @@ type RedisClusterSpec struct {
 	// +kubebuilder:default=30
 	MemoryThresholdLow int32 `json:"memoryThresholdLow,omitempty"`
 
+	// EvictionRateThreshold is the key eviction rate, in evictions per second on a single master,
+	// that triggers scale-up. A capped cache evicts to stay just under its memory limit, so the
+	// eviction rate signals memory pressure that the memory percentage alone may not reach.
+	// 0 disables the eviction-based trigger.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	EvictionRateThreshold int32 `json:"evictionRateThreshold,omitempty"`
+
+	// PodWarmupSeconds is how long after a Redis container (re)starts its metrics are ignored for
+	// scaling decisions. Loading the AOF and rejoining the cluster briefly spikes CPU, which would
+	// otherwise trigger a scale-up driven by startup load rather than real demand.
+	// 0 disables the warm-up period.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	PodWarmupSeconds int32 `json:"podWarmupSeconds,omitempty"`
+
 	// ReshardTimeoutSeconds is the timeout for reshard and drain jobs in seconds.
 	// +kubebuilder:validation:Minimum=60
 	// +kubebuilder:validation:Maximum=3600
 	// +kubebuilder:default=600
 	ReshardTimeoutSeconds int32 `json:"reshardTimeoutSeconds,omitempty"`
 
+	// BootstrapTimeoutSeconds bounds the bootstrap job. A job still unfinished after this long, for
+	// example with --cluster create hung on DNS, sets the BootstrapFailed condition and bootstrap
+	// stops until the spec is edited, which deletes the job and starts a fresh one.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=7200
+	// +kubebuilder:default=900
+	BootstrapTimeoutSeconds int32 `json:"bootstrapTimeoutSeconds,omitempty"`
+
+	// JobPollTimeoutSeconds bounds each wait in the bootstrap, reshard and join-nodes jobs for the
+	// cluster to report cluster_state:ok or for a new node to appear in CLUSTER NODES, and the wait
+	// in the cleanup-standby job for a quorum of masters to forget the deleted nodes. A job whose
+	// wait times out prints CLUSTER NODES and fails instead of continuing on a stale view.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=120
+	JobPollTimeoutSeconds int32 `json:"jobPollTimeoutSeconds,omitempty"`
+
+	// JobConfig overrides the retry limit and deadline of the operator's Jobs per operation, e.g.
+	// to give reshards on a slow network more time than ReshardTimeoutSeconds allows.
+	// +optional
+	JobConfig JobConfig `json:"jobConfig,omitempty"`
+
+	// MigrationRetryAttempts is how many times a reshard or drain job retries a slot migration
+	// that exits non-zero. Each retry only moves the slots that have not migrated yet.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=3
+	MigrationRetryAttempts int32 `json:"migrationRetryAttempts,omitempty"`
+
+	// MigrateTimeoutMillis is the timeout of each MIGRATE command issued while moving slots
+	// (redis-cli --cluster-timeout). Raise it for large keys that take longer to transfer; it is
+	// independent of ReshardTimeoutSeconds, which bounds the whole job.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=600000
+	// +kubebuilder:default=10000
+	MigrateTimeoutMillis int32 `json:"migrateTimeoutMillis,omitempty"`
+
 	// ScaleCooldownSeconds is the minimum time between scaling operations in seconds.
 	// +kubebuilder:validation:Minimum=30
 	// +kubebuilder:validation:Maximum=3600
 	// +kubebuilder:default=60
 	ScaleCooldownSeconds int32 `json:"scaleCooldownSeconds,omitempty"`
 
+	// ScaleUpCooldownSeconds is the minimum time since the last scaling operation before a
+	// scale-up. Defaults to ScaleCooldownSeconds; set it lower to react quickly to load.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleUpCooldownSeconds int32 `json:"scaleUpCooldownSeconds,omitempty"`
+
+	// ScaleDownCooldownSeconds is the minimum time since the last scaling operation before a
+	// scale-down. Defaults to ScaleCooldownSeconds; set it higher to avoid flapping.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleDownCooldownSeconds int32 `json:"scaleDownCooldownSeconds,omitempty"`
+
+	// ScaleUpStabilizationSeconds is how long a scale-up threshold must be exceeded before the
+	// operator scales up. The breach must hold across enough consecutive MetricsQueryInterval polls
+	// to cover the window, so a single spike does not trigger a reshard. 0 scales up on the first
+	// breaching poll.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleUpStabilizationSeconds int32 `json:"scaleUpStabilizationSeconds,omitempty"`
+
+	// ScaleDownStabilizationSeconds is how long the scale-down condition must hold before the
+	// operator scales down, measured like ScaleUpStabilizationSeconds. 0 scales down on the first
+	// poll that meets the condition.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleDownStabilizationSeconds int32 `json:"scaleDownStabilizationSeconds,omitempty"`
+
+	// MetricsSource selects where pod load is read from. Prometheus (default) uses PrometheusURL
+	// and the query templates. MetricsServer reads the metrics.k8s.io API served by metrics-server,
+	// so basic CPU and memory scaling works without Prometheus; the eviction rate is not available
+	// from it, and memory is the instantaneous working set rather than an average over
+	// MemoryMetricWindow.
+	// +kubebuilder:validation:Enum=Prometheus;MetricsServer
+	// +kubebuilder:default=Prometheus
+	// +optional
+	MetricsSource MetricsSource `json:"metricsSource,omitempty"`
+
 	// PrometheusURL is the URL to the Prometheus server for metrics queries.
 	// +kubebuilder:default="http://prometheus-operated.monitoring.svc:9090"
 	PrometheusURL string `json:"prometheusURL,omitempty"`
 
+	// MetricsClusterLabel is the name of a label identifying the physical Kubernetes cluster in
+	// federated Prometheus setups (e.g. "cluster"). When set together with MetricsClusterLabelValue,
+	// every metrics query is restricted to series carrying that label value, so identically named
+	// pods in another federated cluster cannot match.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	// +optional
+	MetricsClusterLabel string `json:"metricsClusterLabel,omitempty"`
+
+	// KubeletServiceLabel is the value of the "service" label on the cAdvisor container metrics
+	// the CPU query reads, i.e. the Service behind the kubelet ServiceMonitor of the Prometheus install.
+	// +kubebuilder:default="kps-kube-prometheus-stack-kubelet"
+	// +optional
+	KubeletServiceLabel string `json:"kubeletServiceLabel,omitempty"`
+
+	// CpuQueryTemplate overrides the PromQL query returning the CPU usage percentage per pod, for
+	// metric label sets other than kube-prometheus-stack's. It is a Go text/template rendered with
+	// MetricsQueryVars, e.g. {{.Name}} and {{.Namespace}}, and must return one sample per pod
+	// carrying a "pod" label. {{.RoleFilter}} should be appended to honour IncludeReplicasInMetrics.
+	// +optional
+	CpuQueryTemplate string `json:"cpuQueryTemplate,omitempty"`
+
+	// MemoryQueryTemplate overrides the PromQL query returning the memory usage percentage per pod,
+	// as a percentage of the container's memory limit. It is rendered like CpuQueryTemplate.
+	// +optional
+	MemoryQueryTemplate string `json:"memoryQueryTemplate,omitempty"`
+
+	// MemoryMetricWindow is the Prometheus range the memory usage is averaged over, e.g. "5m".
+	// Averaging the working set rather than sampling it at one instant keeps short spikes from
+	// driving scale decisions. Available to MemoryQueryTemplate as {{.MemoryWindow}}.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h|d)$`
+	// +kubebuilder:default="5m"
+	// +optional
+	MemoryMetricWindow string `json:"memoryMetricWindow,omitempty"`
+
+	// ServiceMonitor configures the ServiceMonitor through which Prometheus scrapes the Redis
+	// exporters.
+	// +optional
+	ServiceMonitor ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+
+	// MetricsClusterLabelValue is the value of MetricsClusterLabel for this cluster.
+	// +optional
+	MetricsClusterLabelValue string `json:"metricsClusterLabelValue,omitempty"`
+
 	// MetricsQueryInterval is how often to query Prometheus for metrics in seconds.
 	// +kubebuilder:validation:Minimum=5
 	// +kubebuilder:validation:Maximum=300
 	// +kubebuilder:default=15
 	MetricsQueryInterval int32 `json:"metricsQueryInterval,omitempty"`
 
+	// PrometheusQueryRetries is how many times a failed Prometheus query is retried, with a short
+	// backoff, before the metrics cycle is abandoned. Retries stop early once the retry budget of a
+	// few seconds is spent, so an unreachable Prometheus still fails fast. Defaults to 2; 0 disables.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	PrometheusQueryRetries *int32 `json:"prometheusQueryRetries,omitempty"`
+
+	// MetricsGracePeriodSeconds is how long metrics may be unavailable or incomplete before the
+	// MetricsDegraded condition is set and a warning is emitted. Scale-downs are refused from the
+	// first failed cycle regardless, so the cluster never shrinks on a partial view of its load;
+	// scale-ups still proceed on the metrics that are available.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=120
+	// +optional
+	MetricsGracePeriodSeconds int32 `json:"metricsGracePeriodSeconds,omitempty"`
+
 	// ExistingCluster indicates this CR is managing an existing Redis cluster.
 	// When true, the operator will discover the cluster topology instead of bootstrapping.
 	// +optional
@@ -111,20 +322,775 @@ type RedisClusterSpec struct {
 	// +optional
 	ManageStatefulSet bool `json:"manageStatefulSet,omitempty"`
 
+	// ManageConfig indicates whether the operator should manage the redis.conf ConfigMap.
+	// Set to false when adopting an existing cluster whose configuration must be left untouched.
+	// +kubebuilder:default=true
+	// +optional
+	ManageConfig bool `json:"manageConfig"`
+
 	// StatefulSetName is the name of the existing StatefulSet to manage.
 	// If not specified, defaults to the cluster name.
 	// +optional
 	StatefulSetName string `json:"statefulSetName,omitempty"`
+
+	// AutoRollbackOnScaleFailure controls what happens when a reshard or drain job fails.
+	// When false (default), the failed job is cleaned up and the operation is retried on a later cycle.
+	// When true, the operator first runs a rollback job that moves migrated slots back to their source
+	// and closes any half-migrated slots, then records the failure in status for human review.
+	// +optional
+	AutoRollbackOnScaleFailure bool `json:"autoRollbackOnScaleFailure,omitempty"`
+
+	// MaxScaleRetries is the number of reshard, drain, or add-shard jobs that may fail in a row
+	// before automatic scaling stops. Once exceeded, the ScaleRetriesExhausted condition is set and
+	// no further scaling is attempted until the RedisCluster spec is edited, so a doomed operation
+	// is not retried every interval. 0 disables the limit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	MaxScaleRetries int32 `json:"maxScaleRetries,omitempty"`
+
+	// IncludeReplicasInMetrics controls whether replica load factors into scaling decisions.
+	// When false (default), only master pods are measured. When true, each shard is measured as the
+	// highest CPU and memory usage across its master and replicas, so read-saturated replicas can
+	// trigger a scale-up of their shard.
+	// +optional
+	IncludeReplicasInMetrics bool `json:"includeReplicasInMetrics,omitempty"`
+
+	// JobPriorityClassName is the PriorityClass applied to the pods of bootstrap, reshard, drain,
+	// cleanup, join, and rollback Jobs, so scaling operations are not preempted or left Pending
+	// during the capacity crunch that triggered them.
+	// +optional
+	JobPriorityClassName string `json:"jobPriorityClassName,omitempty"`
+
+	// PodPriorityClassName is the PriorityClass applied to the Redis pods of a managed StatefulSet.
+	// +optional
+	PodPriorityClassName string `json:"podPriorityClassName,omitempty"`
+
+	// Affinity is applied to the Redis pods. When unset, pods of the cluster prefer to be spread
+	// across nodes so that a single node failure does not take out a master and its replicas.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations are applied to the Redis pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeSelector is applied to the Redis pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// TopologySpreadConstraints are applied to the Redis pods.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// ImagePullSecrets are used to pull the images of the Redis pods and of the operator's Jobs,
+	// e.g. when they are mirrored into a private registry.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// CommonLabels are added to the ConfigMap, Service, StatefulSet and its pods, ServiceMonitor,
+	// and the operator's Jobs, e.g. for cost allocation or network policies. The labels the
+	// operator selects its resources by take precedence over a common label of the same key.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// CommonAnnotations are added to the same resources as CommonLabels, e.g. to drive service
+	// mesh sidecar injection on the Redis pods. Annotations the operator sets itself take
+	// precedence. Changing them rolls the Redis pods, as they are part of the pod template.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// PodSecurityContext is applied to the Redis pods and to the pods of the operator's Jobs.
+	// Defaults to running as the redis user (UID and GID 999) with runAsNonRoot and an fsGroup of
+	// 999, so the data volume stays writable.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext is applied to the redis and redis-exporter containers and to the containers
+	// of the operator's Jobs; ExtraContainers keep their own. Defaults to disallowing privilege
+	// escalation and dropping all capabilities.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// PodManagementPolicy controls how the managed StatefulSet creates pods. Parallel starts all pods
+	// at once, which bootstraps large clusters much faster; bootstrap and scaling wait for every pod
+	// to be ready either way. The policy is immutable on an existing StatefulSet, so changing it only
+	// takes effect if the StatefulSet is recreated.
+	// +kubebuilder:validation:Enum=OrderedReady;Parallel
+	// +kubebuilder:default=OrderedReady
+	// +optional
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+
+	// DegradedAlertThresholdSeconds is how long a degradation condition may persist before the
+	// NeedsAttention condition is set and an alerting Warning event is emitted. Shorter degradation
+	// is expected while a scaling operation is in progress.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=900
+	DegradedAlertThresholdSeconds int32 `json:"degradedAlertThresholdSeconds,omitempty"`
+
+	// SkipGhostCleanup disables the drain job step that forgets failed or disconnected nodes.
+	// Even when enabled, a node is only forgotten if it is still failing after a re-check.
+	// +optional
+	SkipGhostCleanup bool `json:"skipGhostCleanup,omitempty"`
+
+	// MaxConcurrentScalingInNamespace limits how many RedisClusters in this namespace may be
+	// scaling at the same time. While the limit is reached, this cluster defers its scale
+	// decisions and reports the ScalingDeferred condition. When clusters in the namespace set
+	// different limits, the lowest non-zero one applies to all of them. 0 (default) means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrentScalingInNamespace int32 `json:"maxConcurrentScalingInNamespace,omitempty"`
+
+	// DrainWritePauseMilliseconds pauses writes on the master being drained for this long before
+	// its slots are migrated, letting in-flight commands and MULTI transactions settle.
+	// The pause is released before migration starts and on any drain failure. 0 (default) disables it.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10000
+	// +optional
+	DrainWritePauseMilliseconds int32 `json:"drainWritePauseMilliseconds,omitempty"`
+
+	// ScaleUpStrategy selects how a scale-up adds capacity. ActivateStandby (default) moves half of
+	// the overloaded master's slots onto the standby and then provisions the next standby.
+	// AddShard grows the StatefulSet by a full shard first, joins the new master and all of its
+	// replicas itself instead of relying on a provisioned standby, and then rebalances slots across
+	// every master with redis-cli --cluster rebalance, spreading load from all masters rather than
+	// halving one.
+	// +kubebuilder:validation:Enum=ActivateStandby;AddShard
+	// +kubebuilder:default=ActivateStandby
+	// +optional
+	ScaleUpStrategy ScaleUpStrategy `json:"scaleUpStrategy,omitempty"`
+
+	// ScaleMetric selects which resource signals drive scaling decisions. Both (default) scales up
+	// when CPU or memory exceeds its threshold and counts a master as underutilized only when both
+	// are below their low thresholds. CPU or Memory ignores the other signal's thresholds entirely,
+	// e.g. Memory for caching workloads whose CPU is noisy. The eviction rate applies in every mode.
+	// +kubebuilder:validation:Enum=CPU;Memory;Both
+	// +kubebuilder:default=Both
+	// +optional
+	ScaleMetric ScaleMetric `json:"scaleMetric,omitempty"`
+
+	// ExternalMasterPolicy selects how the operator reacts to more masters serving slots than
+	// Masters, typically after someone ran redis-cli --cluster add-node and moved slots by hand.
+	// Reject (default) sets the UnexpectedMasters condition and blocks scaling until the topology
+	// is reconciled. Adopt raises Masters to the observed count. Remove migrates the slots of
+	// masters no cluster pod holds back onto the other masters and removes them with del-node.
+	// +kubebuilder:validation:Enum=Adopt;Reject;Remove
+	// +kubebuilder:default=Reject
+	// +optional
+	ExternalMasterPolicy ExternalMasterPolicy `json:"externalMasterPolicy,omitempty"`
+
+	// ScaleDownTarget selects which master a scale-down removes. HighestIndex (default) drains the
+	// highest-index master so the StatefulSet can shrink from the top. LowestLoad drains the
+	// least-loaded master instead, then rotates the highest-index master's slots down into the
+	// emptied master so the StatefulSet still shrinks from the top. LowestLoad avoids piling a busy
+	// master's data onto low-util pods at the cost of migrating the drained master's slots as well.
+	// +kubebuilder:validation:Enum=HighestIndex;LowestLoad
+	// +kubebuilder:default=HighestIndex
+	// +optional
+	ScaleDownTarget ScaleDownTarget `json:"scaleDownTarget,omitempty"`
+
+	// ScaleDownStrategy selects how a scale-down removes the drained master. PreserveStandby
+	// (default) migrates its slots to the planned destinations, then resets it and re-adds it with
+	// fresh replicas as the new standby while the old last standby is removed. SimpleRemove skips
+	// that shuffle: the highest-index master's slots are spread over the remaining masters with
+	// redis-cli --cluster rebalance, the emptied master stays in the cluster as the first standby,
+	// and only the pods the StatefulSet sheds are removed with del-node. It needs fewer steps that
+	// can fail, but the standby keeps no freshly reset state and DrainExclusions only keeps masters
+	// from being removed, not from receiving slots. SimpleRemove requires ScaleDownTarget HighestIndex.
+	// +kubebuilder:validation:Enum=PreserveStandby;SimpleRemove
+	// +kubebuilder:default=PreserveStandby
+	// +optional
+	ScaleDownStrategy ScaleDownStrategy `json:"scaleDownStrategy,omitempty"`
+
+	// DrainExclusions are master pods a scale-down never drains or moves slots onto, e.g. shards
+	// holding hot keys. If the highest-index master is excluded, the next-highest eligible master
+	// is drained and the excluded master's slots are rotated into it unchanged. Since excluded
+	// masters are never removed, there must be fewer of them than MinMasters.
+	// +optional
+	DrainExclusions []string `json:"drainExclusions,omitempty"`
+
+	// VerifyKeyspaceIntegrity records the cluster-wide key count (sum of DBSIZE across masters)
+	// when a scaling operation starts and compares it with the count once the operation finishes.
+	// A drop beyond KeyspaceIntegrityTolerancePercent sets the KeyspaceIntegrityViolated condition.
+	// +optional
+	VerifyKeyspaceIntegrity bool `json:"verifyKeyspaceIntegrity,omitempty"`
+
+	// KeyspaceIntegrityTolerancePercent is how far, as a percentage of the starting key count, the
+	// key count may drop during a scaling operation before it is treated as data loss. It absorbs
+	// keys expiring or being deleted by clients while the operation runs.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=1
+	// +optional
+	KeyspaceIntegrityTolerancePercent int32 `json:"keyspaceIntegrityTolerancePercent,omitempty"`
+
+	// ScaleDownAggressiveness controls how quickly idle masters are consolidated. Conservative
+	// (default) waits the full ScaleCooldownSeconds between scale-downs, removing one master at a
+	// time. Aggressive lets the next scale-down follow as soon as load has been re-measured after the
+	// previous removal (about a minute), so a long traffic trough is reclaimed quickly, down to
+	// MinMasters. Eager additionally detects when every active master is underutilized and then
+	// removes masters back to back, without a cooldown or per-pod load re-check, down to the master
+	// count the total load needs (never below MinMasters). Scale-ups after a consolidation still
+	// wait the full cooldown.
+	// +kubebuilder:validation:Enum=Conservative;Aggressive;Eager
+	// +kubebuilder:default=Conservative
+	// +optional
+	ScaleDownAggressiveness ScaleDownAggressiveness `json:"scaleDownAggressiveness,omitempty"`
+
+	// RepairSplitBrain lets the operator resolve slots claimed by several masters by bumping the
+	// config epoch of the claimant with the highest epoch, so its claim wins. Without it a split
+	// brain is only reported through the SplitBrainDetected condition.
+	// +optional
+	RepairSplitBrain bool `json:"repairSplitBrain,omitempty"`
+
+	// RepairMembership lets the operator fix a cluster whose CLUSTER NODES table does not match
+	// the expected pods: failed entries for addresses no pod holds are forgotten, and expected pods
+	// missing from the table are joined. Without it a mismatch is only reported through the
+	// MembershipMismatch condition.
+	// +optional
+	RepairMembership bool `json:"repairMembership,omitempty"`
+
+	// AutoForgetFailedNodes makes every reconcile forget node entries flagged fail or noaddr whose
+	// address no pod holds, e.g. left behind by a pod that restarted with a new IP. Such entries
+	// otherwise pile up between scale-downs and can break later add-node calls. Failed masters that
+	// still own slots are left alone.
+	// +kubebuilder:default=true
+	// +optional
+	AutoForgetFailedNodes bool `json:"autoForgetFailedNodes"`
+
+	// ClusterHealthCheckIntervalSeconds enables a periodic sweep of the Redis cluster's own state,
+	// independent of scaling: CLUSTER INFO, slot coverage and split-brain are checked at most this
+	// often, and drift is reported through events. 0 (default) disables the sweep.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClusterHealthCheckIntervalSeconds int32 `json:"clusterHealthCheckIntervalSeconds,omitempty"`
+
+	// AutoFixCluster makes the health sweep run redis-cli --cluster fix when it finds the cluster
+	// state not ok or slots without a live master. Without it drift is only reported.
+	// +optional
+	AutoFixCluster bool `json:"autoFixCluster,omitempty"`
+
+	// ResetClusterOnDelete flushes every Redis pod and runs CLUSTER RESET HARD when the
+	// RedisCluster is deleted, so retained volumes do not carry the old cluster's data and
+	// topology into a cluster created later under the same name. It destroys the data and is
+	// ignored when ExistingCluster is true.
+	// +optional
+	ResetClusterOnDelete bool `json:"resetClusterOnDelete,omitempty"`
+
+	// WaitForStandbyReplicas makes standby provisioning wait until the new standby's replica pods
+	// are ready, not just the standby master, before joining them, so the standby shard comes up
+	// with full HA instead of skipping replicas that are still starting.
+	// +kubebuilder:default=true
+	// +optional
+	WaitForStandbyReplicas bool `json:"waitForStandbyReplicas"`
+
+	// StandbyProvisioningTimeoutSeconds bounds how long the operator waits for the next standby to
+	// become ready and join the cluster after a scale-up. On timeout provisioning is abandoned and
+	// the StandbyProvisioningFailed condition reports why, instead of blocking scaling forever.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=900
+	StandbyProvisioningTimeoutSeconds int32 `json:"standbyProvisioningTimeoutSeconds,omitempty"`
+
+	// MinFreeMemoryPercentAfterScaleDown refuses a scale-down if any master receiving slots would be
+	// left with less than this percentage of its memory limit free, estimated from current usage
+	// plus its share of the drained master's usage. This keeps a scale-down from immediately causing
+	// the memory pressure that triggers a scale-up. 0 (default) disables the check.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	MinFreeMemoryPercentAfterScaleDown int32 `json:"minFreeMemoryPercentAfterScaleDown,omitempty"`
+
+	// Advisory makes the autoscaler recommend instead of act. Each scaling decision is written to
+	// Status.Recommendation, with the exact plan, and announced with an event, but the operator
+	// only carries it out once a human sets the cache.example.com/approve-recommendation
+	// annotation to the recommendation's ID.
+	// +optional
+	Advisory bool `json:"advisory,omitempty"`
+
+	// AutoScaleDryRun makes the autoscaler evaluate its thresholds and queries against live
+	// metrics and report each scaling decision, as an event and in Status.LastDecision, without
+	// acting on it. No status is changed and no Job is created, so thresholds and PromQL can be
+	// validated safely before autoscaling is enabled for real. Takes precedence over Advisory.
+	// +optional
+	AutoScaleDryRun bool `json:"autoScaleDryRun,omitempty"`
+
+	// AnnotatePodRoles makes the operator annotate each Redis pod with its observed role
+	// (rediscluster.cache.example.com/role), shard index (.../shard-index), and whether it
+	// belongs to the standby shard (.../is-standby), so external tooling can read the live role
+	// mapping from Kubernetes instead of parsing CLUSTER NODES.
+	// +optional
+	AnnotatePodRoles bool `json:"annotatePodRoles,omitempty"`
+
+	// ClusterMemberReadinessGate adds a readiness gate to the Redis pods that the operator only
+	// passes once the pod has joined the Redis cluster (it knows other nodes or owns slots) and, for
+	// a replica, has finished its initial sync, so clients are not routed to pods that are still
+	// joining. The gate is evaluated from the pod's own view only. Changing it rolls the StatefulSet.
+	// +optional
+	ClusterMemberReadinessGate bool `json:"clusterMemberReadinessGate,omitempty"`
+
+	// ClusterNodeTimeoutMs is the cluster-node-timeout in redis.conf: how long, in milliseconds, a
+	// node may be unreachable before it is considered failing. Raise it for large clusters on slow
+	// networks to avoid spurious failovers. Changing it rolls the StatefulSet.
+	// +kubebuilder:validation:Minimum=1000
+	// +kubebuilder:validation:Maximum=600000
+	// +kubebuilder:default=5000
+	// +optional
+	ClusterNodeTimeoutMs int32 `json:"clusterNodeTimeoutMs,omitempty"`
+
+	// AppendOnly enables AOF persistence. Set it to false for RDB-only persistence, configured
+	// through the save directive in RedisConfig. Changing it rolls the StatefulSet.
+	// +kubebuilder:default=true
+	// +optional
+	AppendOnly bool `json:"appendOnly"`
+
+	// AppendFsync is the appendfsync policy of the AOF: Always, EverySec (default), or No.
+	// Ignored when AppendOnly is false.
+	// +kubebuilder:validation:Enum=Always;EverySec;No
+	// +kubebuilder:default=EverySec
+	// +optional
+	AppendFsync AppendFsyncPolicy `json:"appendFsync,omitempty"`
+
+	// RedisConfig holds redis.conf directives, e.g. maxmemory, maxmemory-policy, or save, that
+	// replace the operator's defaults or are appended to them. Changing it rolls the StatefulSet.
+	// cluster-enabled and cluster-config-file cannot be overridden.
+	// +optional
+	RedisConfig map[string]string `json:"redisConfig,omitempty"`
+
+	// RedisResources are the resource requests and limits of the redis container. Memory-based
+	// scaling measures usage against the memory limit, so one is required while AutoScaleEnabled
+	// is true. Defaults to 250m CPU / 512Mi memory requests and a 1Gi memory limit.
+	// +optional
+	RedisResources corev1.ResourceRequirements `json:"redisResources,omitempty"`
+
+	// ExporterResources are the resource requests and limits of the redis-exporter sidecar.
+	// Defaults to 100m CPU / 128Mi memory requests and 200m CPU / 256Mi memory limits.
+	// +optional
+	ExporterResources corev1.ResourceRequirements `json:"exporterResources,omitempty"`
+
+	// JobResources are the resource requests and limits of the containers of the operator's Jobs,
+	// so they stay schedulable on a full node and are not OOM-killed halfway through a reshard.
+	// Defaults to 50m CPU / 64Mi memory requests and a 256Mi memory limit.
+	// +optional
+	JobResources corev1.ResourceRequirements `json:"jobResources,omitempty"`
+
+	// ExtraContainers are added to the Redis pods after the managed redis and redis-exporter
+	// containers, e.g. a config reloader, log shipper, or service-mesh proxy. They may not use the
+	// managed containers' names or ports.
+	// +optional
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// TLS configures TLS for client, replication, and cluster bus connections between Redis pods
+	// and for the operator's jobs.
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+
+	// Storage configures the data volume of each Redis pod. A StatefulSet's volume claim
+	// template cannot be changed once created, so later changes only take effect when the
+	// StatefulSet is recreated.
+	// +optional
+	Storage StorageSpec `json:"storage,omitempty"`
+
+	// ReclaimScaledDownPVCs deletes the data volumes of pods removed by a scale-down instead of
+	// keeping them for a scale-up that would reuse them. The StatefulSet's whenScaled retention
+	// policy is set to Delete; on Kubernetes versions without PVC retention support the operator
+	// deletes the volumes itself once the scale-down has completed.
+	// +optional
+	ReclaimScaledDownPVCs bool `json:"reclaimScaledDownPVCs,omitempty"`
+}
+
+// ScaleRecommendation is a scaling decision awaiting human approval in Advisory mode.
+type ScaleRecommendation struct {
+	// ID identifies this recommendation. Approve it by setting the
+	// cache.example.com/approve-recommendation annotation to this value.
+	ID string `json:"id"`
+
+	// Direction is "up" or "down".
+	Direction string `json:"direction"`
+
+	// TargetMasters is the number of active masters after the recommended operation.
+	TargetMasters int32 `json:"targetMasters"`
+
+	// Reason explains why scaling is recommended.
+	Reason string `json:"reason"`
+
+	// Plan describes the slot migration the operator would perform.
+	Plan string `json:"plan"`
+
+	// Since is when the recommendation was first made.
+	Since metav1.Time `json:"since"`
 }
 
+// ScalingEvent is an entry in the scaling history.
+type ScalingEvent struct {
+	// Time is when the event was recorded.
+	Time metav1.Time `json:"time"`
+
+	// Direction is "up" or "down".
+	Direction string `json:"direction"`
+
+	// Outcome is Triggered, Succeeded, or Failed.
+	Outcome string `json:"outcome"`
+
+	// OperationID is the correlation ID of the scaling operation.
+	// +optional
+	OperationID string `json:"operationID,omitempty"`
+
+	// TriggerPod is the overloaded pod of a scale-up or the drained pod of a scale-down.
+	// +optional
+	TriggerPod string `json:"triggerPod,omitempty"`
+
+	// Reason explains the decision or the outcome.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// MastersBefore is the number of active masters before the operation.
+	MastersBefore int32 `json:"mastersBefore"`
+
+	// MastersAfter is the number of active masters after the operation, or the intended number
+	// for a Triggered entry.
+	MastersAfter int32 `json:"mastersAfter"`
+}
+
+// ScaleDownAggressiveness controls how quickly consecutive scale-downs may follow each other.
+type ScaleDownAggressiveness string
+
+const (
+	// ScaleDownConservative removes one master per cooldown period.
+	ScaleDownConservative ScaleDownAggressiveness = "Conservative"
+
+	// ScaleDownAggressive removes masters in sequence, re-evaluating load after each removal.
+	ScaleDownAggressive ScaleDownAggressiveness = "Aggressive"
+
+	// ScaleDownEager behaves like Aggressive and, when the whole cluster is idle, removes several
+	// masters in a row down to a target derived from the total load.
+	ScaleDownEager ScaleDownAggressiveness = "Eager"
+)
+
+// ServiceMonitorSpec configures the ServiceMonitor for the Redis exporters.
+type ServiceMonitorSpec struct {
+	// Interval is the scrape interval, e.g. "15s". Defaults to MetricsQueryInterval, capped at
+	// 30s so the one-minute rate window of the CPU query always holds two samples; a coarser
+	// scrape than query interval would have the autoscaler act on stale data.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h)$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// ScrapeTimeout is the scrape timeout, e.g. "10s". It may not exceed Interval; Prometheus's
+	// default is used when unset.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h)$`
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
+	// HonorLabels keeps the labels of scraped series when they conflict with target labels.
+	// +optional
+	HonorLabels bool `json:"honorLabels,omitempty"`
+
+	// Labels are merged over the ServiceMonitor's default labels (release: prometheus and
+	// app: redis-cluster), replacing a default of the same key. They must match the
+	// serviceMonitorSelector of the Prometheus instance, e.g. release: monitoring for a Prometheus
+	// Operator installed under that release name; otherwise no metrics are scraped and the
+	// autoscaler never receives data.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// JobConfig holds the per-operation Job settings. Unset fields keep the operation's default.
+type JobConfig struct {
+	// Bootstrap configures the Job creating the cluster. Defaults to no retries and a deadline of
+	// BootstrapTimeoutSeconds.
+	// +optional
+	Bootstrap JobSettings `json:"bootstrap,omitempty"`
+
+	// Reshard configures the Jobs moving slots onto a new master on scale-up, for both scale-up
+	// strategies, and requested rebalances. Defaults to no retries and a deadline of
+	// ReshardTimeoutSeconds.
+	// +optional
+	Reshard JobSettings `json:"reshard,omitempty"`
+
+	// Drain configures the Job moving slots off a master on scale-down. Defaults to no retries and
+	// a deadline of ReshardTimeoutSeconds.
+	// +optional
+	Drain JobSettings `json:"drain,omitempty"`
+
+	// Cleanup configures the Job re-adding the standby after a drain. Defaults to 3 retries and a
+	// 300s deadline.
+	// +optional
+	Cleanup JobSettings `json:"cleanup,omitempty"`
+
+	// Join configures the Job joining the next standby after a scale-up. Defaults to 3 retries and
+	// a 300s deadline.
+	// +optional
+	Join JobSettings `json:"join,omitempty"`
+}
+
+// JobSettings are the retry limit and deadline of one kind of Job.
+type JobSettings struct {
+	// BackoffLimit is the number of times a failed Job pod is retried before the Job fails.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// ActiveDeadlineSeconds bounds how long the Job may run, retries included, before it fails.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// validate checks the settings of the Jobs of the named operation.
+func (s JobSettings) validate(operation string) error {
+	if s.BackoffLimit != nil && *s.BackoffLimit < 0 {
+		return fmt.Errorf("jobConfig.%s.backoffLimit (%d) cannot be negative", operation, *s.BackoffLimit)
+	}
+	if s.ActiveDeadlineSeconds != nil && *s.ActiveDeadlineSeconds <= 0 {
+		return fmt.Errorf("jobConfig.%s.activeDeadlineSeconds (%d) must be positive", operation, *s.ActiveDeadlineSeconds)
+	}
+	return nil
+}
+
+// StorageSpec configures the PersistentVolumeClaim of each Redis pod.
+type StorageSpec struct {
+	// Size is the requested size of the data volume. Defaults to 1Gi.
+	// +optional
+	Size resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the StorageClass of the data volume. The cluster's default
+	// StorageClass is used when unset.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// TLSSpec configures TLS for intra-cluster Redis connections.
+type TLSSpec struct {
+	// Enabled switches Redis to TLS-only: the plain port is disabled and clients, replicas, and
+	// the cluster bus connect over TLS on Port.
+	Enabled bool `json:"enabled"`
+
+	// CertSecretRef is the name of a Secret in the cluster's namespace holding tls.crt, tls.key,
+	// and ca.crt. It is mounted into every Redis pod and every Job the operator creates.
+	// +optional
+	CertSecretRef string `json:"certSecretRef,omitempty"`
+
+	// Port is the TLS port Redis listens on. Defaults to the cluster's Port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=55535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Metrics serves the redis-exporter metrics endpoint over HTTPS with the same certificate,
+	// so a ServiceMonitor or scrape config can scrape over TLS.
+	// +optional
+	Metrics bool `json:"metrics,omitempty"`
+}
+
+// ScaleDownTarget selects which master a scale-down drains.
+type ScaleDownTarget string
+
+const (
+	// ScaleDownTargetHighestIndex drains the highest-index active master.
+	ScaleDownTargetHighestIndex ScaleDownTarget = "HighestIndex"
+
+	// ScaleDownTargetLowestLoad drains the least-loaded active master and rotates the
+	// highest-index master into its place.
+	ScaleDownTargetLowestLoad ScaleDownTarget = "LowestLoad"
+)
+
+// ScaleDownStrategy selects how a scale-down removes a master.
+type ScaleDownStrategy string
+
+const (
+	// ScaleDownPreserveStandby drains the master and re-adds it as a freshly reset standby.
+	ScaleDownPreserveStandby ScaleDownStrategy = "PreserveStandby"
+
+	// ScaleDownSimpleRemove rebalances the master's slots away and removes the pods the
+	// StatefulSet sheds with del-node.
+	ScaleDownSimpleRemove ScaleDownStrategy = "SimpleRemove"
+)
+
+// IPFamily is an IP address family.
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 selects IPv4 addresses.
+	IPFamilyIPv4 IPFamily = "IPv4"
+
+	// IPFamilyIPv6 selects IPv6 addresses.
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
+// ScaleUpStrategy selects how a scale-up adds a master.
+type ScaleUpStrategy string
+
+const (
+	// ScaleUpActivateStandby moves half of the overloaded master's slots to the standby.
+	ScaleUpActivateStandby ScaleUpStrategy = "ActivateStandby"
+
+	// ScaleUpAddShard adds a shard and rebalances slots across all masters.
+	ScaleUpAddShard ScaleUpStrategy = "AddShard"
+)
+
+// ExternalMasterPolicy selects how the operator reacts to masters it did not create.
+type ExternalMasterPolicy string
+
+const (
+	// ExternalMasterAdopt raises Masters to the number of masters serving slots.
+	ExternalMasterAdopt ExternalMasterPolicy = "Adopt"
+
+	// ExternalMasterReject blocks scaling until the unexpected masters are removed by hand.
+	ExternalMasterReject ExternalMasterPolicy = "Reject"
+
+	// ExternalMasterRemove migrates the slots off masters outside the cluster and removes them.
+	ExternalMasterRemove ExternalMasterPolicy = "Remove"
+)
+
+// MetricsSource selects the provider of pod load metrics.
+type MetricsSource string
+
+const (
+	// MetricsSourcePrometheus queries Prometheus.
+	MetricsSourcePrometheus MetricsSource = "Prometheus"
+
+	// MetricsSourceMetricsServer reads the metrics.k8s.io API served by metrics-server.
+	MetricsSourceMetricsServer MetricsSource = "MetricsServer"
+)
+
+// ScaleMetric selects the resource signals that drive scaling decisions.
+type ScaleMetric string
+
+const (
+	// ScaleMetricCPU scales on CPU usage only.
+	ScaleMetricCPU ScaleMetric = "CPU"
+
+	// ScaleMetricMemory scales on memory usage only.
+	ScaleMetricMemory ScaleMetric = "Memory"
+
+	// ScaleMetricBoth scales on CPU and memory usage.
+	ScaleMetricBoth ScaleMetric = "Both"
+)
+
+// AppendFsyncPolicy is the appendfsync policy of the AOF.
+type AppendFsyncPolicy string
+
+const (
+	// AppendFsyncAlways fsyncs after every write.
+	AppendFsyncAlways AppendFsyncPolicy = "Always"
+
+	// AppendFsyncEverySec fsyncs once per second.
+	AppendFsyncEverySec AppendFsyncPolicy = "EverySec"
+
+	// AppendFsyncNo leaves flushing to the operating system.
+	AppendFsyncNo AppendFsyncPolicy = "No"
+)
+
+// RedisClusterPhase is a one-word summary of the cluster state.
+type RedisClusterPhase string
+
+const (
+	RedisClusterPhasePending  RedisClusterPhase = "Pending"
+	RedisClusterPhaseScaling  RedisClusterPhase = "Scaling"
+	RedisClusterPhaseDegraded RedisClusterPhase = "Degraded"
+	RedisClusterPhaseReady    RedisClusterPhase = "Ready"
+)
+
+// Condition types reported in RedisClusterStatus.Conditions.
+const (
+	// ConditionStandbyInvariantViolated is True when the cluster does not have StandbyCount
+	// zero-slot masters to act as standbys. Scaling is blocked while it is True.
+	ConditionStandbyInvariantViolated = "StandbyInvariantViolated"
+
+	// ConditionNeedsAttention is True when a degradation condition has persisted longer than
+	// DegradedAlertThresholdSeconds, indicating the operator is stuck rather than mid-scale.
+	ConditionNeedsAttention = "NeedsAttention"
+
+	// ConditionScalingDeferred is True when a scaling decision was postponed because other
+	// RedisClusters in the namespace are already scaling.
+	ConditionScalingDeferred = "ScalingDeferred"
+
+	// ConditionReady is False until the cluster is bootstrapped, and while a newly activated
+	// master's replicas are still catching up after a scale-up, i.e. while the new shard has no
+	// functioning HA.
+	ConditionReady = "Ready"
+
+	// ConditionBootstrapped is True once the cluster has been bootstrapped, or discovered when
+	// ExistingCluster is set. It mirrors Status.Initialized.
+	ConditionBootstrapped = "Bootstrapped"
+
+	// ConditionScaling is True while a scaling operation (reshard, drain, standby provisioning, or
+	// rollback) is in progress; the reason names the phase. It mirrors the Is* status flags.
+	ConditionScaling = "Scaling"
+
+	// ConditionDegraded is True while any degradation condition (StandbyInvariantViolated,
+	// KeyspaceIntegrityViolated, StandbyProvisioningFailed, SplitBrainDetected, MembershipMismatch,
+	// UnexpectedMasters, ScaleRetriesExhausted, MetricsDegraded, BootstrapFailed) is True.
+	ConditionDegraded = "Degraded"
+
+	// ConditionKeyspaceIntegrityViolated is True when the key count after the last verified scaling
+	// operation dropped by more than KeyspaceIntegrityTolerancePercent, indicating keys were lost
+	// during slot migration. It is cleared by the next scaling operation that verifies cleanly.
+	ConditionKeyspaceIntegrityViolated = "KeyspaceIntegrityViolated"
+
+	// ConditionStandbyProvisioningFailed is True when the standby after a scale-up could not be
+	// provisioned within StandbyProvisioningTimeoutSeconds. It is cleared by the next successful
+	// provisioning.
+	ConditionStandbyProvisioningFailed = "StandbyProvisioningFailed"
+
+	// ConditionSplitBrainDetected is True when several masters claim the same slots, or serve
+	// slots under the same config epoch, typically after a network partition healed. Scaling is
+	// blocked while it is True.
+	ConditionSplitBrainDetected = "SplitBrainDetected"
+
+	// ConditionMembershipMismatch is True when the nodes Redis reports in CLUSTER NODES differ from
+	// the pods the layout expects, through ghost entries left by earlier scaling or pods that
+	// dropped out of the cluster.
+	ConditionMembershipMismatch = "MembershipMismatch"
+
+	// ConditionUnexpectedMasters is True when more masters serve slots than Masters, for example
+	// after a master was added to the cluster by hand. Scaling is blocked while it is True.
+	ConditionUnexpectedMasters = "UnexpectedMasters"
+
+	// ConditionAtMaxMasters is True while the cluster runs MaxMasters masters, so scale-ups are
+	// refused however loaded it is.
+	ConditionAtMaxMasters = "AtMaxMasters"
+
+	// ConditionScaleRetriesExhausted is True once more than MaxScaleRetries scaling jobs failed in
+	// a row. Scaling is blocked while it is True; editing the spec clears it.
+	ConditionScaleRetriesExhausted = "ScaleRetriesExhausted"
+
+	// ConditionMetricsDegraded is True once metrics have been unavailable or incomplete for longer
+	// than MetricsGracePeriodSeconds, i.e. the autoscaler is scaling blind.
+	ConditionMetricsDegraded = "MetricsDegraded"
+
+	// ConditionPaused is True while Paused is set and no new scaling decisions are made.
+	ConditionPaused = "Paused"
+
+	// ConditionBootstrapFailed is True when the bootstrap job did not finish within
+	// BootstrapTimeoutSeconds. Bootstrap stops while it is True; editing the spec clears it.
+	ConditionBootstrapFailed = "BootstrapFailed"
+)
+
 // RedisClusterStatus defines the observed state of a Redis Cluster.
 type RedisClusterStatus struct {
-	// CurrentMasters is the actual number of active master nodes currently running.
+	// CurrentMasters is the number of live masters that own slots, as observed in the Redis cluster.
+	// It is the status of the scale subresource.
 	CurrentMasters int32 `json:"currentMasters"`
 
 	// CurrentReplicas is the actual number of replica nodes currently running.
 	CurrentReplicas int32 `json:"currentReplicas"`
 
+	// Phase summarizes the cluster state for display: Pending until bootstrapped, Scaling while a
+	// scaling operation is in progress, Degraded while the Degraded condition is True, else Ready.
+	// +optional
+	Phase RedisClusterPhase `json:"phase,omitempty"`
+
+	// Selector is the label selector of the cluster's Redis pods, in string form, for the scale
+	// subresource.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// TotalSlotsAssigned is the number of hash slots (of 16384) served by a live master,
+	// as observed when an existing cluster was discovered.
+	// +optional
+	TotalSlotsAssigned int32 `json:"totalSlotsAssigned,omitempty"`
+
 	// Initialized indicates whether the cluster has completed bootstrap.
 	// +optional
 	Initialized bool `json:"initialized,omitempty"`
@@ -141,23 +1107,58 @@ type RedisClusterStatus struct {
 	// +optional
 	IsDraining bool `json:"isDraining,omitempty"`
 
+	// IsRebalancing indicates a manually requested slot rebalance across the active masters
+	// is in progress.
+	// +optional
+	IsRebalancing bool `json:"isRebalancing,omitempty"`
+
 	// LastScaleTime records when the last scaling operation started (for cooldown).
 	// +optional
 	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 
-	// StandbyPod is the name of the pod serving as the hot standby (0 hash slots).
+	// LastClusterHealthCheck is when the periodic health sweep last ran.
+	// +optional
+	LastClusterHealthCheck *metav1.Time `json:"lastClusterHealthCheck,omitempty"`
+
+	// MetricsUnavailableSince is when metrics first became unavailable or incomplete. It is cleared
+	// by the next cycle that reads the load of every active master.
+	// +optional
+	MetricsUnavailableSince *metav1.Time `json:"metricsUnavailableSince,omitempty"`
+
+	// StandbyPod is the name of the pod serving as the hot standby (0 hash slots) that the next
+	// scale-up activates.
 	// +optional
 	StandbyPod string `json:"standbyPod,omitempty"`
 
+	// StandbyPods are the pods serving as standby masters, in activation order. StandbyPod is the
+	// first of them.
+	// +optional
+	StandbyPods []string `json:"standbyPods,omitempty"`
+
+	// ScalingStandbySnapshot is StandbyPod as it was when the current standby scale-up or scale-down
+	// started. The reshard and drain Jobs are only created while the live standby still matches it.
+	// +optional
+	ScalingStandbySnapshot string `json:"scalingStandbySnapshot,omitempty"`
+
 	// OverloadedPod is the pod that triggered the current scale-up operation.
 	// +optional
 	OverloadedPod string `json:"overloadedPod,omitempty"`
 
+	// ScaleUpTargetMasters is the master count an AddShard scale-up in progress grows the cluster
+	// to. 0 for ActivateStandby scale-ups.
+	// +optional
+	ScaleUpTargetMasters int32 `json:"scaleUpTargetMasters,omitempty"`
+
+	// ProvisioningStartTime is when the current standby provisioning started.
+	// +optional
+	ProvisioningStartTime *metav1.Time `json:"provisioningStartTime,omitempty"`
+
 	// PodToDrain is the pod being drained during the current scale-down operation.
 	// +optional
 	PodToDrain string `json:"podToDrain,omitempty"`
 
 	// DrainDestPod1 is the first destination pod for slots from the drained pod.
+	// Empty in SimpleRemove mode, which rebalances them across every remaining master.
 	// +optional
 	DrainDestPod1 string `json:"drainDestPod1,omitempty"`
 
@@ -165,10 +1166,128 @@ type RedisClusterStatus struct {
 	// Empty if only one destination is needed.
 	// +optional
 	DrainDestPod2 string `json:"drainDestPod2,omitempty"`
+
+	// DrainRotatePod is the least-loaded master drained first in LowestLoad scale-down mode.
+	// Its slots go to the destination pods, then PodToDrain's slots are rotated into it, so
+	// PodToDrain (the highest-index master) still ends up empty. Empty in HighestIndex mode.
+	// +optional
+	DrainRotatePod string `json:"drainRotatePod,omitempty"`
+
+	// DrainSlotBaseline is the slot count of every master when the drain job was created. The
+	// rollback of a failed drain returns the slots a master holds above its baseline.
+	// +optional
+	DrainSlotBaseline map[string]int32 `json:"drainSlotBaseline,omitempty"`
+
+	// IsRollingBack indicates a failed scaling operation is being rolled back.
+	// +optional
+	IsRollingBack bool `json:"isRollingBack,omitempty"`
+
+	// RollbackOperation is the operation being rolled back ("reshard" or "drain").
+	// +optional
+	RollbackOperation string `json:"rollbackOperation,omitempty"`
+
+	// RollbackSourcePod is the pod that owned the slots before the failed operation started.
+	// +optional
+	RollbackSourcePod string `json:"rollbackSourcePod,omitempty"`
+
+	// RollbackDestPods are the masters a failed drain may have moved RollbackSourcePod's slots to.
+	// +optional
+	RollbackDestPods []string `json:"rollbackDestPods,omitempty"`
+
+	// RollbackRotatePod is the DrainRotatePod of a failed drain. The destinations return their
+	// slots to it first, then it returns the slots it took over to RollbackSourcePod.
+	// +optional
+	RollbackRotatePod string `json:"rollbackRotatePod,omitempty"`
+
+	// LastScaleFailure describes the most recent failed scaling operation and its rollback outcome.
+	// +optional
+	LastScaleFailure string `json:"lastScaleFailure,omitempty"`
+
+	// LastFailureLog is the tail of the log of the most recent failed reshard, drain, or add-shard
+	// Job, kept because the Job itself is deleted. At most 4KiB of the last 50 lines are stored.
+	// +optional
+	LastFailureLog string `json:"lastFailureLog,omitempty"`
+
+	// ScaleFailureCount is the number of scaling jobs that have failed since the last successful
+	// scaling operation. It is also reset when scaling resumes after ScaleRetriesExhausted.
+	// +optional
+	ScaleFailureCount int32 `json:"scaleFailureCount,omitempty"`
+
+	// ActiveOperation is the correlation ID of the bootstrap or scaling operation in progress.
+	// It is stamped on every Job and Event belonging to that operation.
+	// +optional
+	ActiveOperation string `json:"activeOperation,omitempty"`
+
+	// ReplicaSyncPendingPod is the master activated by the last scale-up whose replicas have not
+	// yet caught up with the migrated data. Scaling is blocked until it is cleared.
+	// +optional
+	ReplicaSyncPendingPod string `json:"replicaSyncPendingPod,omitempty"`
+
+	// LastScaleDecision summarizes the most recent scaling decision and, when
+	// VerifyKeyspaceIntegrity is enabled, the key counts before and after it.
+	// +optional
+	LastScaleDecision string `json:"lastScaleDecision,omitempty"`
+
+	// LastDecision is the most recent decision evaluated in AutoScaleDryRun mode: the scaling
+	// operation the autoscaler would have carried out, or that no scaling was needed.
+	// +optional
+	LastDecision string `json:"lastDecision,omitempty"`
+
+	// KeyCountBeforeScale is the cluster-wide key count recorded when the scaling operation in
+	// progress started. Only set when VerifyKeyspaceIntegrity is enabled.
+	// +optional
+	KeyCountBeforeScale *int64 `json:"keyCountBeforeScale,omitempty"`
+
+	// Recommendation is the scaling operation awaiting approval in Advisory mode.
+	// +optional
+	Recommendation *ScaleRecommendation `json:"recommendation,omitempty"`
+
+	// ScalingEvents is the recent scaling history, oldest first: each scale-up and scale-down is
+	// recorded when it is triggered and again when it completes or fails. Only the last 10
+	// entries are kept.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	ScalingEvents []ScalingEvent `json:"scalingEvents,omitempty"`
+
+	// ConsecutiveHighSamples counts the consecutive metrics polls that exceeded a scale-up
+	// threshold. Only tracked when ScaleUpStabilizationSeconds is set.
+	// +optional
+	ConsecutiveHighSamples int32 `json:"consecutiveHighSamples,omitempty"`
+
+	// ConsecutiveLowSamples counts the consecutive metrics polls that met the scale-down
+	// condition. Only tracked when ScaleDownStabilizationSeconds is set.
+	// +optional
+	ConsecutiveLowSamples int32 `json:"consecutiveLowSamples,omitempty"`
+
+	// ConsecutiveScaleDowns counts the scale-downs completed since the last scale-up.
+	// +optional
+	ConsecutiveScaleDowns int32 `json:"consecutiveScaleDowns,omitempty"`
+
+	// IdleConsolidationTarget is the master count an Eager idle consolidation is scaling down to.
+	// 0 when no idle consolidation is in progress.
+	// +optional
+	IdleConsolidationTarget int32 `json:"idleConsolidationTarget,omitempty"`
+
+	// NextMetricsCheckTime is when the operator will next evaluate metrics for a scaling decision.
+	// Unset while a scaling operation is in progress.
+	// +optional
+	NextMetricsCheckTime *metav1.Time `json:"nextMetricsCheckTime,omitempty"`
+
+	// Conditions represent the latest observations of the cluster's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.masters,statuspath=.status.currentMasters,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Masters",type=integer,JSONPath=`.spec.masters`
+// +kubebuilder:printcolumn:name="Current",type=integer,JSONPath=`.status.currentMasters`
+// +kubebuilder:printcolumn:name="Standby",type=string,JSONPath=`.status.standbyPod`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // RedisCluster is the Schema for the redisclusters API.
 type RedisCluster struct {
@@ -210,6 +1329,103 @@ func (r *RedisCluster) ValidateSpec() error {
 			r.Spec.Masters, r.Spec.MinMasters)
 	}
 
+	// Draining needs an eligible master to empty and another to receive its slots, so with every
+	// other master drained, the cluster stops one above the excluded masters.
+	if int32(len(r.Spec.DrainExclusions)) >= r.Spec.MinMasters {
+		return fmt.Errorf("drainExclusions (%d pods) must list fewer pods than minMasters (%d), or scale-downs could never reach minMasters",
+			len(r.Spec.DrainExclusions), r.Spec.MinMasters)
+	}
+
+	// SimpleRemove has no rotation, so it can only empty the highest-index master.
+	if r.Spec.ScaleDownStrategy == ScaleDownSimpleRemove && r.Spec.ScaleDownTarget == ScaleDownTargetLowestLoad {
+		return fmt.Errorf("scaleDownTarget LowestLoad requires scaleDownStrategy PreserveStandby, SimpleRemove always removes the highest-index master")
+	}
+
+	if r.Spec.MaxMasters > 0 && r.Spec.MaxMasters < r.Spec.MinMasters {
+		return fmt.Errorf("maxMasters (%d) cannot be less than minMasters (%d)",
+			r.Spec.MaxMasters, r.Spec.MinMasters)
+	}
+
+	// The cluster bus listens on the Redis port + 10000, which must still be a valid port.
+	if r.Spec.Port < 0 || r.Spec.Port > 55535 {
+		return fmt.Errorf("port (%d) must be between 1 and 55535, the cluster bus uses port+10000", r.Spec.Port)
+	}
+	if r.Spec.Port == 9121 {
+		return fmt.Errorf("port 9121 is used by the metrics exporter")
+	}
+
+	for operation, settings := range map[string]JobSettings{
+		"bootstrap": r.Spec.JobConfig.Bootstrap,
+		"reshard":   r.Spec.JobConfig.Reshard,
+		"drain":     r.Spec.JobConfig.Drain,
+		"cleanup":   r.Spec.JobConfig.Cleanup,
+		"join":      r.Spec.JobConfig.Join,
+	} {
+		if err := settings.validate(operation); err != nil {
+			return err
+		}
+	}
+
+	if (r.Spec.MetricsClusterLabel == "") != (r.Spec.MetricsClusterLabelValue == "") {
+		return fmt.Errorf("metricsClusterLabel and metricsClusterLabelValue must be set together")
+	}
+
+	if sm := r.Spec.ServiceMonitor; sm.Interval != "" && sm.ScrapeTimeout != "" {
+		interval, errInterval := time.ParseDuration(sm.Interval)
+		timeout, errTimeout := time.ParseDuration(sm.ScrapeTimeout)
+		if errInterval == nil && errTimeout == nil && timeout > interval {
+			return fmt.Errorf("serviceMonitor.scrapeTimeout (%s) cannot be greater than serviceMonitor.interval (%s)",
+				sm.ScrapeTimeout, sm.Interval)
+		}
+	}
+
+	if err := validateMetricsQueryTemplate("cpuQueryTemplate", r.Spec.CpuQueryTemplate); err != nil {
+		return err
+	}
+	if err := validateMetricsQueryTemplate("memoryQueryTemplate", r.Spec.MemoryQueryTemplate); err != nil {
+		return err
+	}
+
+	if err := validateCooldown("scaleUpCooldownSeconds", r.Spec.ScaleUpCooldownSeconds); err != nil {
+		return err
+	}
+	if err := validateCooldown("scaleDownCooldownSeconds", r.Spec.ScaleDownCooldownSeconds); err != nil {
+		return err
+	}
+
+	if r.StandbyReplicaCount() > r.Spec.ReplicasPerMaster {
+		return fmt.Errorf("standbyReplicasPerMaster (%d) cannot be greater than replicasPerMaster (%d)",
+			r.StandbyReplicaCount(), r.Spec.ReplicasPerMaster)
+	}
+
+	if r.Spec.AutoScaleEnabled && r.Spec.ManageStatefulSet {
+		if _, ok := r.Spec.RedisResources.Limits[corev1.ResourceMemory]; !ok {
+			return fmt.Errorf("redisResources.limits.memory is required when autoScaleEnabled is true, memory usage is measured against it")
+		}
+	}
+
+	for key := range r.Spec.RedisConfig {
+		if IsProtectedRedisConfigKey(key) {
+			return fmt.Errorf("redisConfig may not set %q, changing it corrupts the cluster", key)
+		}
+	}
+
+	if r.Spec.Storage.Size.Sign() <= 0 {
+		return fmt.Errorf("storage.size (%s) must be a positive quantity", r.Spec.Storage.Size.String())
+	}
+
+	if r.Spec.TLS != nil && r.Spec.TLS.Enabled && r.Spec.TLS.CertSecretRef == "" {
+		return fmt.Errorf("tls.certSecretRef is required when tls.enabled is true")
+	}
+
+	if err := r.validateExtraContainers(); err != nil {
+		return err
+	}
+
+	if !r.Spec.ManageConfig && r.Spec.ManageStatefulSet {
+		return fmt.Errorf("manageConfig=false requires manageStatefulSet=false, the managed StatefulSet mounts the operator's ConfigMap")
+	}
+
 	// Validate existing cluster configuration
 	if r.Spec.ExistingCluster {
 		if len(r.Spec.PodSelector) == 0 {
@@ -223,11 +1439,115 @@ func (r *RedisCluster) ValidateSpec() error {
 	return nil
 }
 
+// validateExtraContainers rejects sidecars that reuse the name of a managed container, or a port
+// of the managed containers: the Redis port, its cluster bus port, and the exporter's metrics port.
+func (r *RedisCluster) validateExtraContainers() error {
+	redisPort := r.Spec.Port
+	if redisPort == 0 {
+		redisPort = 6379
+	}
+	if r.Spec.TLS != nil && r.Spec.TLS.Enabled && r.Spec.TLS.Port != 0 {
+		redisPort = r.Spec.TLS.Port
+	}
+	reservedPorts := map[int32]string{
+		redisPort:         "redis",
+		redisPort + 10000: "cluster bus",
+		9121:              "metrics",
+	}
+
+	for _, container := range r.Spec.ExtraContainers {
+		if container.Name == "redis" || container.Name == "redis-exporter" {
+			return fmt.Errorf("extraContainers may not use the reserved container name %q", container.Name)
+		}
+		for _, port := range container.Ports {
+			if use, ok := reservedPorts[port.ContainerPort]; ok {
+				return fmt.Errorf("extraContainers %q may not use port %d, it is the %s port", container.Name, port.ContainerPort, use)
+			}
+			if port.Name == "redis" || port.Name == "metrics" {
+				return fmt.Errorf("extraContainers %q may not use the reserved port name %q", container.Name, port.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// MetricsQueryVars are the variables available to CpuQueryTemplate and MemoryQueryTemplate.
+// +kubebuilder:object:generate=false
+type MetricsQueryVars struct {
+	// Name is the name of the RedisCluster; its pods are named "<Name>-<ordinal>".
+	Name string
+	// Namespace is the namespace of the RedisCluster.
+	Namespace string
+	// KubeletService is KubeletServiceLabel.
+	KubeletService string
+	// ClusterMatcher is the label matcher, with a leading comma, scoping a selector to this
+	// physical cluster through MetricsClusterLabel, or empty.
+	ClusterMatcher string
+	// RoleFilter is the clause restricting the query to master pods, or empty when
+	// IncludeReplicasInMetrics is set.
+	RoleFilter string
+	// MemoryWindow is MemoryMetricWindow.
+	MemoryWindow string
+}
+
+// validateMetricsQueryTemplate checks that a metrics query template parses and renders with
+// MetricsQueryVars, so a typo is reported before any query is sent to Prometheus.
+func validateMetricsQueryTemplate(field, text string) error {
+	if text == "" {
+		return nil
+	}
+	tmpl, err := template.New(field).Parse(text)
+	if err != nil {
+		return fmt.Errorf("%s does not parse: %w", field, err)
+	}
+	if err := tmpl.Execute(io.Discard, MetricsQueryVars{}); err != nil {
+		return fmt.Errorf("%s does not render: %w", field, err)
+	}
+	return nil
+}
+
+// validateCooldown checks that a directional cooldown, when set, is within 30–3600 seconds.
+func validateCooldown(field string, seconds int32) error {
+	if seconds != 0 && (seconds < 30 || seconds > 3600) {
+		return fmt.Errorf("%s (%d) must be between 30 and 3600", field, seconds)
+	}
+	return nil
+}
+
+// IsProtectedRedisConfigKey reports whether a redis.conf directive is managed by the operator and
+// may not be set through RedisConfig.
+func IsProtectedRedisConfigKey(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "cluster-enabled", "cluster-config-file":
+		return true
+	}
+	return false
+}
+
 // SetDefaults sets default values for optional fields that weren't provided.
 func (r *RedisCluster) SetDefaults() {
 	if r.Spec.RedisVersion == "" {
 		r.Spec.RedisVersion = "7.2"
 	}
+	if r.Spec.PodSecurityContext == nil {
+		redisID := int64(999)
+		runAsNonRoot := true
+		r.Spec.PodSecurityContext = &corev1.PodSecurityContext{
+			RunAsNonRoot: &runAsNonRoot,
+			RunAsUser:    &redisID,
+			RunAsGroup:   &redisID,
+			FSGroup:      &redisID,
+		}
+	}
+	if r.Spec.SecurityContext == nil {
+		allowPrivilegeEscalation := false
+		r.Spec.SecurityContext = &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		}
+	}
 	if r.Spec.MinMasters == 0 {
 		r.Spec.MinMasters = 3
 	}
@@ -243,9 +1563,103 @@ func (r *RedisCluster) SetDefaults() {
 	if r.Spec.ReshardTimeoutSeconds == 0 {
 		r.Spec.ReshardTimeoutSeconds = 600
 	}
+	if r.Spec.BootstrapTimeoutSeconds == 0 {
+		r.Spec.BootstrapTimeoutSeconds = 900
+	}
+	if r.Spec.JobPollTimeoutSeconds == 0 {
+		r.Spec.JobPollTimeoutSeconds = 120
+	}
+	if r.Spec.MigrationRetryAttempts == 0 {
+		r.Spec.MigrationRetryAttempts = 3
+	}
+	if r.Spec.MigrateTimeoutMillis == 0 {
+		r.Spec.MigrateTimeoutMillis = 10000
+	}
 	if r.Spec.ScaleCooldownSeconds == 0 {
 		r.Spec.ScaleCooldownSeconds = 60
 	}
+	if r.Spec.PodManagementPolicy == "" {
+		r.Spec.PodManagementPolicy = appsv1.OrderedReadyPodManagement
+	}
+	if r.Spec.Port == 0 {
+		r.Spec.Port = 6379
+	}
+	if r.Spec.TLS != nil && r.Spec.TLS.Port == 0 {
+		r.Spec.TLS.Port = r.Spec.Port
+	}
+	if r.Spec.RedisResources.Requests == nil && r.Spec.RedisResources.Limits == nil {
+		r.Spec.RedisResources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		}
+	}
+	if r.Spec.ExporterResources.Requests == nil && r.Spec.ExporterResources.Limits == nil {
+		r.Spec.ExporterResources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("200m"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		}
+	}
+	if r.Spec.JobResources.Requests == nil && r.Spec.JobResources.Limits == nil {
+		r.Spec.JobResources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		}
+	}
+	if r.Spec.ScaleDownAggressiveness == "" {
+		r.Spec.ScaleDownAggressiveness = ScaleDownConservative
+	}
+	if r.Spec.IPFamily == "" {
+		r.Spec.IPFamily = IPFamilyIPv4
+	}
+	if r.Spec.ScaleUpStrategy == "" {
+		r.Spec.ScaleUpStrategy = ScaleUpActivateStandby
+	}
+	if r.Spec.ExternalMasterPolicy == "" {
+		r.Spec.ExternalMasterPolicy = ExternalMasterReject
+	}
+	if r.Spec.ScaleMetric == "" {
+		r.Spec.ScaleMetric = ScaleMetricBoth
+	}
+	if r.Spec.ScaleDownTarget == "" {
+		r.Spec.ScaleDownTarget = ScaleDownTargetHighestIndex
+	}
+	if r.Spec.ScaleDownStrategy == "" {
+		r.Spec.ScaleDownStrategy = ScaleDownPreserveStandby
+	}
+	if r.Spec.StandbyProvisioningTimeoutSeconds == 0 {
+		r.Spec.StandbyProvisioningTimeoutSeconds = 900
+	}
+	if r.Spec.DegradedAlertThresholdSeconds == 0 {
+		r.Spec.DegradedAlertThresholdSeconds = 900
+	}
+	if r.Spec.MemoryMetricWindow == "" {
+		r.Spec.MemoryMetricWindow = "5m"
+	}
+	if r.Spec.ClusterNodeTimeoutMs == 0 {
+		r.Spec.ClusterNodeTimeoutMs = 5000
+	}
+	if r.Spec.AppendFsync == "" {
+		r.Spec.AppendFsync = AppendFsyncEverySec
+	}
+	if r.Spec.MetricsSource == "" {
+		r.Spec.MetricsSource = MetricsSourcePrometheus
+	}
 	if r.Spec.PrometheusURL == "" {
 		r.Spec.PrometheusURL = "http://prometheus-operated.monitoring.svc:9090"
 	}
@@ -255,6 +1669,15 @@ func (r *RedisCluster) SetDefaults() {
 	if r.Spec.ReplicasPerMaster == 0 {
 		r.Spec.ReplicasPerMaster = 1
 	}
+	if r.Spec.StandbyCount == 0 {
+		r.Spec.StandbyCount = 1
+	}
+	if r.Spec.KubeletServiceLabel == "" {
+		r.Spec.KubeletServiceLabel = "kps-kube-prometheus-stack-kubelet"
+	}
+	if r.Spec.Storage.Size.IsZero() {
+		r.Spec.Storage.Size = resource.MustParse("1Gi")
+	}
 
 	// Defaults for existing cluster support
 	if r.Spec.ServiceName == "" {
@@ -263,5 +1686,37 @@ func (r *RedisCluster) SetDefaults() {
 	if r.Spec.StatefulSetName == "" {
 		r.Spec.StatefulSetName = r.Name
 	}
-	// ManageStatefulSet defaults to true (kubebuilder default marker handles this)
+	// ManageStatefulSet, ManageConfig and AppendOnly default to true (kubebuilder default markers handle this)
+}
+
+// ScaleUpCooldown returns the cooldown in seconds before a scale-up.
+func (r *RedisCluster) ScaleUpCooldown() int32 {
+	if r.Spec.ScaleUpCooldownSeconds == 0 {
+		return r.Spec.ScaleCooldownSeconds
+	}
+	return r.Spec.ScaleUpCooldownSeconds
+}
+
+// ScaleDownCooldown returns the cooldown in seconds before a scale-down.
+func (r *RedisCluster) ScaleDownCooldown() int32 {
+	if r.Spec.ScaleDownCooldownSeconds == 0 {
+		return r.Spec.ScaleCooldownSeconds
+	}
+	return r.Spec.ScaleDownCooldownSeconds
+}
+
+// PrometheusQueryRetryCount returns the number of retries for a failed Prometheus query.
+func (r *RedisCluster) PrometheusQueryRetryCount() int32 {
+	if r.Spec.PrometheusQueryRetries == nil {
+		return 2
+	}
+	return *r.Spec.PrometheusQueryRetries
+}
+
+// StandbyReplicaCount returns the number of replicas the standby master runs with.
+func (r *RedisCluster) StandbyReplicaCount() int32 {
+	if r.Spec.StandbyReplicasPerMaster == nil {
+		return r.Spec.ReplicasPerMaster
+	}
+	return *r.Spec.StandbyReplicasPerMaster
 }
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -39,6 +40,7 @@ import (
 
 	cachev1 "github.com/myuser/redis-operator/api/v1"
 	"github.com/myuser/redis-operator/internal/controller"
+	webhookv1 "github.com/myuser/redis-operator/internal/webhook/v1"
 
 	// +kubebuilder:scaffold:imports
 
@@ -68,6 +70,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var reconcileTimeout time.Duration
+	var watchNamespace string
+	var enableWebhooks bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -86,6 +91,14 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 2*time.Minute,
+		"Maximum duration of a single RedisCluster reconcile before it is cancelled and requeued. 0 disables the timeout.")
+	flag.StringVar(&watchNamespace, "watch-namespace", os.Getenv(controller.WatchNamespaceEnv),
+		"Comma-separated namespaces to watch RedisClusters and their resources in. Empty watches all namespaces. "+
+			"Defaults to $"+controller.WatchNamespaceEnv+".")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the defaulting and validating webhooks for RedisCluster are served. They need the webhook "+
+			"certificates and the webhook configurations from config/webhook to be installed.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -183,8 +196,14 @@ func main() {
 		})
 	}
 
+	watchNamespaces := controller.ParseWatchNamespaces(watchNamespace)
+	if len(watchNamespaces) > 0 {
+		setupLog.Info("Restricting watches to namespaces", "namespaces", watchNamespaces)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  controller.WatchNamespaceCacheOptions(watchNamespaces),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -208,12 +227,21 @@ func main() {
 	}
 
 	if err := (&controller.RedisClusterReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Config:           mgr.GetConfig(),
+		Recorder:         mgr.GetEventRecorderFor("rediscluster-controller"),
+		ReconcileTimeout: reconcileTimeout,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RedisCluster")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err := webhookv1.SetupRedisClusterWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RedisCluster")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+//go:embed scripts/add-shard.sh
+var addShardScript string
+
+// newShardMasterPod returns the master pod of the shard an AddShard scale-up adds. Shards are laid
+// out by ordinal, so it is the first pod after the last active shard before the scale-up.
+func newShardMasterPod(cluster *appv1.RedisCluster) string {
+	index := (cluster.Status.ScaleUpTargetMasters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
+	return fmt.Sprintf("%s-%d", cluster.Name, index)
+}
+
+// checkAddShardStatus drives an AddShard scale-up. The master count is raised first so the
+// StatefulSet grows by a full shard, the new shard's master and replicas are joined to the cluster,
+// and a rebalance job then spreads slots across every master. The new standby at the top of the
+// StatefulSet is joined by the regular standby provisioning that follows.
+func (r *RedisClusterReconciler) checkAddShardStatus(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	target := cluster.Status.ScaleUpTargetMasters
+
+	if cluster.Spec.Masters < target {
+		logger.Info("Growing StatefulSet by a shard", "masters", target)
+		if err := r.patchCluster(ctx, cluster, func() { cluster.Spec.Masters = target }); err != nil {
+			logger.Error(err, "Failed to update spec to increment masters")
+			return ctrl.Result{}, err
+		}
+		if err := r.scaleStatefulSet(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to reconcile StatefulSet for the new shard")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}, sts); err != nil {
+		logger.Error(err, "Failed to get StatefulSet for add-shard check")
+		return ctrl.Result{}, err
+	}
+	readyReplicas, err := r.joinablePodCount(ctx, cluster, sts)
+	if err != nil {
+		logger.Error(err, "Failed to count ready pods")
+		return ctrl.Result{}, err
+	}
+	if readyReplicas != desiredPodCount(cluster) {
+		logger.Info("Waiting for new shard pods to be ready",
+			"ready", readyReplicas,
+			"desired", desiredPodCount(cluster))
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	jobName := cluster.Name + "-reshard"
+	newMaster := newShardMasterPod(cluster)
+	addShardJob := &batchv1.Job{}
+	err = r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, addShardJob)
+
+	if err != nil && errors.IsNotFound(err) {
+		if err := r.joinNewShard(ctx, cluster, newMaster); err != nil {
+			logger.Info("New shard not yet joined to cluster, waiting", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
+		job := r.addShardJobForRedisCluster(cluster, newMaster)
+		if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
+			logger.Error(err, "Failed to set owner reference on add-shard job")
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			logger.Error(err, "Failed to create add-shard job")
+			return ctrl.Result{}, err
+		}
+		r.recordNormal(cluster, "ReshardStarted", "Created job %s rebalancing slots onto new master %s", jobName, newMaster)
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+
+	} else if err != nil {
+		logger.Error(err, "Failed to get add-shard job")
+		return ctrl.Result{}, err
+	}
+
+	if addShardJob.Status.Succeeded == 0 && addShardJob.Status.Failed == 0 {
+		logger.Info("Add-shard rebalance job is still running...")
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
+	if addShardJob.Status.Succeeded > 0 {
+		logger.Info("Add-shard rebalance succeeded, provisioning next standby pods", "newMaster", newMaster)
+		r.recordNormal(cluster, "ReshardSucceeded", "Shard %s added, provisioning next standby", newMaster)
+		recordScalingEvent(cluster, scaleDirectionUp, scalingOutcomeSucceeded, cluster.Status.OverloadedPod,
+			fmt.Sprintf("shard %s added and slots rebalanced", newMaster), target-1, target)
+		resetScaleFailures(cluster)
+	} else {
+		// The slots already moved stay on the new master, which serves them like any other; the
+		// shard remains part of the cluster and the next rebalance evens out the rest.
+		logger.Error(fmt.Errorf("add-shard job %s failed", jobName), "Rebalancing onto new shard failed")
+		now := metav1.Now()
+		cluster.Status.LastScaleFailure = fmt.Sprintf("add-shard rebalance onto %s failed at %s; request a rebalance once the cluster is healthy",
+			newMaster, now.Format(time.RFC3339))
+		r.recordWarning(cluster, "ReshardFailed", "Add-shard job %s failed, shard %s kept", jobName, newMaster)
+		r.recordJobFailureLog(ctx, cluster, addShardJob)
+		recordScalingEvent(cluster, scaleDirectionUp, scalingOutcomeFailed, cluster.Status.OverloadedPod,
+			fmt.Sprintf("rebalance job %s failed, shard %s kept", jobName, newMaster), target-1, target)
+		r.recordScaleFailure(cluster, "add-shard rebalance")
+	}
+
+	// The new shard's master takes the role an activated standby has in the standby flow.
+	cluster.Status.StandbyPod = newMaster
+	cluster.Status.IsResharding = false
+	cluster.Status.IsProvisioningStandby = true
+	cluster.Status.OverloadedPod = ""
+	cluster.Status.ScaleUpTargetMasters = 0
+	now := metav1.Now()
+	cluster.Status.LastScaleTime = &now
+	cluster.Status.ProvisioningStartTime = &now
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status after add-shard rebalance")
+		return ctrl.Result{}, err
+	}
+
+	_ = r.Delete(ctx, addShardJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// joinNewShard joins the new shard's master and all of its replicas to the cluster. When the pods
+// were the standby shard they are already members and only missing replicas are added.
+func (r *RedisClusterReconciler) joinNewShard(ctx context.Context, cluster *appv1.RedisCluster, masterPod string) error {
+	if err := r.ensureClusterMember(ctx, cluster, masterPod, ""); err != nil {
+		return err
+	}
+	masterIndex := (cluster.Status.ScaleUpTargetMasters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
+	for i := int32(1); i <= cluster.Spec.ReplicasPerMaster; i++ {
+		replicaPod := fmt.Sprintf("%s-%d", cluster.Name, masterIndex+i)
+		if err := r.ensureClusterMember(ctx, cluster, replicaPod, masterPod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addShardJobForRedisCluster creates a Kubernetes Job that rebalances slots across every master,
+// including the new shard's master, while keeping the standby masters empty.
+func (r *RedisClusterReconciler) addShardJobForRedisCluster(cluster *appv1.RedisCluster, newMaster string) *batchv1.Job {
+	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
+		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
+
+	timeout := int64(cluster.Spec.ReshardTimeoutSeconds)
+	backoff := int32(0)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name + "-reshard",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: &timeout,
+			BackoffLimit:          &backoff,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
+					Containers: []corev1.Container{
+						{
+							Name:    "add-shard",
+							Image:   redisImage(cluster),
+							Command: []string{"sh", "-c"},
+							Args:    []string{redis.Script(addShardScript, redis.ResolveIPScript, redis.NodeLookupScript, redis.SlotCountScript)},
+							Env: []corev1.EnvVar{
+								{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
+								{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
+								{Name: "NEW_MASTER_POD", Value: newMaster},
+								{Name: "SERVICE_NAME", Value: cluster.Name + "-headless"},
+								{Name: "NAMESPACE", Value: cluster.Namespace},
+								{Name: "MIGRATE_TIMEOUT_MS", Value: fmt.Sprintf("%d", cluster.Spec.MigrateTimeoutMillis)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Reshard)
+	return job
+}
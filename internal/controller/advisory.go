@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// approveRecommendationAnnotation approves the recommendation whose ID it is set to.
+// Approving by ID rather than with a plain flag keeps a stale approval from applying to a
+// different recommendation made after conditions changed.
+const approveRecommendationAnnotation = "cache.example.com/approve-recommendation"
+
+// awaitApproval gates a scaling decision in Advisory mode. It returns true when the operator may
+// proceed: always outside Advisory mode or in AutoScaleDryRun mode, which never acts on the
+// decision, and in Advisory mode only once the current recommendation for the same direction and
+// target has been approved. Otherwise the decision is
+// recorded as the recommendation (a new one gets a fresh ID and an event) and false is returned.
+// On approval the annotation is consumed and the recommendation cleared; the caller persists the status.
+func (r *RedisClusterReconciler) awaitApproval(ctx context.Context, cluster *appv1.RedisCluster, direction string, targetMasters int32, reason, plan string) (bool, error) {
+	if !cluster.Spec.Advisory || cluster.Spec.AutoScaleDryRun {
+		return true, nil
+	}
+	logger := log.FromContext(ctx)
+
+	rec := cluster.Status.Recommendation
+	if rec != nil && rec.Direction == direction && rec.TargetMasters == targetMasters {
+		if cluster.Annotations[approveRecommendationAnnotation] != rec.ID {
+			logger.Info("Scaling recommendation awaiting approval", "id", rec.ID, "direction", direction, "plan", plan)
+			return false, nil
+		}
+
+		approved := *rec
+		if err := r.patchCluster(ctx, cluster, func() {
+			delete(cluster.Annotations, approveRecommendationAnnotation)
+		}); err != nil {
+			return false, fmt.Errorf("failed to consume approval annotation: %w", err)
+		}
+		cluster.Status.Recommendation = nil
+		logger.Info("Scaling recommendation approved", "id", approved.ID, "direction", direction, "plan", plan)
+		r.recordNormal(cluster, "RecommendationApproved", "Recommendation %s approved, scaling %s to %d masters: %s",
+			approved.ID, direction, targetMasters, plan)
+		return true, nil
+	}
+
+	cluster.Status.Recommendation = &appv1.ScaleRecommendation{
+		ID:            rand.String(8),
+		Direction:     direction,
+		TargetMasters: targetMasters,
+		Reason:        reason,
+		Plan:          plan,
+		Since:         metav1.Now(),
+	}
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return false, fmt.Errorf("failed to record scaling recommendation: %w", err)
+	}
+	logger.Info("Scaling recommended", "id", cluster.Status.Recommendation.ID, "direction", direction, "targetMasters", targetMasters, "plan", plan)
+	r.recordNormal(cluster, "ScaleRecommended",
+		"Recommend scaling %s to %d masters (%s): %s. Approve with: kubectl annotate rediscluster %s %s=%s",
+		direction, targetMasters, plan, reason, cluster.Name, approveRecommendationAnnotation, cluster.Status.Recommendation.ID)
+	return false, nil
+}
+
+// clearRecommendation withdraws a pending recommendation once its scaling decision no longer holds.
+func (r *RedisClusterReconciler) clearRecommendation(ctx context.Context, cluster *appv1.RedisCluster) error {
+	if cluster.Status.Recommendation == nil {
+		return nil
+	}
+	log.FromContext(ctx).Info("Withdrawing scaling recommendation", "id", cluster.Status.Recommendation.ID)
+	r.recordNormal(cluster, "RecommendationWithdrawn", "Recommendation %s no longer applies", cluster.Status.Recommendation.ID)
+	cluster.Status.Recommendation = nil
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to clear scaling recommendation: %w", err)
+	}
+	return nil
+}
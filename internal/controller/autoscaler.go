@@ -3,7 +3,11 @@ package controller
 import (
 	"context"
 	"fmt"
-	"sort"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/prometheus/client_golang/api"
@@ -12,6 +16,8 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -28,20 +34,30 @@ type ClusterHealthStatus struct {
 
 // PodLoad represents CPU and memory metrics for a single Redis pod.
 type PodLoad struct {
-	PodName     string
-	CPUUsage    float64
-	MemoryUsage float64
+	PodName      string
+	CPUUsage     float64
+	MemoryUsage  float64
+	EvictionRate float64
+	// WarmingUp is set while the pod (or, with IncludeReplicasInMetrics, any pod of its shard)
+	// is within PodWarmupSeconds of a restart; its load is not used for scaling decisions.
+	WarmingUp bool
 }
 
 // handleAutoScaling is the main entry point for autoscaling logic.
-// It implements a state machine with four states:
+// It implements a state machine with five states:
 //   - IsDraining: Scale-down operation in progress
 //   - IsResharding: Scale-up operation in progress
+//   - IsRollingBack: Failed scaling operation being rolled back
 //   - IsProvisioningStandby: Adding new standby pods to cluster
+//   - IsRebalancing: Manually requested slot rebalance in progress
 //   - Monitoring: Normal operation, checking metrics for scaling decisions
 func (r *RedisClusterReconciler) handleAutoScaling(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	if err := r.setPausedCondition(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update Paused condition")
+	}
+
 	if cluster.Status.IsDraining {
 		logger.Info("Cluster is draining, checking drain job status")
 		return r.checkDrainStatus(ctx, cluster)
@@ -52,13 +68,77 @@ func (r *RedisClusterReconciler) handleAutoScaling(ctx context.Context, cluster
 		return r.checkReshardingStatus(ctx, cluster)
 	}
 
+	if cluster.Status.IsRollingBack {
+		logger.Info("Cluster is rolling back a failed scaling operation")
+		return r.checkRollbackStatus(ctx, cluster)
+	}
+
 	if cluster.Status.IsProvisioningStandby {
 		logger.Info("Cluster is provisioning standby, adding new pods to cluster")
 		return r.checkProvisioningStatus(ctx, cluster)
 	}
 
+	if cluster.Status.IsRebalancing {
+		logger.Info("Cluster is rebalancing slots, checking rebalance job status")
+		return r.checkRebalanceStatus(ctx, cluster)
+	}
+
+	if err := r.checkReplicaSync(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to check replica sync of newly activated master")
+	}
+
+	// Operations already in progress are driven above; a paused cluster starts no new ones.
+	if cluster.Spec.Paused {
+		logger.Info("Autoscaling paused, no new scaling decisions")
+		return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second}, nil
+	}
+
+	// A rebalance request is handled ahead of the metrics schedule, since annotating the
+	// cluster is what triggered this reconcile.
+	if rebalanceRequested(cluster) {
+		return r.handleRebalanceRequest(ctx, cluster)
+	}
+	if manualScaleRequested(cluster) {
+		return r.handleManualScaleRequest(ctx, cluster)
+	}
+
+	// Pod events trigger reconciles too (see podToRedisClusters). A standby that stopped running
+	// may have failed over to its replica, so re-detect it ahead of the metrics schedule.
+	if r.standbyNeedsRedetection(ctx, cluster) {
+		if err := r.checkAndUpdateStandbyPod(ctx, cluster); err != nil {
+			logger.Info("Standby re-detection pending", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
+	// Status writes re-trigger reconciliation; don't re-evaluate metrics before the scheduled check.
+	if next := cluster.Status.NextMetricsCheckTime; next != nil && time.Until(next.Time) > time.Second {
+		return ctrl.Result{RequeueAfter: time.Until(next.Time)}, nil
+	}
+
 	logger.Info("Cluster is stable, monitoring metrics for scaling decisions")
-	return r.monitorMetrics(ctx, cluster)
+	result, err := r.monitorMetrics(ctx, cluster)
+	r.recordNextMetricsCheck(ctx, cluster, result)
+	return result, err
+}
+
+// recordNextMetricsCheck stores when the next metrics evaluation will happen, so users can tell
+// "waiting for the next interval" apart from "not reconciling". It is cleared once a scaling
+// operation has started, since metrics are not evaluated until it completes.
+func (r *RedisClusterReconciler) recordNextMetricsCheck(ctx context.Context, cluster *appv1.RedisCluster, result ctrl.Result) {
+	var next *metav1.Time
+	if result.RequeueAfter > 0 && !isScaling(cluster) {
+		t := metav1.NewTime(time.Now().Add(result.RequeueAfter))
+		next = &t
+	}
+	if next == nil && cluster.Status.NextMetricsCheckTime == nil {
+		return
+	}
+
+	cluster.Status.NextMetricsCheckTime = next
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update next metrics check time")
+	}
 }
 
 // monitorMetrics queries Prometheus for CPU and memory metrics and makes scaling decisions.
@@ -81,29 +161,143 @@ func (r *RedisClusterReconciler) monitorMetrics(ctx context.Context, cluster *ap
 	podLoads, err := r.queryPodMetrics(ctx, cluster)
 	if err != nil {
 		logger.Error(err, "Failed to query pod metrics")
+		if statusErr := r.recordMetricsAvailability(ctx, cluster, err); statusErr != nil {
+			logger.Error(statusErr, "Failed to record metrics availability")
+		}
 		return ctrl.Result{RequeueAfter: requeueInterval}, err
 	}
 
 	if len(podLoads) == 0 {
-		logger.Info("No pod metrics available, skipping scaling check")
+		logger.Info("No pod metrics available, skipping scaling check; check that serviceMonitor.labels match the Prometheus serviceMonitorSelector")
+		if err := r.recordMetricsAvailability(ctx, cluster, fmt.Errorf("no pod metrics available")); err != nil {
+			logger.Error(err, "Failed to record metrics availability")
+		}
 		return ctrl.Result{RequeueAfter: requeueInterval}, nil
 	}
 
-	if shouldScaleUp, triggerPod, reason := r.checkScaleUpCondition(cluster, podLoads); shouldScaleUp {
-		return r.triggerScaleUp(ctx, cluster, triggerPod, reason)
+	// A partial view of the load may still justify a scale-up, but never a scale-down.
+	var metricsProblem error
+	if int32(len(podLoads)) < cluster.Spec.Masters {
+		metricsProblem = fmt.Errorf("metrics for only %d of %d active masters", len(podLoads), cluster.Spec.Masters)
+	}
+	if err := r.recordMetricsAvailability(ctx, cluster, metricsProblem); err != nil {
+		logger.Error(err, "Failed to record metrics availability")
+	}
+
+	shouldScaleUp, triggerPod, upReason := r.checkScaleUpCondition(cluster, podLoads)
+	shouldScaleDown, reason := r.checkScaleDownCondition(cluster, podLoads)
+	if err := r.recordThresholdSamples(ctx, cluster, shouldScaleUp, shouldScaleDown); err != nil {
+		logger.Error(err, "Failed to record threshold samples")
+	}
+
+	if shouldScaleUp {
+		if atMaxMasters(cluster) {
+			logger.Info("Refusing scale-up at maximum shard count", "reason", upReason, "maxMasters", cluster.Spec.MaxMasters)
+			r.recordWarning(cluster, "AtMaxMasters", "Scale-up refused at maximum shard count (%d masters): %s",
+				cluster.Spec.MaxMasters, upReason)
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		if required := stabilizationSamples(cluster, cluster.Spec.ScaleUpStabilizationSeconds); cluster.Status.ConsecutiveHighSamples < required {
+			logger.Info("Deferring scale-up until the breach is sustained", "reason", upReason,
+				"samples", cluster.Status.ConsecutiveHighSamples, "required", required)
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		if err := r.checkCooldownPeriod(ctx, cluster, scaleDirectionUp); err != nil {
+			logger.Info("Deferring scale-up", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		if err := r.checkNamespaceScalingSlot(ctx, cluster); err != nil {
+			logger.Info("Deferring scale-up", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		plan := fmt.Sprintf("move half of the slots of %s to standby %s", triggerPod.PodName, cluster.Status.StandbyPod)
+		if cluster.Spec.ScaleUpStrategy == appv1.ScaleUpAddShard {
+			plan = fmt.Sprintf("add a shard and rebalance slots across %d masters", cluster.Spec.Masters+1)
+		}
+		approved, err := r.awaitApproval(ctx, cluster, scaleDirectionUp, cluster.Spec.Masters+1, upReason, plan)
+		if err != nil || !approved {
+			return ctrl.Result{RequeueAfter: requeueInterval}, err
+		}
+		return r.triggerScaleUp(ctx, cluster, triggerPod, upReason)
 	}
 
-	if shouldScaleDown, reason := r.checkScaleDownCondition(cluster, podLoads); shouldScaleDown {
-		return r.triggerScaleDown(ctx, cluster, podLoads, reason)
+	idleTarget := cluster.Status.IdleConsolidationTarget
+	if inIdleConsolidation(cluster) {
+		shouldScaleDown = true
+		reason = fmt.Sprintf("Idle consolidation to %d masters", idleTarget)
+	} else if shouldScaleDown {
+		if idleTarget = idleConsolidationTarget(cluster, podLoads); idleTarget > 0 {
+			reason = fmt.Sprintf("%s; whole cluster idle, consolidating to %d masters", reason, idleTarget)
+		}
 	}
 
+	if shouldScaleDown {
+		if cluster.Status.MetricsUnavailableSince != nil {
+			logger.Info("Refusing scale-down while metrics are incomplete", "reason", reason,
+				"unavailableSince", cluster.Status.MetricsUnavailableSince.Time)
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		if required := stabilizationSamples(cluster, cluster.Spec.ScaleDownStabilizationSeconds); !inIdleConsolidation(cluster) && cluster.Status.ConsecutiveLowSamples < required {
+			logger.Info("Deferring scale-down until the condition is sustained", "reason", reason,
+				"samples", cluster.Status.ConsecutiveLowSamples, "required", required)
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		if err := r.checkCooldownPeriod(ctx, cluster, scaleDirectionDown); err != nil {
+			logger.Info("Deferring scale-down", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		if err := r.checkNamespaceScalingSlot(ctx, cluster); err != nil {
+			logger.Info("Deferring scale-down", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		plan, err := r.planScaleDown(ctx, cluster, podLoads)
+		if err != nil {
+			logger.Error(err, "Cannot plan scale-down")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		if err := checkScaleDownHeadroom(cluster, plan, podLoads); err != nil {
+			logger.Info("Refusing scale-down", "reason", err.Error())
+			if inIdleConsolidation(cluster) {
+				return ctrl.Result{RequeueAfter: requeueInterval}, r.endIdleConsolidation(ctx, cluster, err.Error())
+			}
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		approved, err := r.awaitApproval(ctx, cluster, scaleDirectionDown, cluster.Spec.Masters-1, reason, plan.String())
+		if err != nil || !approved {
+			return ctrl.Result{RequeueAfter: requeueInterval}, err
+		}
+		if !cluster.Spec.AutoScaleDryRun {
+			cluster.Status.IdleConsolidationTarget = idleTarget
+		}
+		return r.triggerScaleDown(ctx, cluster, plan, reason)
+	}
+
+	if err := r.clearRecommendation(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to clear scaling recommendation")
+	}
+	if cluster.Spec.AutoScaleDryRun {
+		if err := r.setLastDecision(ctx, cluster, dryRunNoScaling); err != nil {
+			logger.Error(err, "Failed to record dry-run decision")
+		}
+	}
+
+	observeDecision(cluster, decisionNone, "all pods within acceptable CPU and memory ranges")
 	logger.Info("All pods within acceptable CPU and memory ranges")
 	return ctrl.Result{RequeueAfter: requeueInterval}, nil
 }
 
-// queryPodMetrics queries Prometheus for CPU and memory usage of all active Redis master pods.
-// It returns a slice of PodLoad structs, excluding the standby pod.
+// queryPodMetrics returns the load of all active Redis master pods, excluding the standby pod,
+// from the metrics provider selected by MetricsSource, and records the query's latency.
 func (r *RedisClusterReconciler) queryPodMetrics(ctx context.Context, cluster *appv1.RedisCluster) ([]PodLoad, error) {
+	started := time.Now()
+	podLoads, err := r.metricsProvider(cluster).PodLoads(ctx, cluster)
+	observeMetricsQuery(cluster, started, err)
+	return podLoads, err
+}
+
+// queryPrometheusPodLoads queries Prometheus for CPU and memory usage, and the eviction rate when
+// EvictionRateThreshold is set, of all active Redis master pods.
+func (r *RedisClusterReconciler) queryPrometheusPodLoads(ctx context.Context, cluster *appv1.RedisCluster) ([]PodLoad, error) {
 	logger := log.FromContext(ctx)
 
 	promClient, err := api.NewClient(api.Config{Address: cluster.Spec.PrometheusURL})
@@ -122,9 +316,43 @@ func (r *RedisClusterReconciler) queryPodMetrics(ctx context.Context, cluster *a
 		return nil, err
 	}
 
+	// The eviction rate is an additional signal; without it CPU and memory still drive scaling.
+	var evictionMap map[string]float64
+	if cluster.Spec.EvictionRateThreshold > 0 {
+		evictionMap, err = r.queryEvictionMetrics(ctx, v1api, cluster)
+		if err != nil {
+			logger.Error(err, "Failed to query eviction rate, continuing without it")
+		}
+	}
+
+	return r.buildPodLoads(ctx, cluster, cpuMap, memoryMap, evictionMap)
+}
+
+// buildPodLoads combines per-pod usage maps into PodLoads, folding them by shard when
+// IncludeReplicasInMetrics is set and marking pods that are warming up. The standby pod, pods
+// without memory data, and pods of other clusters are skipped.
+func (r *RedisClusterReconciler) buildPodLoads(ctx context.Context, cluster *appv1.RedisCluster, cpuMap, memoryMap, evictionMap map[string]float64) ([]PodLoad, error) {
+	logger := log.FromContext(ctx)
+
+	cpuMap = clusterPodsOnly(ctx, cluster, cpuMap)
+	memoryMap = clusterPodsOnly(ctx, cluster, memoryMap)
+	evictionMap = clusterPodsOnly(ctx, cluster, evictionMap)
+
+	warmingMap, err := r.warmingUpPods(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Spec.IncludeReplicasInMetrics {
+		cpuMap = aggregateByShard(cluster, cpuMap)
+		memoryMap = aggregateByShard(cluster, memoryMap)
+		evictionMap = aggregateByShard(cluster, evictionMap)
+		warmingMap = aggregateByShard(cluster, warmingMap)
+	}
+
 	var podLoads []PodLoad
 	for podName, cpuUsage := range cpuMap {
-		if podName == cluster.Status.StandbyPod {
+		if isStandbyPod(cluster, podName) {
 			logger.Info("Skipping standby pod from metrics", "standbyPod", podName)
 			continue
 		}
@@ -136,34 +364,160 @@ func (r *RedisClusterReconciler) queryPodMetrics(ctx context.Context, cluster *a
 		}
 
 		podLoads = append(podLoads, PodLoad{
-			PodName:     podName,
-			CPUUsage:    cpuUsage,
-			MemoryUsage: memoryUsage,
+			PodName:      podName,
+			CPUUsage:     cpuUsage,
+			MemoryUsage:  memoryUsage,
+			EvictionRate: evictionMap[podName],
+			WarmingUp:    warmingMap[podName] > 0,
 		})
 
 		logger.Info("Pod metrics",
 			"pod", podName,
 			"cpu", fmt.Sprintf("%.2f%%", cpuUsage),
 			"memory", fmt.Sprintf("%.2f%%", memoryUsage),
+			"evictionRate", fmt.Sprintf("%.2f/s", evictionMap[podName]),
+			"warmingUp", warmingMap[podName] > 0,
 		)
 	}
 
 	return podLoads, nil
 }
 
-// queryCPUMetrics queries Prometheus for CPU usage percentage of Redis master pods.
+// podOrdinal returns the ordinal of podName if it is exactly "<Name>-<ordinal>", the name of a
+// pod of the cluster's StatefulSet. Cluster "foo" does not own "foo-bar-0", which belongs to
+// cluster "foo-bar", nor "foo-3x"; a misparsed ordinal would point slot migrations at the wrong
+// shard.
+func podOrdinal(cluster *appv1.RedisCluster, podName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(podName, cluster.Name+"-")
+	if !ok || suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+// isClusterPod reports whether podName is a pod of the cluster's StatefulSet.
+func isClusterPod(cluster *appv1.RedisCluster, podName string) bool {
+	_, ok := podOrdinal(cluster, podName)
+	return ok
+}
+
+// clusterPodsOnly returns usage without the pods that are not the cluster's own. The default
+// queries only match the cluster's pods, but a custom query template may match those of a
+// similarly named cluster, whose load must never drive this cluster's scaling.
+func clusterPodsOnly(ctx context.Context, cluster *appv1.RedisCluster, usage map[string]float64) map[string]float64 {
+	var filtered map[string]float64
+	for podName, value := range usage {
+		if !isClusterPod(cluster, podName) {
+			log.FromContext(ctx).Info("Ignoring metrics of a pod outside the cluster", "pod", podName)
+			continue
+		}
+		if filtered == nil {
+			filtered = make(map[string]float64, len(usage))
+		}
+		filtered[podName] = value
+	}
+	return filtered
+}
+
+// warmingUpPods returns the pods whose Redis container started less than PodWarmupSeconds ago,
+// mapped to 1 so they can be folded by shard like the usage maps.
+func (r *RedisClusterReconciler) warmingUpPods(ctx context.Context, cluster *appv1.RedisCluster) (map[string]float64, error) {
+	if cluster.Spec.PodWarmupSeconds <= 0 {
+		return nil, nil
+	}
+	warmup := time.Duration(cluster.Spec.PodWarmupSeconds) * time.Second
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	warming := make(map[string]float64)
+	for _, pod := range podList.Items {
+		started := redisStartTime(&pod)
+		if started == nil || time.Since(started.Time) < warmup {
+			warming[pod.Name] = 1
+		}
+	}
+	return warming, nil
+}
+
+// redisStartTime returns when the pod's Redis container last started, falling back to the pod's
+// start time, or nil if the pod has not started yet. A container restart resets the running
+// state's start time, so OOM recoveries are covered as well as rescheduled pods.
+func redisStartTime(pod *corev1.Pod) *metav1.Time {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == "redis" && status.State.Running != nil {
+			return &status.State.Running.StartedAt
+		}
+	}
+	return pod.Status.StartTime
+}
+
+// defaultCPUQueryTemplate and defaultMemoryQueryTemplate are used unless CpuQueryTemplate or
+// MemoryQueryTemplate is set. They match the series of a kube-prometheus-stack install. Memory is
+// the working set, which excludes reclaimable page cache, averaged over MemoryMetricWindow.
+const (
+	defaultCPUQueryTemplate = `rate(container_cpu_usage_seconds_total{container="redis", pod=~"^{{.Name}}-[0-9]+$", namespace="{{.Namespace}}", service="{{.KubeletService}}"{{.ClusterMatcher}}}[1m]) * 100
+		 {{.RoleFilter}}`
+
+	defaultMemoryQueryTemplate = `(
+		  sum(avg_over_time(container_memory_working_set_bytes{container="redis", pod=~"^{{.Name}}-[0-9]+$", namespace="{{.Namespace}}"{{.ClusterMatcher}}}[{{.MemoryWindow}}])) by (pod)
+		  /
+		  sum(kube_pod_container_resource_limits{resource="memory", pod=~"^{{.Name}}-[0-9]+$", namespace="{{.Namespace}}"{{.ClusterMatcher}}}) by (pod)
+		) * 100
+		{{.RoleFilter}}`
+)
+
+// renderMetricsQuery renders the user's query template, or fallback when none is set.
+func renderMetricsQuery(cluster *appv1.RedisCluster, field, userTemplate, fallback string) (string, error) {
+	text := userTemplate
+	if text == "" {
+		text = fallback
+	}
+	tmpl, err := template.New(field).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", field, err)
+	}
+
+	var query strings.Builder
+	vars := appv1.MetricsQueryVars{
+		Name:           cluster.Name,
+		Namespace:      cluster.Namespace,
+		KubeletService: cluster.Spec.KubeletServiceLabel,
+		ClusterMatcher: metricsClusterMatcher(cluster),
+		RoleFilter:     metricsRoleFilter(cluster),
+		MemoryWindow:   cluster.Spec.MemoryMetricWindow,
+	}
+	if err := tmpl.Execute(&query, vars); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", field, err)
+	}
+	return query.String(), nil
+}
+
+// oneLine collapses the whitespace of a multi-line query so it reads well in errors and events.
+func oneLine(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// queryCPUMetrics queries Prometheus for CPU usage percentage of Redis master pods,
+// or of all Redis pods when IncludeReplicasInMetrics is set.
 // Returns a map of pod name to CPU usage percentage.
 func (r *RedisClusterReconciler) queryCPUMetrics(ctx context.Context, v1api prometheusv1.API, cluster *appv1.RedisCluster) (map[string]float64, error) {
 	logger := log.FromContext(ctx)
 
-	cpuQuery := fmt.Sprintf(
-		`rate(container_cpu_usage_seconds_total{container="redis", pod=~"^%s-.*", namespace="%s", service="kps-kube-prometheus-stack-kubelet"}[1m]) * 100
-		 and on(pod) redis_instance_info{role="master"}`,
-		cluster.Name,
-		cluster.Namespace,
-	)
+	cpuQuery, err := renderMetricsQuery(cluster, "cpuQueryTemplate", cluster.Spec.CpuQueryTemplate, defaultCPUQueryTemplate)
+	if err != nil {
+		return nil, err
+	}
 
-	cpuResult, warnings, err := v1api.Query(ctx, cpuQuery, time.Now())
+	cpuResult, warnings, err := queryPrometheusWithRetry(ctx, v1api, cluster, cpuQuery)
 	if err != nil {
 		return nil, fmt.Errorf("prometheus CPU query failed: %w", err)
 	}
@@ -173,7 +527,7 @@ func (r *RedisClusterReconciler) queryCPUMetrics(ctx context.Context, v1api prom
 
 	cpuVec, ok := cpuResult.(model.Vector)
 	if !ok || cpuVec.Len() == 0 {
-		return nil, fmt.Errorf("no CPU metrics data available")
+		return nil, fmt.Errorf("no CPU metrics data available from query %s (check kubeletServiceLabel and metric labels)", oneLine(cpuQuery))
 	}
 
 	cpuMap := make(map[string]float64)
@@ -185,25 +539,18 @@ func (r *RedisClusterReconciler) queryCPUMetrics(ctx context.Context, v1api prom
 	return cpuMap, nil
 }
 
-// queryMemoryMetrics queries Prometheus for memory usage percentage of Redis master pods.
+// queryMemoryMetrics queries Prometheus for memory usage percentage of Redis master pods,
+// or of all Redis pods when IncludeReplicasInMetrics is set.
 // Returns a map of pod name to memory usage percentage.
 func (r *RedisClusterReconciler) queryMemoryMetrics(ctx context.Context, v1api prometheusv1.API, cluster *appv1.RedisCluster) (map[string]float64, error) {
 	logger := log.FromContext(ctx)
 
-	memoryQuery := fmt.Sprintf(
-		`(
-		  sum(container_memory_usage_bytes{container="redis", pod=~"^%s-.*", namespace="%s"}) by (pod)
-		  /
-		  sum(kube_pod_container_resource_limits{resource="memory", pod=~"^%s-.*", namespace="%s"}) by (pod)
-		) * 100
-		and on(pod) redis_instance_info{role="master"}`,
-		cluster.Name,
-		cluster.Namespace,
-		cluster.Name,
-		cluster.Namespace,
-	)
+	memoryQuery, err := renderMetricsQuery(cluster, "memoryQueryTemplate", cluster.Spec.MemoryQueryTemplate, defaultMemoryQueryTemplate)
+	if err != nil {
+		return nil, err
+	}
 
-	memoryResult, warnings, err := v1api.Query(ctx, memoryQuery, time.Now())
+	memoryResult, warnings, err := queryPrometheusWithRetry(ctx, v1api, cluster, memoryQuery)
 	if err != nil {
 		return nil, fmt.Errorf("prometheus memory query failed: %w", err)
 	}
@@ -213,7 +560,7 @@ func (r *RedisClusterReconciler) queryMemoryMetrics(ctx context.Context, v1api p
 
 	memoryVec, ok := memoryResult.(model.Vector)
 	if !ok || memoryVec.Len() == 0 {
-		return nil, fmt.Errorf("no memory metrics data available")
+		return nil, fmt.Errorf("no memory metrics data available from query %s", oneLine(memoryQuery))
 	}
 
 	memoryMap := make(map[string]float64)
@@ -225,19 +572,144 @@ func (r *RedisClusterReconciler) queryMemoryMetrics(ctx context.Context, v1api p
 	return memoryMap, nil
 }
 
+// queryEvictionMetrics queries Prometheus for the key eviction rate, in evictions per second,
+// of Redis master pods, or of all Redis pods when IncludeReplicasInMetrics is set.
+// Returns a map of pod name to eviction rate. Pods that evict nothing are reported with rate 0.
+func (r *RedisClusterReconciler) queryEvictionMetrics(ctx context.Context, v1api prometheusv1.API, cluster *appv1.RedisCluster) (map[string]float64, error) {
+	logger := log.FromContext(ctx)
+
+	evictionQuery := fmt.Sprintf(
+		`sum(rate(redis_evicted_keys_total{pod=~"^%s-[0-9]+$", namespace="%s"%s}[1m])) by (pod)
+		 %s`,
+		cluster.Name,
+		cluster.Namespace,
+		metricsClusterMatcher(cluster),
+		metricsRoleFilter(cluster),
+	)
+
+	evictionResult, warnings, err := queryPrometheusWithRetry(ctx, v1api, cluster, evictionQuery)
+	if err != nil {
+		return nil, fmt.Errorf("prometheus eviction query failed: %w", err)
+	}
+	if len(warnings) > 0 {
+		logger.Info("Prometheus eviction warnings", "warnings", warnings)
+	}
+
+	evictionVec, ok := evictionResult.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected eviction metrics result type %s", evictionResult.Type())
+	}
+
+	evictionMap := make(map[string]float64)
+	for _, sample := range evictionVec {
+		podName := string(sample.Metric["pod"])
+		evictionMap[podName] = float64(sample.Value)
+	}
+
+	return evictionMap, nil
+}
+
+const (
+	// prometheusRetryBackoff is the delay before the first retry of a failed Prometheus query;
+	// it doubles on every further attempt.
+	prometheusRetryBackoff = 500 * time.Millisecond
+
+	// prometheusRetryBudget caps the total time spent waiting between retries of one query, so a
+	// Prometheus that is down costs a few seconds of the reconcile rather than all of it.
+	prometheusRetryBudget = 3 * time.Second
+)
+
+// queryPrometheusWithRetry runs an instant query, retrying failed attempts up to
+// PrometheusQueryRetries times with exponential backoff within prometheusRetryBudget.
+// Only query errors are retried; an empty result is returned to the caller as is.
+func queryPrometheusWithRetry(ctx context.Context, v1api prometheusv1.API, cluster *appv1.RedisCluster, query string) (model.Value, prometheusv1.Warnings, error) {
+	logger := log.FromContext(ctx)
+
+	retries := cluster.PrometheusQueryRetryCount()
+	backoff := prometheusRetryBackoff
+	var waited time.Duration
+
+	for attempt := int32(0); ; attempt++ {
+		result, warnings, err := v1api.Query(ctx, query, time.Now())
+		if err == nil {
+			return result, warnings, nil
+		}
+		if attempt >= retries || waited+backoff > prometheusRetryBudget {
+			return nil, nil, err
+		}
+
+		logger.Info("Prometheus query failed, retrying", "attempt", attempt+1, "retries", retries, "backoff", backoff, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return nil, nil, err
+		case <-time.After(backoff):
+		}
+		waited += backoff
+		backoff *= 2
+	}
+}
+
+// metricsRoleFilter returns the PromQL clause restricting a query to master pods,
+// or an empty string when replica load should be included.
+func metricsRoleFilter(cluster *appv1.RedisCluster) string {
+	if cluster.Spec.IncludeReplicasInMetrics {
+		return ""
+	}
+	return fmt.Sprintf(`and on(pod) redis_instance_info{role="master", namespace="%s"%s}`,
+		cluster.Namespace, metricsClusterMatcher(cluster))
+}
+
+// metricsClusterMatcher returns an extra label matcher (with leading comma) that scopes a
+// selector to this physical cluster, or an empty string when no cluster label is configured.
+func metricsClusterMatcher(cluster *appv1.RedisCluster) string {
+	if cluster.Spec.MetricsClusterLabel == "" {
+		return ""
+	}
+	return fmt.Sprintf(`, %s=%q`, cluster.Spec.MetricsClusterLabel, cluster.Spec.MetricsClusterLabelValue)
+}
+
+// aggregateByShard folds per-pod usage into per-shard usage keyed by the shard's master pod.
+// Masters are at indices 0, (1+R), 2*(1+R), ... and their replicas follow them, so each pod maps to
+// the master at the start of its index block. A shard's usage is the highest usage of its members.
+func aggregateByShard(cluster *appv1.RedisCluster, usage map[string]float64) map[string]float64 {
+	shardSize := int(1 + cluster.Spec.ReplicasPerMaster)
+	shards := make(map[string]float64)
+	for podName, value := range usage {
+		podIndex, ok := podOrdinal(cluster, podName)
+		if !ok {
+			continue
+		}
+		masterPod := fmt.Sprintf("%s-%d", cluster.Name, podIndex-podIndex%shardSize)
+		if current, ok := shards[masterPod]; !ok || value > current {
+			shards[masterPod] = value
+		}
+	}
+	return shards
+}
+
 // checkScaleUpCondition determines if scale-up is needed.
-// Returns true if any pod exceeds CPU or memory thresholds, along with the triggering pod and reason.
+// Returns true if any pod exceeds the CPU or memory threshold of a signal selected by ScaleMetric,
+// or the eviction rate threshold, along with the triggering pod and reason.
+// Pods still warming up after a restart are ignored.
 func (r *RedisClusterReconciler) checkScaleUpCondition(cluster *appv1.RedisCluster, podLoads []PodLoad) (bool, PodLoad, string) {
-	highCPUThreshold := float64(cluster.Spec.CpuThreshold)
-	highMemoryThreshold := float64(cluster.Spec.MemoryThreshold)
+	// The busiest pod by the signal that drives scaling is relieved first.
+	load := func(pod PodLoad) float64 {
+		if cluster.Spec.ScaleMetric == appv1.ScaleMetricCPU {
+			return pod.CPUUsage
+		}
+		return pod.MemoryUsage
+	}
 
 	var triggerPod PodLoad
 	triggered := false
 
 	for _, pod := range podLoads {
-		if pod.CPUUsage > highCPUThreshold || pod.MemoryUsage > highMemoryThreshold {
+		if pod.WarmingUp {
+			continue
+		}
+		if cpuOverloaded(cluster, pod) || memoryOverloaded(cluster, pod) || isEvicting(cluster, pod) {
 			triggered = true
-			if triggerPod.PodName == "" || pod.MemoryUsage > triggerPod.MemoryUsage {
+			if triggerPod.PodName == "" || load(pod) > load(triggerPod) {
 				triggerPod = pod
 			}
 		}
@@ -248,12 +720,15 @@ func (r *RedisClusterReconciler) checkScaleUpCondition(cluster *appv1.RedisClust
 	}
 
 	var reason string
-	if triggerPod.CPUUsage > highCPUThreshold && triggerPod.MemoryUsage > highMemoryThreshold {
+	if cpuOverloaded(cluster, triggerPod) && memoryOverloaded(cluster, triggerPod) {
 		reason = fmt.Sprintf("CPU and Memory overloaded (CPU: %.2f%%, Memory: %.2f%%)",
 			triggerPod.CPUUsage, triggerPod.MemoryUsage)
-	} else if triggerPod.CPUUsage > highCPUThreshold {
+	} else if cpuOverloaded(cluster, triggerPod) {
 		reason = fmt.Sprintf("CPU overloaded (CPU: %.2f%%, Memory: %.2f%%)",
 			triggerPod.CPUUsage, triggerPod.MemoryUsage)
+	} else if isEvicting(cluster, triggerPod) && !memoryOverloaded(cluster, triggerPod) {
+		reason = fmt.Sprintf("Evicting keys (Evictions: %.2f/s, CPU: %.2f%%, Memory: %.2f%%)",
+			triggerPod.EvictionRate, triggerPod.CPUUsage, triggerPod.MemoryUsage)
 	} else {
 		reason = fmt.Sprintf("Memory overloaded (CPU: %.2f%%, Memory: %.2f%%)",
 			triggerPod.CPUUsage, triggerPod.MemoryUsage)
@@ -262,8 +737,123 @@ func (r *RedisClusterReconciler) checkScaleUpCondition(cluster *appv1.RedisClust
 	return true, triggerPod, reason
 }
 
+// scalesOnCPU and scalesOnMemory report whether ScaleMetric lets the signal drive scaling.
+func scalesOnCPU(cluster *appv1.RedisCluster) bool {
+	return cluster.Spec.ScaleMetric != appv1.ScaleMetricMemory
+}
+
+func scalesOnMemory(cluster *appv1.RedisCluster) bool {
+	return cluster.Spec.ScaleMetric != appv1.ScaleMetricCPU
+}
+
+// cpuOverloaded reports whether the pod exceeds CpuThreshold and CPU drives scaling.
+func cpuOverloaded(cluster *appv1.RedisCluster, pod PodLoad) bool {
+	return scalesOnCPU(cluster) && pod.CPUUsage > float64(cluster.Spec.CpuThreshold)
+}
+
+// memoryOverloaded reports whether the pod exceeds MemoryThreshold and memory drives scaling.
+func memoryOverloaded(cluster *appv1.RedisCluster, pod PodLoad) bool {
+	return scalesOnMemory(cluster) && pod.MemoryUsage > float64(cluster.Spec.MemoryThreshold)
+}
+
+// isUnderutilized reports whether the pod is below the low threshold of every signal that drives
+// scaling and evicts no keys.
+func isUnderutilized(cluster *appv1.RedisCluster, pod PodLoad) bool {
+	if scalesOnCPU(cluster) && pod.CPUUsage >= float64(cluster.Spec.CpuThresholdLow) {
+		return false
+	}
+	if scalesOnMemory(cluster) && pod.MemoryUsage >= float64(cluster.Spec.MemoryThresholdLow) {
+		return false
+	}
+	return pod.EvictionRate == 0
+}
+
+// stabilizationSamples returns how many consecutive MetricsQueryInterval polls must meet a scaling
+// condition to cover a stabilization window of the given length, or 0 when the window is unset.
+func stabilizationSamples(cluster *appv1.RedisCluster, windowSeconds int32) int32 {
+	interval := cluster.Spec.MetricsQueryInterval
+	if windowSeconds <= 0 {
+		return 0
+	}
+	if interval <= 0 {
+		return 1
+	}
+	return (windowSeconds + interval - 1) / interval
+}
+
+// recordThresholdSamples advances the consecutive breach counters for this poll, resetting a
+// counter whose condition no longer holds. A counter stays at zero while its stabilization window
+// is unset, so polls only write the status when stabilization is in use.
+func (r *RedisClusterReconciler) recordThresholdSamples(ctx context.Context, cluster *appv1.RedisCluster, high, low bool) error {
+	next := func(count, windowSeconds int32, breached bool) int32 {
+		if windowSeconds <= 0 || !breached {
+			return 0
+		}
+		return count + 1
+	}
+	nextHigh := next(cluster.Status.ConsecutiveHighSamples, cluster.Spec.ScaleUpStabilizationSeconds, high)
+	nextLow := next(cluster.Status.ConsecutiveLowSamples, cluster.Spec.ScaleDownStabilizationSeconds, low)
+	if nextHigh == cluster.Status.ConsecutiveHighSamples && nextLow == cluster.Status.ConsecutiveLowSamples {
+		return nil
+	}
+
+	cluster.Status.ConsecutiveHighSamples = nextHigh
+	cluster.Status.ConsecutiveLowSamples = nextLow
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update threshold sample counters: %w", err)
+	}
+	return nil
+}
+
+// atMaxMasters reports whether the cluster may not grow any further under MaxMasters.
+func atMaxMasters(cluster *appv1.RedisCluster) bool {
+	return cluster.Spec.MaxMasters > 0 && cluster.Spec.Masters >= cluster.Spec.MaxMasters
+}
+
+// setMaxMastersCondition records whether the cluster is capped by MaxMasters, persisting the
+// status only when the condition changes. Being capped does not fail the health check, since
+// scale-downs remain possible; the condition lets operators see why load no longer scales up.
+func (r *RedisClusterReconciler) setMaxMastersCondition(ctx context.Context, cluster *appv1.RedisCluster) error {
+	status, reason := metav1.ConditionFalse, "BelowMaxMasters"
+	message := fmt.Sprintf("%d masters, no maximum", cluster.Spec.Masters)
+	if cluster.Spec.MaxMasters > 0 {
+		message = fmt.Sprintf("%d of at most %d masters", cluster.Spec.Masters, cluster.Spec.MaxMasters)
+	}
+	if atMaxMasters(cluster) {
+		status, reason = metav1.ConditionTrue, "AtMaxMasters"
+	}
+
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionAtMaxMasters)
+	if current == nil && status == metav1.ConditionFalse {
+		return nil
+	}
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionAtMaxMasters,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update AtMaxMasters condition: %w", err)
+	}
+	return nil
+}
+
+// isEvicting reports whether the pod evicts keys faster than EvictionRateThreshold.
+func isEvicting(cluster *appv1.RedisCluster, pod PodLoad) bool {
+	threshold := cluster.Spec.EvictionRateThreshold
+	return threshold > 0 && pod.EvictionRate > float64(threshold)
+}
+
 // checkScaleDownCondition determines if scale-down is needed.
-// Returns true if there are at least 2 underutilized pods and we're above minimum masters.
+// Returns true if there are at least 2 underutilized pods (below the low threshold of every signal
+// selected by ScaleMetric) and we're above minimum masters.
+// Pods still warming up are not counted, since a restarted pod may not have reloaded its data yet.
 func (r *RedisClusterReconciler) checkScaleDownCondition(cluster *appv1.RedisCluster, podLoads []PodLoad) (bool, string) {
 	lowCPUThreshold := float64(cluster.Spec.CpuThresholdLow)
 	lowMemoryThreshold := float64(cluster.Spec.MemoryThresholdLow)
@@ -274,22 +864,91 @@ func (r *RedisClusterReconciler) checkScaleDownCondition(cluster *appv1.RedisClu
 
 	underutilizedCount := 0
 	for _, pod := range podLoads {
-		if pod.CPUUsage < lowCPUThreshold && pod.MemoryUsage < lowMemoryThreshold {
+		if !pod.WarmingUp && isUnderutilized(cluster, pod) {
 			underutilizedCount++
 		}
 	}
 
 	if underutilizedCount >= 2 {
-		reason := fmt.Sprintf("Scale-down triggered: %d underutilized pods (CPU < %.0f%%, Memory < %.0f%%)",
-			underutilizedCount, lowCPUThreshold, lowMemoryThreshold)
+		var limits []string
+		if scalesOnCPU(cluster) {
+			limits = append(limits, fmt.Sprintf("CPU < %.0f%%", lowCPUThreshold))
+		}
+		if scalesOnMemory(cluster) {
+			limits = append(limits, fmt.Sprintf("Memory < %.0f%%", lowMemoryThreshold))
+		}
+		reason := fmt.Sprintf("Scale-down triggered: %d underutilized pods (%s)",
+			underutilizedCount, strings.Join(limits, ", "))
 		return true, reason
 	}
 
 	return false, ""
 }
 
+// idleConsolidationTarget returns the master count an Eager consolidation should scale down to
+// when every active master is underutilized, or 0 when the cluster is not wholly idle or a single
+// scale-down already reaches the target. The target is the fewest masters that keep the average
+// usage of each signal selected by ScaleMetric below its scale-down threshold, so the consolidated
+// cluster does not immediately trigger a scale-up; it is never below MinMasters.
+func idleConsolidationTarget(cluster *appv1.RedisCluster, podLoads []PodLoad) int32 {
+	if cluster.Spec.ScaleDownAggressiveness != appv1.ScaleDownEager || int32(len(podLoads)) < cluster.Spec.Masters {
+		return 0
+	}
+
+	lowCPUThreshold := float64(cluster.Spec.CpuThresholdLow)
+	lowMemoryThreshold := float64(cluster.Spec.MemoryThresholdLow)
+	var totalCPU, totalMemory float64
+	for _, pod := range podLoads {
+		if pod.WarmingUp || !isUnderutilized(cluster, pod) {
+			return 0
+		}
+		totalCPU += pod.CPUUsage
+		totalMemory += pod.MemoryUsage
+	}
+
+	target := cluster.Spec.MinMasters
+	if needed := int32(math.Ceil(totalCPU / lowCPUThreshold)); scalesOnCPU(cluster) && needed > target {
+		target = needed
+	}
+	if needed := int32(math.Ceil(totalMemory / lowMemoryThreshold)); scalesOnMemory(cluster) && needed > target {
+		target = needed
+	}
+	if target >= cluster.Spec.Masters-1 {
+		return 0
+	}
+	return target
+}
+
+// inIdleConsolidation reports whether an Eager idle consolidation still has masters to remove.
+func inIdleConsolidation(cluster *appv1.RedisCluster) bool {
+	target := cluster.Status.IdleConsolidationTarget
+	return cluster.Spec.ScaleDownAggressiveness == appv1.ScaleDownEager && target > 0 && cluster.Spec.Masters > target
+}
+
+// endIdleConsolidation stops an idle consolidation before its target, returning the cluster to
+// regular load-driven scale-down decisions.
+func (r *RedisClusterReconciler) endIdleConsolidation(ctx context.Context, cluster *appv1.RedisCluster, reason string) error {
+	r.recordNormal(cluster, "IdleConsolidationStopped", "Stopped consolidating at %d masters (target %d): %s",
+		cluster.Spec.Masters, cluster.Status.IdleConsolidationTarget, reason)
+	cluster.Status.IdleConsolidationTarget = 0
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to clear idle consolidation target: %w", err)
+	}
+	return nil
+}
+
 // triggerScaleUp initiates a scale-up operation by activating the standby pod.
+// In AutoScaleDryRun mode it only reports the decision.
 func (r *RedisClusterReconciler) triggerScaleUp(ctx context.Context, cluster *appv1.RedisCluster, triggerPod PodLoad, reason string) (ctrl.Result, error) {
+	if cluster.Spec.AutoScaleDryRun {
+		plan := fmt.Sprintf("relieve %s", triggerPod.PodName)
+		observeDecision(cluster, scaleDirectionUp, reason)
+		return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second},
+			r.recordDryRunDecision(ctx, cluster, scaleDirectionUp, cluster.Spec.Masters+1, plan, reason)
+	}
+
+	beginOperation(cluster)
+	ctx = withOperationLogger(ctx, cluster)
 	logger := log.FromContext(ctx)
 
 	logger.Info("Triggering scale-up using standby pod",
@@ -300,55 +959,112 @@ func (r *RedisClusterReconciler) triggerScaleUp(ctx context.Context, cluster *ap
 
 	cluster.Status.IsResharding = true
 	cluster.Status.OverloadedPod = triggerPod.PodName
+	if cluster.Spec.ScaleUpStrategy == appv1.ScaleUpAddShard {
+		cluster.Status.ScaleUpTargetMasters = cluster.Spec.Masters + 1
+	} else {
+		cluster.Status.ScalingStandbySnapshot = cluster.Status.StandbyPod
+	}
+	cluster.Status.LastScaleDecision = fmt.Sprintf("scale-up of %s: %s", triggerPod.PodName, reason)
+	cluster.Status.ConsecutiveScaleDowns = 0
+	cluster.Status.IdleConsolidationTarget = 0
+	cluster.Status.ConsecutiveHighSamples = 0
+	cluster.Status.ConsecutiveLowSamples = 0
+	recordScalingEvent(cluster, scaleDirectionUp, scalingOutcomeTriggered, triggerPod.PodName, reason,
+		cluster.Spec.Masters, cluster.Spec.Masters+1)
+	r.recordKeyCountBefore(ctx, cluster)
 
 	if err := r.Status().Update(ctx, cluster); err != nil {
 		logger.Error(err, "Failed to update status to IsResharding")
 		return ctrl.Result{}, err
 	}
+	observeScaleOperation(cluster, scaleDirectionUp, reason)
+
+	if cluster.Status.ScaleUpTargetMasters > 0 {
+		r.recordNormal(cluster, "ScaleUpTriggered", "Adding shard %d to relieve %s: %s",
+			cluster.Status.ScaleUpTargetMasters, triggerPod.PodName, reason)
+	} else {
+		r.recordNormal(cluster, "ScaleUpTriggered", "Activating standby %s to relieve %s: %s",
+			cluster.Status.StandbyPod, triggerPod.PodName, reason)
+	}
 
 	logger.Info("Successfully triggered scale-up", "currentMasters", cluster.Spec.Masters)
 	return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 }
 
-// triggerScaleDown initiates a scale-down operation by draining the highest-index active master.
-func (r *RedisClusterReconciler) triggerScaleDown(ctx context.Context, cluster *appv1.RedisCluster, podLoads []PodLoad, reason string) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
+// scaleDownPlan describes which master a scale-down empties and where its slots go.
+type scaleDownPlan struct {
+	// PodToDrain is the highest-index active master, which ends up empty and becomes the standby.
+	PodToDrain string
+	DestPod1   string
+	DestPod2   string
+	// RotatePod is the least-loaded master drained instead in LowestLoad mode; PodToDrain's
+	// slots are rotated into it.
+	RotatePod string
+	// SimpleRemove rebalances PodToDrain's slots across every remaining master, so no
+	// destinations are planned.
+	SimpleRemove bool
+}
+
+// String describes the plan for events and recommendations.
+func (p scaleDownPlan) String() string {
+	if p.SimpleRemove {
+		return fmt.Sprintf("remove %s, rebalancing its slots across the remaining masters", p.PodToDrain)
+	}
+	dests := strings.TrimSuffix(p.DestPod1+","+p.DestPod2, ",")
+	if p.RotatePod != "" {
+		return fmt.Sprintf("drain %s into %s and rotate %s into it", p.RotatePod, dests, p.PodToDrain)
+	}
+	return fmt.Sprintf("drain %s into %s", p.PodToDrain, dests)
+}
 
-	logger.Info("Scale-down triggered", "reason", reason)
+// planScaleDown selects the master to drain and the destinations for its slots. Destinations are
+// the masters ranked lowest by rankDestinations, i.e. underweight in both memory and slots.
+// The highest-index active master is drained so the StatefulSet can shrink from the top.
+// In LowestLoad mode the least-loaded master is drained instead and the highest-index master is
+// rotated into it, so the highest-index master still ends up empty and becomes the new standby.
+// Masters listed in DrainExclusions are never drained or used as destinations. If the
+// highest-index master is excluded, the next-highest eligible master is drained and the excluded
+// master's slots are rotated into it intact, since the StatefulSet can only shrink from the top.
+// SimpleRemove cannot rotate, so it refuses to plan when the highest-index master is excluded.
+func (r *RedisClusterReconciler) planScaleDown(ctx context.Context, cluster *appv1.RedisCluster, podLoads []PodLoad) (scaleDownPlan, error) {
+	logger := log.FromContext(ctx)
 
 	highestActiveMasterIndex := (cluster.Spec.Masters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
 	highestIndexPod := fmt.Sprintf("%s-%d", cluster.Name, highestActiveMasterIndex)
 
+	excluded := make(map[string]bool, len(cluster.Spec.DrainExclusions))
+	for _, podName := range cluster.Spec.DrainExclusions {
+		excluded[podName] = true
+	}
+
 	// Filter out replica pods - only select master pods as drain destinations
 	// Master pods are at indices: 0, (1+R), 2*(1+R), 3*(1+R), etc.
 	var masterLoads []PodLoad
 	for _, load := range podLoads {
 		// Extract pod index from pod name (e.g., "redis-cluster-6" -> 6)
-		var podIndex int
-		_, err := fmt.Sscanf(load.PodName, cluster.Name+"-%d", &podIndex)
-		if err != nil {
-			logger.Error(err, "Failed to parse pod index", "podName", load.PodName)
+		podIndex, ok := podOrdinal(cluster, load.PodName)
+		if !ok {
+			logger.Info("Skipping pod whose name is not <cluster>-<ordinal>", "podName", load.PodName)
 			continue
 		}
 
 		// Check if this is a master pod (index divisible by 1+ReplicasPerMaster)
 		replicasPerMaster := int(cluster.Spec.ReplicasPerMaster)
-		if podIndex%(1+replicasPerMaster) == 0 {
+		if podIndex%(1+replicasPerMaster) == 0 && !excluded[load.PodName] {
 			masterLoads = append(masterLoads, load)
 		}
 	}
 
 	if len(masterLoads) < 2 {
-		logger.Error(fmt.Errorf("not enough master pods for scale-down"), "Only have master pods",
-			"count", len(masterLoads))
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return scaleDownPlan{}, fmt.Errorf("not enough master pods for scale-down: have %d eligible (%d excluded from draining)",
+			len(masterLoads), len(cluster.Spec.DrainExclusions))
 	}
 
-	sortedLoads := make([]PodLoad, len(masterLoads))
-	copy(sortedLoads, masterLoads)
-	sort.Slice(sortedLoads, func(i, j int) bool {
-		return sortedLoads[i].MemoryUsage < sortedLoads[j].MemoryUsage
-	})
+	slots, err := r.masterSlotCounts(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Failed to read slot ownership, ranking scale-down destinations by memory only")
+	}
+	sortedLoads := rankDestinations(masterLoads, slots)
 
 	lowestUtil1 := sortedLoads[0].PodName
 	lowestUtil2 := ""
@@ -361,45 +1077,205 @@ func (r *RedisClusterReconciler) triggerScaleDown(ctx context.Context, cluster *
 		"standbyPod", cluster.Status.StandbyPod,
 		"lowestUtil1", lowestUtil1,
 		"lowestUtil2", lowestUtil2,
+		"excluded", cluster.Spec.DrainExclusions,
 	)
 
-	var destPod1, destPod2 string
-	if highestIndexPod != lowestUtil1 && highestIndexPod != lowestUtil2 {
-		destPod1 = lowestUtil1
-		destPod2 = lowestUtil2
+	plan := scaleDownPlan{PodToDrain: highestIndexPod, SimpleRemove: cluster.Spec.ScaleDownStrategy == appv1.ScaleDownSimpleRemove}
+	if plan.SimpleRemove && excluded[highestIndexPod] {
+		return scaleDownPlan{}, fmt.Errorf("highest-index master %s is excluded from draining and SimpleRemove cannot rotate another master into it", highestIndexPod)
+	}
+	if plan.SimpleRemove {
+		logger.Info("Strategy: Rebalance highest index across the remaining masters", "from", highestIndexPod)
+		return plan, nil
+	}
+	if excluded[highestIndexPod] {
+		// Drain the next-highest eligible master to the least-loaded other eligible masters, then
+		// rotate the excluded highest-index master's slots into it.
+		for index := highestActiveMasterIndex - (1 + cluster.Spec.ReplicasPerMaster); index >= 0; index -= 1 + cluster.Spec.ReplicasPerMaster {
+			podName := fmt.Sprintf("%s-%d", cluster.Name, index)
+			if slices.ContainsFunc(masterLoads, func(load PodLoad) bool { return load.PodName == podName }) {
+				plan.RotatePod = podName
+				break
+			}
+		}
+		for _, load := range sortedLoads {
+			if load.PodName == plan.RotatePod {
+				continue
+			}
+			if plan.DestPod1 == "" {
+				plan.DestPod1 = load.PodName
+			} else {
+				plan.DestPod2 = load.PodName
+				break
+			}
+		}
+		logger.Info("Strategy: Highest index is excluded. Drain next-highest master and rotate highest index into it",
+			"drain", plan.RotatePod, "to1", plan.DestPod1, "to2", plan.DestPod2, "rotateFrom", highestIndexPod)
+	} else if cluster.Spec.ScaleDownTarget == appv1.ScaleDownTargetLowestLoad && highestIndexPod != lowestUtil1 && len(sortedLoads) > 2 {
+		// Drain the least-loaded master to the next least-loaded masters other than the
+		// highest-index one, whose slots are then rotated into the emptied master.
+		plan.RotatePod = lowestUtil1
+		for _, load := range sortedLoads[1:] {
+			if load.PodName == highestIndexPod {
+				continue
+			}
+			if plan.DestPod1 == "" {
+				plan.DestPod1 = load.PodName
+			} else {
+				plan.DestPod2 = load.PodName
+				break
+			}
+		}
+		logger.Info("Strategy: Drain lowest-load master and rotate highest index into it",
+			"drain", plan.RotatePod, "to1", plan.DestPod1, "to2", plan.DestPod2, "rotateFrom", highestIndexPod)
+	} else if highestIndexPod != lowestUtil1 && highestIndexPod != lowestUtil2 {
+		plan.DestPod1 = lowestUtil1
+		plan.DestPod2 = lowestUtil2
 		logger.Info("Strategy: Split load from highest index to two low-util pods",
-			"from", highestIndexPod, "to1", destPod1, "to2", destPod2)
+			"from", highestIndexPod, "to1", plan.DestPod1, "to2", plan.DestPod2)
 	} else {
 		if highestIndexPod == lowestUtil1 {
-			destPod1 = lowestUtil2
+			plan.DestPod1 = lowestUtil2
 		} else {
-			destPod1 = lowestUtil1
+			plan.DestPod1 = lowestUtil1
 		}
 		logger.Info("Strategy: Highest index is low-util. Moving all load to single pod",
-			"from", highestIndexPod, "to", destPod1)
+			"from", highestIndexPod, "to", plan.DestPod1)
+	}
+
+	if plan.DestPod1 == "" {
+		return scaleDownPlan{}, fmt.Errorf("no eligible master to receive the slots of %s", plan.PodToDrain)
+	}
+	return plan, nil
+}
+
+// checkScaleDownHeadroom estimates the memory usage of every master receiving slots under the plan
+// and returns an error if any would be left with less than MinFreeMemoryPercentAfterScaleDown free.
+// Slots are split evenly between destinations, so each receives an equal share of the drained
+// master's usage; in a rotation the emptied master takes over all of PodToDrain's usage. A
+// SimpleRemove plan has no destinations and spreads the usage over every remaining master.
+// Usage is a percentage of each pod's memory limit, so equal limits across pods are assumed.
+func checkScaleDownHeadroom(cluster *appv1.RedisCluster, plan scaleDownPlan, podLoads []PodLoad) error {
+	minFree := float64(cluster.Spec.MinFreeMemoryPercentAfterScaleDown)
+	if minFree == 0 {
+		return nil
+	}
+
+	usage := make(map[string]float64, len(podLoads))
+	for _, load := range podLoads {
+		usage[load.PodName] = load.MemoryUsage
+	}
+
+	source := plan.PodToDrain
+	if plan.RotatePod != "" {
+		source = plan.RotatePod
+	}
+	dests := []string{plan.DestPod1}
+	if plan.DestPod2 != "" {
+		dests = append(dests, plan.DestPod2)
+	}
+	if plan.SimpleRemove {
+		dests = remainingMasters(cluster, plan.PodToDrain, podLoads)
+		if len(dests) == 0 {
+			return fmt.Errorf("no master left to receive the slots of %s", plan.PodToDrain)
+		}
+	}
+
+	projected := make(map[string]float64)
+	share := usage[source] / float64(len(dests))
+	for _, dest := range dests {
+		projected[dest] = usage[dest] + share
+	}
+	if plan.RotatePod != "" {
+		projected[plan.RotatePod] = usage[plan.PodToDrain]
+	}
+
+	for pod, memory := range projected {
+		if free := 100 - memory; free < minFree {
+			return fmt.Errorf("scale-down would leave %s with %.1f%% memory free (minimum %.0f%%)", pod, free, minFree)
+		}
+	}
+	return nil
+}
+
+// remainingMasters returns the active masters in podLoads other than podToDrain, the masters a
+// SimpleRemove scale-down rebalances its slots across.
+func remainingMasters(cluster *appv1.RedisCluster, podToDrain string, podLoads []PodLoad) []string {
+	shardSize := int(1 + cluster.Spec.ReplicasPerMaster)
+	var pods []string
+	for _, load := range podLoads {
+		index, ok := podOrdinal(cluster, load.PodName)
+		if ok && index%shardSize == 0 && index < int(cluster.Spec.Masters)*shardSize && load.PodName != podToDrain {
+			pods = append(pods, load.PodName)
+		}
+	}
+	return pods
+}
+
+// triggerScaleDown initiates a scale-down operation that carries out the given plan.
+// In AutoScaleDryRun mode it only reports the decision.
+func (r *RedisClusterReconciler) triggerScaleDown(ctx context.Context, cluster *appv1.RedisCluster, plan scaleDownPlan, reason string) (ctrl.Result, error) {
+	if cluster.Spec.AutoScaleDryRun {
+		observeDecision(cluster, scaleDirectionDown, reason)
+		return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second},
+			r.recordDryRunDecision(ctx, cluster, scaleDirectionDown, cluster.Spec.Masters-1, plan.String(), reason)
 	}
 
+	beginOperation(cluster)
+	ctx = withOperationLogger(ctx, cluster)
+	logger := log.FromContext(ctx)
+
+	logger.Info("Scale-down triggered", "reason", reason, "plan", plan.String())
+
 	cluster.Status.IsDraining = true
-	cluster.Status.PodToDrain = highestIndexPod
-	cluster.Status.DrainDestPod1 = destPod1
-	cluster.Status.DrainDestPod2 = destPod2
+	cluster.Status.PodToDrain = plan.PodToDrain
+	cluster.Status.DrainDestPod1 = plan.DestPod1
+	cluster.Status.DrainDestPod2 = plan.DestPod2
+	cluster.Status.DrainRotatePod = plan.RotatePod
+	cluster.Status.ScalingStandbySnapshot = cluster.Status.StandbyPod
+	cluster.Status.LastScaleDecision = fmt.Sprintf("scale-down of %s: %s", plan.PodToDrain, reason)
+	cluster.Status.ConsecutiveHighSamples = 0
+	cluster.Status.ConsecutiveLowSamples = 0
+	recordScalingEvent(cluster, scaleDirectionDown, scalingOutcomeTriggered, plan.PodToDrain, reason,
+		cluster.Spec.Masters, cluster.Spec.Masters-1)
+	r.recordKeyCountBefore(ctx, cluster)
 
 	if err := r.Status().Update(ctx, cluster); err != nil {
 		logger.Error(err, "Failed to update status to IsDraining")
 		return ctrl.Result{}, err
 	}
+	observeScaleOperation(cluster, scaleDirectionDown, reason)
+
+	r.recordNormal(cluster, "ScaleDownTriggered", "Scale-down plan: %s: %s", plan, reason)
 
 	logger.Info("Successfully triggered scale-down")
 	return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 }
 
 // isClusterHealthyForScaling performs comprehensive health checks before allowing scaling operations.
-// It checks cooldown period, pod count, pod readiness, standby detection, and job status.
+// After recording whether MaxMasters caps scale-ups, clusterHealthForScaling checks, in order:
+//  1. the scale retry limit
+//  2. the cooldown period
+//  3. that every pod is running and ready
+//  4. duplicate node entries, which are forgotten unless they still own slots
+//  5. split-brain, unexpected masters and the standby invariant
+//  6. standby detection and slot coverage
+//  7. that no Job is running, no replica sync is pending and no scaling operation holds the lock
 func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context, cluster *appv1.RedisCluster) ClusterHealthStatus {
+	return r.clusterHealthForScaling(ctx, cluster, true)
+}
+
+// clusterHealthForScaling performs the checks of isClusterHealthyForScaling, skipping the
+// cooldown period unless checkCooldown is set.
+func (r *RedisClusterReconciler) clusterHealthForScaling(ctx context.Context, cluster *appv1.RedisCluster, checkCooldown bool) ClusterHealthStatus {
 	logger := log.FromContext(ctx)
 	requeueInterval := time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second
 
-	if err := r.checkCooldownPeriod(cluster); err != nil {
+	if err := r.setMaxMastersCondition(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update AtMaxMasters condition")
+	}
+
+	if err := r.checkScaleRetries(ctx, cluster); err != nil {
 		return ClusterHealthStatus{
 			IsHealthy:    false,
 			Reason:       err.Error(),
@@ -407,6 +1283,16 @@ func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context,
 		}
 	}
 
+	if checkCooldown {
+		if err := r.checkCooldownPeriod(ctx, cluster, ""); err != nil {
+			return ClusterHealthStatus{
+				IsHealthy:    false,
+				Reason:       err.Error(),
+				RequeueAfter: requeueInterval,
+			}
+		}
+	}
+
 	if err := r.checkPodCount(ctx, cluster); err != nil {
 		return ClusterHealthStatus{
 			IsHealthy:    false,
@@ -415,6 +1301,34 @@ func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context,
 		}
 	}
 
+	if err := r.forgetDuplicateNodes(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to check for duplicate node entries")
+	}
+
+	if err := r.verifyNoSplitBrain(ctx, cluster); err != nil {
+		return ClusterHealthStatus{
+			IsHealthy:    false,
+			Reason:       err.Error(),
+			RequeueAfter: requeueInterval,
+		}
+	}
+
+	if err := r.verifyNoUnexpectedMasters(ctx, cluster); err != nil {
+		return ClusterHealthStatus{
+			IsHealthy:    false,
+			Reason:       err.Error(),
+			RequeueAfter: requeueInterval,
+		}
+	}
+
+	if err := r.verifyStandbyInvariant(ctx, cluster); err != nil {
+		return ClusterHealthStatus{
+			IsHealthy:    false,
+			Reason:       err.Error(),
+			RequeueAfter: requeueInterval,
+		}
+	}
+
 	if err := r.checkAndUpdateStandbyPod(ctx, cluster); err != nil {
 		return ClusterHealthStatus{
 			IsHealthy:    false,
@@ -423,6 +1337,14 @@ func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context,
 		}
 	}
 
+	if err := r.verifySlotCoverage(ctx, cluster); err != nil {
+		return ClusterHealthStatus{
+			IsHealthy:    false,
+			Reason:       err.Error(),
+			RequeueAfter: requeueInterval,
+		}
+	}
+
 	if err := r.checkNoJobsRunning(ctx, cluster); err != nil {
 		return ClusterHealthStatus{
 			IsHealthy:    false,
@@ -431,7 +1353,15 @@ func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context,
 		}
 	}
 
-	if cluster.Status.IsDraining || cluster.Status.IsResharding {
+	if cluster.Status.ReplicaSyncPendingPod != "" {
+		return ClusterHealthStatus{
+			IsHealthy:    false,
+			Reason:       fmt.Sprintf("replicas of %s are still catching up", cluster.Status.ReplicaSyncPendingPod),
+			RequeueAfter: requeueInterval,
+		}
+	}
+
+	if cluster.Status.IsDraining || cluster.Status.IsResharding || cluster.Status.IsRollingBack {
 		return ClusterHealthStatus{
 			IsHealthy:    false,
 			Reason:       "Cluster is locked in scaling state",
@@ -440,7 +1370,7 @@ func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context,
 	}
 
 	logger.Info("Cluster health check passed - safe to scale",
-		"pods", (cluster.Spec.Masters+1)*(1+cluster.Spec.ReplicasPerMaster),
+		"pods", desiredPodCount(cluster),
 		"standbyPod", cluster.Status.StandbyPod,
 		"timeSinceLastScale", func() string {
 			if cluster.Status.LastScaleTime != nil {
@@ -457,15 +1387,67 @@ func (r *RedisClusterReconciler) isClusterHealthyForScaling(ctx context.Context,
 	}
 }
 
-// checkCooldownPeriod verifies that enough time has passed since the last scaling operation.
-func (r *RedisClusterReconciler) checkCooldownPeriod(cluster *appv1.RedisCluster) error {
+// consolidationSettlePeriod is how long aggressive consolidation waits after a scale-down before
+// evaluating the next one, so the CPU rate window reflects the load after the removal.
+const consolidationSettlePeriod = time.Minute
+
+// isConsolidating reports whether the cluster is in a run of aggressive scale-downs, during which
+// further scale-downs only wait consolidationSettlePeriod instead of the full cooldown.
+func isConsolidating(cluster *appv1.RedisCluster) bool {
+	aggressive := cluster.Spec.ScaleDownAggressiveness == appv1.ScaleDownAggressive ||
+		cluster.Spec.ScaleDownAggressiveness == appv1.ScaleDownEager
+	return aggressive && cluster.Status.ConsecutiveScaleDowns > 0
+}
+
+// Scaling directions, as passed to checkCooldownPeriod and awaitApproval.
+const (
+	scaleDirectionUp   = "up"
+	scaleDirectionDown = "down"
+)
+
+// checkCooldownPeriod verifies that enough time has passed since the last scaling operation for a
+// scale in the given direction: ScaleUpCooldown before a scale-up and ScaleDownCooldown before a
+// scale-down. While consolidating aggressively a scale-down only waits consolidationSettlePeriod,
+// and during an idle consolidation not at all. An empty direction passes once either window has
+// elapsed; the health check uses it before the direction is known.
+func (r *RedisClusterReconciler) checkCooldownPeriod(ctx context.Context, cluster *appv1.RedisCluster, direction string) error {
+	switch direction {
+	case scaleDirectionUp:
+		return r.checkCooldownElapsed(ctx, cluster, time.Duration(cluster.ScaleUpCooldown())*time.Second)
+	case scaleDirectionDown:
+		if inIdleConsolidation(cluster) {
+			return nil
+		}
+		cooldown := time.Duration(cluster.ScaleDownCooldown()) * time.Second
+		if isConsolidating(cluster) && consolidationSettlePeriod < cooldown {
+			cooldown = consolidationSettlePeriod
+		}
+		return r.checkCooldownElapsed(ctx, cluster, cooldown)
+	}
+
+	if err := r.checkCooldownPeriod(ctx, cluster, scaleDirectionUp); err == nil {
+		return nil
+	}
+	return r.checkCooldownPeriod(ctx, cluster, scaleDirectionDown)
+}
+
+// checkCooldownElapsed verifies that cooldown has passed since the last scaling operation.
+// A LastScaleTime in the future (clock skew or a manual status edit) is treated as elapsed,
+// since it would otherwise block scaling indefinitely.
+func (r *RedisClusterReconciler) checkCooldownElapsed(ctx context.Context, cluster *appv1.RedisCluster, cooldown time.Duration) error {
 	if cluster.Status.LastScaleTime == nil {
 		return nil
 	}
 
-	cooldown := time.Duration(cluster.Spec.ScaleCooldownSeconds) * time.Second
 	timeSinceLastScale := time.Since(cluster.Status.LastScaleTime.Time)
 
+	if timeSinceLastScale < 0 {
+		log.FromContext(ctx).Info("LastScaleTime is in the future (clock skew?), treating cooldown as elapsed",
+			"lastScaleTime", cluster.Status.LastScaleTime.Time,
+			"skew", (-timeSinceLastScale).String())
+		return nil
+	}
+
 	if timeSinceLastScale < cooldown {
 		return fmt.Errorf("scale cooldown active (%s remaining)", (cooldown - timeSinceLastScale).String())
 	}
@@ -477,7 +1459,7 @@ func (r *RedisClusterReconciler) checkCooldownPeriod(cluster *appv1.RedisCluster
 func (r *RedisClusterReconciler) checkPodCount(ctx context.Context, cluster *appv1.RedisCluster) error {
 	logger := log.FromContext(ctx)
 
-	expectedPods := (cluster.Spec.Masters + 1) * (1 + cluster.Spec.ReplicasPerMaster)
+	expectedPods := desiredPodCount(cluster)
 	podList := &corev1.PodList{}
 
 	if err := r.List(ctx, podList,
@@ -549,7 +1531,7 @@ func (r *RedisClusterReconciler) checkAndUpdateStandbyPod(ctx context.Context, c
 	return nil
 }
 
-// checkNoJobsRunning verifies that no reshard or drain jobs are currently active.
+// checkNoJobsRunning verifies that no reshard, drain, or rollback jobs are currently active.
 func (r *RedisClusterReconciler) checkNoJobsRunning(ctx context.Context, cluster *appv1.RedisCluster) error {
 	if err := r.checkJobStatus(ctx, cluster.Name+"-reshard", cluster.Namespace); err != nil {
 		return err
@@ -559,6 +1541,18 @@ func (r *RedisClusterReconciler) checkNoJobsRunning(ctx context.Context, cluster
 		return err
 	}
 
+	if err := r.checkJobStatus(ctx, cluster.Name+"-rollback", cluster.Namespace); err != nil {
+		return err
+	}
+
+	if err := r.checkJobStatus(ctx, cluster.Name+"-rebalance", cluster.Namespace); err != nil {
+		return err
+	}
+
+	if err := r.checkJobStatus(ctx, cluster.Name+"-remove-masters", cluster.Namespace); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// bootstrapLayoutAnnotation records on the bootstrap Job the layout its script was built for.
+const bootstrapLayoutAnnotation = "cache.example.com/bootstrap-layout"
+
+// bootstrapLayout describes the pod layout the bootstrap Job creates the cluster for.
+func bootstrapLayout(cluster *appv1.RedisCluster) string {
+	return fmt.Sprintf("masters=%d,replicasPerMaster=%d,standbyCount=%d,standbyReplicasPerMaster=%d",
+		cluster.Spec.Masters, cluster.Spec.ReplicasPerMaster, cluster.Spec.StandbyCount, cluster.StandbyReplicaCount())
+}
+
+// cancelStaleBootstrap deletes a bootstrap Job built for a different layout than the current spec,
+// for example after Masters was edited while the cluster was still forming. The Job's script
+// carries the old pod addresses, so it would never produce the cluster the StatefulSet now holds.
+// The Job is deleted in the foreground so none of its pods still runs when the next Job starts,
+// and it reports true while a stale Job exists; handleBootstrap then creates a fresh one from the
+// current spec, resetting the pods the stale one may already have clustered.
+func (r *RedisClusterReconciler) cancelStaleBootstrap(ctx context.Context, cluster *appv1.RedisCluster) (bool, error) {
+	logger := log.FromContext(ctx)
+	jobName := cluster.Name + "-bootstrap"
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get bootstrap job: %w", err)
+	}
+
+	if job.DeletionTimestamp != nil {
+		logger.Info("Waiting for stale bootstrap job to be deleted", "job", jobName)
+		return true, nil
+	}
+
+	// Jobs created before the layout was recorded are left to finish.
+	built, desired := job.Annotations[bootstrapLayoutAnnotation], bootstrapLayout(cluster)
+	if built == "" || built == desired {
+		return false, nil
+	}
+
+	logger.Info("Spec changed during bootstrap, restarting", "job", jobName, "built", built, "desired", desired)
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete stale bootstrap job: %w", err)
+	}
+	r.recordWarning(cluster, "BootstrapRestarted", "Spec changed during bootstrap (%s, now %s), restarting job %s", built, desired, jobName)
+	return true, nil
+}
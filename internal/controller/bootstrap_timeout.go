@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// bootstrapTimedOut reports whether the bootstrap Job was stopped by its deadline or has run past
+// it. The deadline is the Job's own ActiveDeadlineSeconds, BootstrapTimeoutSeconds unless
+// JobConfig.Bootstrap overrides it, so a Job the API server has not failed yet is caught as well.
+func bootstrapTimedOut(cluster *appv1.RedisCluster, job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue &&
+			condition.Reason == batchv1.JobReasonDeadlineExceeded {
+			return true
+		}
+	}
+
+	deadline := time.Duration(cluster.Spec.BootstrapTimeoutSeconds) * time.Second
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		deadline = time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+	}
+	started := job.CreationTimestamp.Time
+	if job.Status.StartTime != nil {
+		started = job.Status.StartTime.Time
+	}
+	return deadline > 0 && time.Since(started) > deadline
+}
+
+// failBootstrap sets the BootstrapFailed condition for a bootstrap Job that timed out. The
+// condition records the generation it was set at, and handleBootstrap makes no further attempt
+// until resumeFailedBootstrap sees the spec edited.
+func (r *RedisClusterReconciler) failBootstrap(ctx context.Context, cluster *appv1.RedisCluster, jobName string) error {
+	message := fmt.Sprintf("Bootstrap job %s did not finish within its deadline; check its logs, then edit the RedisCluster to retry", jobName)
+	log.FromContext(ctx).Info("Bootstrap timed out, stopping until the spec is edited", "job", jobName)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionBootstrapFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DeadlineExceeded",
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	r.recordWarning(cluster, appv1.ConditionBootstrapFailed, "%s", message)
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to set BootstrapFailed condition: %w", err)
+	}
+	return nil
+}
+
+// resumeFailedBootstrap holds bootstrap while the BootstrapFailed condition is True. An edit of the
+// spec since the condition was set is taken as the manual intervention it waits for: the timed-out
+// Job is deleted and the condition cleared, so the next reconcile starts a fresh bootstrap on reset
+// pods.
+func (r *RedisClusterReconciler) resumeFailedBootstrap(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, bool, error) {
+	logger := log.FromContext(ctx)
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionBootstrapFailed)
+	if condition.ObservedGeneration == cluster.Generation {
+		logger.Info("Bootstrap stopped after a timeout, edit the RedisCluster to retry")
+		// Requeued so NeedsAttention is raised once the failure outlasts the alert threshold.
+		return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.DegradedAlertThresholdSeconds) * time.Second}, true, nil
+	}
+
+	jobName := cluster.Name + "-bootstrap"
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: cluster.Namespace}}
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, true, fmt.Errorf("failed to delete timed-out bootstrap job: %w", err)
+	}
+
+	logger.Info("Spec edited, retrying bootstrap after a timeout", "job", jobName)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionBootstrapFailed,
+		Status:             metav1.ConditionFalse,
+		Reason:             "SpecEdited",
+		Message:            "Bootstrap retried after a spec edit",
+		ObservedGeneration: cluster.Generation,
+	})
+	r.recordNormal(cluster, "BootstrapRetried", "Spec edited, retrying bootstrap")
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return ctrl.Result{}, true, fmt.Errorf("failed to clear BootstrapFailed condition: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: 5 * time.Second}, true, nil
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// clusterHealthCheckDue reports whether the periodic health sweep is enabled and
+// ClusterHealthCheckIntervalSeconds have passed since it last ran.
+func clusterHealthCheckDue(cluster *appv1.RedisCluster) bool {
+	if cluster.Spec.ClusterHealthCheckIntervalSeconds <= 0 {
+		return false
+	}
+	if cluster.Status.LastClusterHealthCheck == nil {
+		return true
+	}
+	interval := time.Duration(cluster.Spec.ClusterHealthCheckIntervalSeconds) * time.Second
+	return time.Since(cluster.Status.LastClusterHealthCheck.Time) >= interval
+}
+
+// runClusterHealthCheck sweeps the Redis cluster for drift that no Kubernetes event reveals: a
+// cluster_state other than ok, slots without a live master, or masters claiming the same slots.
+// Problems are reported with a ClusterDriftDetected event (split-brain also through its
+// condition), and with AutoFixCluster set a state or coverage problem is repaired with
+// redis-cli --cluster fix. Reconcile only runs on the elected leader when leader election is
+// enabled, so operator replicas never sweep or fix the same cluster concurrently.
+func (r *RedisClusterReconciler) runClusterHealthCheck(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	now := metav1.Now()
+	cluster.Status.LastClusterHealthCheck = &now
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to record cluster health check: %w", err)
+	}
+
+	entryPod, _, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	fixable := false
+	info, err := r.execRedisCLI(ctx, cluster.Namespace, entryPod, "cluster", "info")
+	if err != nil {
+		return fmt.Errorf("failed to query cluster info from %s: %w", entryPod, err)
+	}
+	if state := redis.ParseInfo(info)["cluster_state"]; state != "ok" {
+		problems = append(problems, fmt.Sprintf("cluster_state is %q on %s", state, entryPod))
+		fixable = true
+	}
+	if err := r.verifySlotCoverage(ctx, cluster); err != nil {
+		problems = append(problems, err.Error())
+		fixable = true
+	}
+	if err := r.verifyNoSplitBrain(ctx, cluster); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) == 0 {
+		logger.Info("Cluster health check passed")
+		return nil
+	}
+
+	logger.Info("Cluster health check found drift", "problems", problems)
+	r.recordWarning(cluster, "ClusterDriftDetected", "Cluster health check: %s", strings.Join(problems, "; "))
+	if !fixable || !cluster.Spec.AutoFixCluster {
+		return nil
+	}
+
+	output, err := r.execRedisCLI(ctx, cluster.Namespace, entryPod,
+		"--cluster", "fix", fmt.Sprintf("127.0.0.1:%d", redisPort(cluster)), "--cluster-yes")
+	if err != nil {
+		r.recordWarning(cluster, "ClusterFixFailed", "redis-cli --cluster fix on %s failed: %v", entryPod, err)
+		return fmt.Errorf("cluster fix failed: %w", err)
+	}
+	logger.Info("Ran cluster fix", "pod", entryPod, "output", lastBytes(output, maxFailureEventBytes))
+	r.recordNormal(cluster, "ClusterFixed", "Ran redis-cli --cluster fix on %s", entryPod)
+	return nil
+}
@@ -0,0 +1,356 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// ensureClusterMember idempotently joins a pod to the Redis cluster, optionally as a replica of
+// masterPodName. Membership is checked by node ID (CLUSTER MYID on the pod itself) rather than by
+// IP, so a retried join never adds a second entry for the same address. Reconciles are serialized,
+// so the check-and-add cannot race with another join from the operator.
+// It returns an error while the join is still propagating; the caller should requeue.
+func (r *RedisClusterReconciler) ensureClusterMember(ctx context.Context, cluster *appv1.RedisCluster, podName, masterPodName string) error {
+	logger := log.FromContext(ctx)
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Name: podName, Namespace: cluster.Namespace}, pod); err != nil {
+		return fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+	if pod.Status.PodIP == "" {
+		return fmt.Errorf("pod %s has no IP yet", podName)
+	}
+
+	nodeID, err := r.clusterMyID(ctx, cluster.Namespace, podName)
+	if err != nil {
+		return err
+	}
+
+	entryPod, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	var member *redis.ClusterNode
+	for i := range nodes {
+		if nodes[i].ID == nodeID {
+			member = &nodes[i]
+			break
+		}
+	}
+
+	if member == nil {
+		logger.Info("Joining pod to cluster", "pod", podName, "ip", pod.Status.PodIP, "via", entryPod)
+		if _, err := r.execRedisCLI(ctx, cluster.Namespace, entryPod, "cluster", "meet", pod.Status.PodIP, fmt.Sprintf("%d", redisPort(cluster))); err != nil {
+			return fmt.Errorf("CLUSTER MEET %s from %s failed: %w", podName, entryPod, err)
+		}
+		return fmt.Errorf("pod %s joined, waiting for gossip to propagate", podName)
+	}
+
+	if masterPodName == "" {
+		return nil
+	}
+
+	masterID, err := r.clusterMyID(ctx, cluster.Namespace, masterPodName)
+	if err != nil {
+		return err
+	}
+	if member.MasterID == masterID {
+		return nil
+	}
+
+	logger.Info("Attaching replica to master", "pod", podName, "master", masterPodName)
+	output, err := r.execRedisCLI(ctx, cluster.Namespace, podName, "cluster", "replicate", masterID)
+	if err != nil {
+		return fmt.Errorf("CLUSTER REPLICATE on %s failed: %w", podName, err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(output), "OK") {
+		return fmt.Errorf("CLUSTER REPLICATE on %s returned: %s", podName, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// clusterMyID returns the Redis cluster node ID of the given pod.
+func (r *RedisClusterReconciler) clusterMyID(ctx context.Context, namespace, podName string) (string, error) {
+	output, err := r.execRedisCLI(ctx, namespace, podName, "cluster", "myid")
+	if err != nil {
+		return "", fmt.Errorf("failed to get node ID of %s: %w", podName, err)
+	}
+	nodeID := strings.TrimSpace(output)
+	if nodeID == "" {
+		return "", fmt.Errorf("empty node ID returned by %s", podName)
+	}
+	return nodeID, nil
+}
+
+// forgetDuplicateNodes removes stale node entries that share an address with a live pod.
+// A pod that restarts without its nodes.conf rejoins under a new ID, and a retried add-node can
+// register an address twice; the old IDs linger as ghosts. The live ID for an address is the one
+// the pod at that address reports for itself, and every other ID for the address is forgotten.
+// As in reconcileClusterMembership, a stale entry still owning slots is kept and only reported:
+// forgetting it would leave its slots unassigned.
+func (r *RedisClusterReconciler) forgetDuplicateNodes(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	byAddr := make(map[string][]redis.ClusterNode)
+	for _, node := range nodes {
+		addr := fmt.Sprintf("%s:%d", node.IP, node.Port)
+		byAddr[addr] = append(byAddr[addr], node)
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	podByIP := make(map[string]string)
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			podByIP[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	for _, entries := range byAddr {
+		if len(entries) < 2 {
+			continue
+		}
+		podName, ok := podByIP[entries[0].IP]
+		if !ok {
+			continue
+		}
+		liveID, err := r.clusterMyID(ctx, cluster.Namespace, podName)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.ID == liveID {
+				continue
+			}
+			if entry.Slots > 0 {
+				logger.Info("Not forgetting duplicate node entry that still owns slots",
+					"pod", podName, "staleID", entry.ID, "liveID", liveID, "slots", entry.Slots)
+				r.recordWarning(cluster, "DuplicateNodeOwnsSlots",
+					"Stale node %s duplicating %s (%s) still owns %d slots; move them before it can be forgotten",
+					entry.ID, podName, entry.IP, entry.Slots)
+				continue
+			}
+			logger.Info("Forgetting duplicate node entry", "pod", podName, "staleID", entry.ID, "liveID", liveID)
+			r.forgetNodeEverywhere(ctx, podList.Items, entry.ID)
+			r.recordWarning(cluster, "DuplicateNodeForgotten", "Forgot stale node %s duplicating %s (%s)", entry.ID, podName, entry.IP)
+		}
+	}
+
+	return nil
+}
+
+// forgetNodeEverywhere sends CLUSTER FORGET for nodeID to every running pod. A node that any
+// member still knows is gossiped back in, so the forget must reach all of them.
+func (r *RedisClusterReconciler) forgetNodeEverywhere(ctx context.Context, pods []corev1.Pod, nodeID string) {
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if _, err := r.execRedisCLI(ctx, pod.Namespace, pod.Name, "cluster", "forget", nodeID); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to forget node", "pod", pod.Name, "nodeID", nodeID)
+		}
+	}
+}
+
+// reconcileClusterMembership forgets failed node entries (flagged fail or noaddr) whose address no
+// pod of the cluster holds. A pod that restarts with a new IP leaves its old entry behind, and only
+// the drain job used to clean those up. An entry still owning slots is kept: forgetting it would
+// drop the slots, and failover or a human has to move them first. Failed entries sharing an
+// address with a live pod are duplicates, which forgetDuplicateNodes handles.
+func (r *RedisClusterReconciler) reconcileClusterMembership(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	_, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	podIPs := make(map[string]bool)
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podIPs[pod.Status.PodIP] = true
+		}
+	}
+
+	for _, node := range nodes {
+		if !node.HasFlag("fail") && !node.HasFlag("noaddr") {
+			continue
+		}
+		if node.IP != "" && podIPs[node.IP] {
+			continue
+		}
+		if node.Slots > 0 {
+			logger.Info("Not forgetting failed node that still owns slots", "nodeID", node.ID, "ip", node.IP, "slots", node.Slots)
+			continue
+		}
+		logger.Info("Forgetting failed node", "nodeID", node.ID, "ip", node.IP, "flags", strings.Join(node.Flags, ","))
+		r.forgetNodeEverywhere(ctx, podList.Items, node.ID)
+		r.recordWarning(cluster, "FailedNodeForgotten", "Forgot failed node %s (%s) that no pod holds", node.ID, node.IP)
+	}
+	return nil
+}
+
+// expectedMembers returns the pods the layout expects in the cluster, each mapped to the master
+// pod it replicates ("" for masters): every pod of the active shards, and the master and
+// StandbyReplicasPerMaster replicas of each standby shard.
+func expectedMembers(cluster *appv1.RedisCluster) map[string]string {
+	shardSize := 1 + cluster.Spec.ReplicasPerMaster
+	members := make(map[string]string)
+	addShard := func(masterIndex, replicas int32) {
+		masterPod := fmt.Sprintf("%s-%d", cluster.Name, masterIndex)
+		members[masterPod] = ""
+		for i := int32(1); i <= replicas; i++ {
+			members[fmt.Sprintf("%s-%d", cluster.Name, masterIndex+i)] = masterPod
+		}
+	}
+	for shard := int32(0); shard < cluster.Spec.Masters; shard++ {
+		addShard(shard*shardSize, cluster.Spec.ReplicasPerMaster)
+	}
+	for standby := int32(0); standby < cluster.Spec.StandbyCount; standby++ {
+		addShard(standbyMasterIndex(cluster, standby), cluster.StandbyReplicaCount())
+	}
+	return members
+}
+
+// verifyClusterMembership compares the CLUSTER NODES table with the pods the layout expects and
+// sets the MembershipMismatch condition when they differ. Ghost entries (addresses no expected pod
+// holds) pile up from flaky scaling between the reactive cleanups of the drain script, and a pod
+// can silently drop out of the cluster. With RepairMembership set, failed ghosts are forgotten and
+// missing pods are joined; ghosts that still respond are only reported.
+func (r *RedisClusterReconciler) verifyClusterMembership(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	_, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	members := expectedMembers(cluster)
+	expectedIPs := make(map[string]bool)
+	for _, pod := range podList.Items {
+		if _, ok := members[pod.Name]; ok && pod.Status.PodIP != "" {
+			expectedIPs[pod.Status.PodIP] = true
+		}
+	}
+	nodeIPs := make(map[string]bool)
+	var ghosts []redis.ClusterNode
+	for _, node := range nodes {
+		nodeIPs[node.IP] = true
+		if !expectedIPs[node.IP] {
+			ghosts = append(ghosts, node)
+		}
+	}
+	var missing []string
+	for _, pod := range podList.Items {
+		if _, ok := members[pod.Name]; ok && pod.Status.PodIP != "" && !nodeIPs[pod.Status.PodIP] {
+			missing = append(missing, pod.Name)
+		}
+	}
+
+	if len(nodes) == len(members) && len(ghosts) == 0 && len(missing) == 0 {
+		return r.setMembershipCondition(ctx, cluster, metav1.ConditionFalse, "MembershipMatches",
+			fmt.Sprintf("CLUSTER NODES lists the %d expected pods", len(members)))
+	}
+
+	var problems []string
+	for _, ghost := range ghosts {
+		problems = append(problems, fmt.Sprintf("ghost %s (%s:%d, %s)", ghost.ID, ghost.IP, ghost.Port, strings.Join(ghost.Flags, ",")))
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ","))
+	}
+	message := fmt.Sprintf("CLUSTER NODES lists %d nodes, expected %d", len(nodes), len(members))
+	if len(problems) > 0 {
+		message += ": " + strings.Join(problems, "; ")
+	}
+	if err := r.setMembershipCondition(ctx, cluster, metav1.ConditionTrue, "MembershipMismatch", message); err != nil {
+		return err
+	}
+
+	if !cluster.Spec.RepairMembership {
+		return nil
+	}
+
+	if err := r.forgetDuplicateNodes(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to forget duplicate node entries")
+	}
+	for _, ghost := range ghosts {
+		if !ghost.IsFailed() {
+			continue
+		}
+		logger.Info("Forgetting ghost node", "nodeID", ghost.ID, "ip", ghost.IP)
+		r.forgetNodeEverywhere(ctx, podList.Items, ghost.ID)
+		r.recordNormal(cluster, "GhostNodeForgotten", "Forgot failed node %s (%s) that no pod holds", ghost.ID, ghost.IP)
+	}
+	for _, podName := range missing {
+		logger.Info("Joining pod missing from the cluster", "pod", podName)
+		if err := r.ensureClusterMember(ctx, cluster, podName, members[podName]); err != nil {
+			logger.Info("Pod not yet rejoined", "pod", podName, "reason", err.Error())
+		}
+	}
+	return nil
+}
+
+// setMembershipCondition records the MembershipMismatch condition, persisting the status only
+// when the condition status or reason changes.
+func (r *RedisClusterReconciler) setMembershipCondition(ctx context.Context, cluster *appv1.RedisCluster, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionMembershipMismatch)
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+	if current == nil && status == metav1.ConditionFalse {
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionMembershipMismatch,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if status == metav1.ConditionTrue {
+		r.recordWarning(cluster, appv1.ConditionMembershipMismatch, "%s", message)
+	} else {
+		r.recordNormal(cluster, "MembershipRestored", "%s", message)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update membership condition: %w", err)
+	}
+	return nil
+}
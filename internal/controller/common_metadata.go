@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"maps"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// withCommonLabels returns CommonLabels merged with labels, the operator's own labels winning on
+// a shared key, so a common label never changes what a selector matches. labels is not modified.
+func withCommonLabels(cluster *appv1.RedisCluster, labels map[string]string) map[string]string {
+	return mergeMetadata(cluster.Spec.CommonLabels, labels)
+}
+
+// withCommonAnnotations returns CommonAnnotations merged with annotations, the operator's own
+// annotations winning on a shared key. annotations is not modified.
+func withCommonAnnotations(cluster *appv1.RedisCluster, annotations map[string]string) map[string]string {
+	return mergeMetadata(cluster.Spec.CommonAnnotations, annotations)
+}
+
+// jobAnnotations returns the annotations for a Job created by the operator.
+func jobAnnotations(cluster *appv1.RedisCluster) map[string]string {
+	return withCommonAnnotations(cluster, nil)
+}
+
+// mergeMetadata returns a new map of common overlaid with own, or nil when both are empty.
+func mergeMetadata(common, own map[string]string) map[string]string {
+	if len(common) == 0 && len(own) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(common)+len(own))
+	maps.Copy(merged, common)
+	maps.Copy(merged, own)
+	return merged
+}
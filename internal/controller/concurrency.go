@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// isScaling reports whether the cluster has a scaling operation in flight.
+func isScaling(cluster *appv1.RedisCluster) bool {
+	return cluster.Status.IsResharding || cluster.Status.IsDraining ||
+		cluster.Status.IsRollingBack || cluster.Status.IsProvisioningStandby ||
+		cluster.Status.IsRebalancing
+}
+
+// scalingSlotGrace is how long a slot reserved in scalingSlots counts on its own. It covers the
+// time until the holder's status update showing the operation reaches the informer cache.
+const scalingSlotGrace = 30 * time.Second
+
+// scalingSlots holds the namespace scaling slots taken by this operator process, keyed by namespace
+// and cluster name, with the time each was taken. Reconciles read other clusters from the informer
+// cache, which can lag their status updates, so without them two clusters reconciled back to back
+// could both see the last slot free.
+var scalingSlots = struct {
+	sync.Mutex
+	taken map[string]map[string]time.Time
+}{taken: make(map[string]map[string]time.Time)}
+
+// checkNamespaceScalingSlot enforces MaxConcurrentScalingInNamespace and takes a slot for the
+// cluster when one is free. A cluster holds a slot while its status shows an operation in flight,
+// which survives operator restarts without separate bookkeeping, or for scalingSlotGrace after
+// taking it in scalingSlots, which covers the cache lag. Both are counted under one mutex, so two
+// clusters cannot both take the last slot. The strictest limit set by any cluster in the namespace
+// applies to all of them. When the limit is reached, the ScalingDeferred condition is set and an
+// error returned.
+func (r *RedisClusterReconciler) checkNamespaceScalingSlot(ctx context.Context, cluster *appv1.RedisCluster) error {
+	clusterList := &appv1.RedisClusterList{}
+	if err := r.List(ctx, clusterList, client.InNamespace(cluster.Namespace)); err != nil {
+		return fmt.Errorf("failed to list RedisClusters: %w", err)
+	}
+
+	limit := namespaceScalingLimit(cluster, clusterList.Items)
+	if limit == 0 {
+		releaseScalingSlot(cluster)
+		return r.setScalingDeferredCondition(ctx, cluster, metav1.ConditionFalse, "SlotAvailable", "Scaling is not limited")
+	}
+
+	scaling := takeScalingSlot(cluster, clusterList.Items, limit)
+	if len(scaling) >= limit {
+		message := fmt.Sprintf("%d of %d concurrent scaling operations in use by %s",
+			len(scaling), limit, strings.Join(scaling, ", "))
+		if err := r.setScalingDeferredCondition(ctx, cluster, metav1.ConditionTrue, "NamespaceLimitReached", message); err != nil {
+			return err
+		}
+		return fmt.Errorf("namespace scaling limit reached: %s", message)
+	}
+
+	return r.setScalingDeferredCondition(ctx, cluster, metav1.ConditionFalse, "SlotAvailable",
+		fmt.Sprintf("%d of %d concurrent scaling operations in use", len(scaling)+1, limit))
+}
+
+// namespaceScalingLimit returns the lowest non-zero MaxConcurrentScalingInNamespace of the cluster
+// and the others in its namespace, or 0 when none of them sets a limit. Clusters being deleted no
+// longer constrain the others.
+func namespaceScalingLimit(cluster *appv1.RedisCluster, clusters []appv1.RedisCluster) int {
+	limit := int(cluster.Spec.MaxConcurrentScalingInNamespace)
+	for i := range clusters {
+		if !clusters[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		if other := int(clusters[i].Spec.MaxConcurrentScalingInNamespace); other > 0 && (limit == 0 || other < limit) {
+			limit = other
+		}
+	}
+	return limit
+}
+
+// takeScalingSlot returns the other clusters in the namespace that hold a scaling slot and, when
+// fewer than limit do, takes one for the cluster. A cluster asking for a slot has no operation in
+// flight, so its own earlier reservation is dropped first, as are reservations past
+// scalingSlotGrace whose holder no longer scales or no longer exists. A cluster being deleted holds
+// no slot: its finalizer cancels the operation Jobs, even though its status may still show one.
+func takeScalingSlot(cluster *appv1.RedisCluster, clusters []appv1.RedisCluster, limit int) []string {
+	scalingSlots.Lock()
+	defer scalingSlots.Unlock()
+
+	taken := scalingSlots.taken[cluster.Namespace]
+	if taken == nil {
+		taken = make(map[string]time.Time)
+		scalingSlots.taken[cluster.Namespace] = taken
+	}
+	delete(taken, cluster.Name)
+
+	listed := make(map[string]bool, len(clusters))
+	var scaling []string
+	for i := range clusters {
+		other := &clusters[i]
+		listed[other.Name] = true
+		if other.Name == cluster.Name {
+			continue
+		}
+		if !other.DeletionTimestamp.IsZero() {
+			delete(taken, other.Name)
+			continue
+		}
+		takenAt, reserved := taken[other.Name]
+		fresh := reserved && time.Since(takenAt) < scalingSlotGrace
+		if reserved && !fresh && !isScaling(other) {
+			delete(taken, other.Name)
+		}
+		if isScaling(other) || fresh {
+			scaling = append(scaling, other.Name)
+		}
+	}
+	for name := range taken {
+		if !listed[name] {
+			delete(taken, name)
+		}
+	}
+
+	if len(scaling) < limit {
+		taken[cluster.Name] = time.Now()
+	}
+	return scaling
+}
+
+// releaseScalingSlot drops the cluster's reservation in scalingSlots, e.g. when it is deleted.
+func releaseScalingSlot(cluster *appv1.RedisCluster) {
+	scalingSlots.Lock()
+	defer scalingSlots.Unlock()
+	delete(scalingSlots.taken[cluster.Namespace], cluster.Name)
+}
+
+// setScalingDeferredCondition records the ScalingDeferred condition, persisting the status only
+// when the condition status or reason changes.
+func (r *RedisClusterReconciler) setScalingDeferredCondition(ctx context.Context, cluster *appv1.RedisCluster, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionScalingDeferred)
+	if current == nil && status == metav1.ConditionFalse {
+		return nil
+	}
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionScalingDeferred,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if status == metav1.ConditionTrue {
+		r.recordNormal(cluster, "ScalingDeferred", "%s", message)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update ScalingDeferred condition: %w", err)
+	}
+	return nil
+}
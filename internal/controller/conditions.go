@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// degradedConditionTypes are the conditions that indicate the cluster is degraded while True.
+var degradedConditionTypes = []string{
+	appv1.ConditionStandbyInvariantViolated,
+	appv1.ConditionKeyspaceIntegrityViolated,
+	appv1.ConditionStandbyProvisioningFailed,
+	appv1.ConditionSplitBrainDetected,
+	appv1.ConditionMembershipMismatch,
+	appv1.ConditionUnexpectedMasters,
+	appv1.ConditionScaleRetriesExhausted,
+	appv1.ConditionMetricsDegraded,
+	appv1.ConditionBootstrapFailed,
+}
+
+// evaluateNeedsAttention sets the NeedsAttention condition once any degradation condition has been
+// True for longer than DegradedAlertThresholdSeconds, and clears it once all have recovered.
+// On the transition to True it emits a Warning event with a stable, key=value formatted message
+// that alerting pipelines can match on.
+func (r *RedisClusterReconciler) evaluateNeedsAttention(ctx context.Context, cluster *appv1.RedisCluster) error {
+	threshold := time.Duration(cluster.Spec.DegradedAlertThresholdSeconds) * time.Second
+
+	var sustained []string
+	var longest time.Duration
+	for _, conditionType := range degradedConditionTypes {
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, conditionType)
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			continue
+		}
+		degradedFor := time.Since(condition.LastTransitionTime.Time)
+		if degradedFor < threshold {
+			continue
+		}
+		sustained = append(sustained, conditionType)
+		if degradedFor > longest {
+			longest = degradedFor
+		}
+	}
+
+	desired := metav1.Condition{
+		Type:               appv1.ConditionNeedsAttention,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotDegraded",
+		Message:            "No sustained degradation",
+		ObservedGeneration: cluster.Generation,
+	}
+	if len(sustained) > 0 {
+		desired.Status = metav1.ConditionTrue
+		desired.Reason = "SustainedDegradation"
+		desired.Message = fmt.Sprintf("%s degraded for %s (threshold %s)",
+			strings.Join(sustained, ","), longest.Round(time.Second), threshold)
+	} else if meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionNeedsAttention) == nil {
+		// Nothing to report and nothing to clear.
+		return nil
+	}
+
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionNeedsAttention)
+	if current != nil && current.Status == desired.Status && current.Reason == desired.Reason {
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, desired)
+	if desired.Status == metav1.ConditionTrue {
+		log.FromContext(ctx).Info("Cluster needs attention", "conditions", sustained, "degradedFor", longest.Round(time.Second))
+		r.recordWarning(cluster, appv1.ConditionNeedsAttention,
+			"severity=critical cluster=%s/%s conditions=%s degradedForSeconds=%d thresholdSeconds=%d",
+			cluster.Namespace, cluster.Name, strings.Join(sustained, ","),
+			int64(longest.Seconds()), cluster.Spec.DegradedAlertThresholdSeconds)
+	} else {
+		r.recordNormal(cluster, "DegradationResolved", "cluster=%s/%s recovered", cluster.Namespace, cluster.Name)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update NeedsAttention condition: %w", err)
+	}
+	return nil
+}
+
+// syncLifecycleConditions derives the Bootstrapped, Scaling, and Degraded conditions from the
+// status flags and degradation conditions, and sets Ready to False until the cluster is
+// bootstrapped. Once bootstrapped, Ready is owned by checkReplicaSync. It also refreshes the
+// status summary fields. It reports whether anything changed; the caller is responsible for
+// persisting the status.
+func syncLifecycleConditions(cluster *appv1.RedisCluster) bool {
+	changed := false
+	set := func(conditionType string, status metav1.ConditionStatus, reason, message string) {
+		current := meta.FindStatusCondition(cluster.Status.Conditions, conditionType)
+		if current != nil && current.Status == status && current.Reason == reason {
+			return
+		}
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: cluster.Generation,
+		})
+		changed = true
+	}
+
+	if cluster.Status.Initialized {
+		set(appv1.ConditionBootstrapped, metav1.ConditionTrue, "ClusterInitialized", "Cluster is initialized")
+		if ready := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionReady); ready == nil || ready.Reason == "NotBootstrapped" {
+			set(appv1.ConditionReady, metav1.ConditionTrue, "ClusterInitialized", "Cluster is initialized")
+		}
+	} else {
+		reason, message := "BootstrapPending", "Waiting for the cluster to be bootstrapped"
+		if cluster.Spec.ExistingCluster {
+			reason, message = "DiscoveryPending", "Waiting for the existing cluster topology to be discovered"
+		}
+		set(appv1.ConditionBootstrapped, metav1.ConditionFalse, reason, message)
+		set(appv1.ConditionReady, metav1.ConditionFalse, "NotBootstrapped", message)
+	}
+
+	switch {
+	case cluster.Status.IsResharding:
+		set(appv1.ConditionScaling, metav1.ConditionTrue, "Resharding",
+			fmt.Sprintf("Migrating slots from %s to the standby", cluster.Status.OverloadedPod))
+	case cluster.Status.IsDraining:
+		set(appv1.ConditionScaling, metav1.ConditionTrue, "Draining",
+			fmt.Sprintf("Draining %s", cluster.Status.PodToDrain))
+	case cluster.Status.IsProvisioningStandby:
+		set(appv1.ConditionScaling, metav1.ConditionTrue, "ProvisioningStandby", "Adding a new standby to the cluster")
+	case cluster.Status.IsRebalancing:
+		set(appv1.ConditionScaling, metav1.ConditionTrue, "Rebalancing", "Rebalancing slots across the active masters")
+	case cluster.Status.IsRollingBack:
+		set(appv1.ConditionScaling, metav1.ConditionTrue, "RollingBack",
+			fmt.Sprintf("Rolling back failed %s", cluster.Status.RollbackOperation))
+	default:
+		set(appv1.ConditionScaling, metav1.ConditionFalse, "Idle", "No scaling operation in progress")
+	}
+
+	var degraded []string
+	for _, conditionType := range degradedConditionTypes {
+		if meta.IsStatusConditionTrue(cluster.Status.Conditions, conditionType) {
+			degraded = append(degraded, conditionType)
+		}
+	}
+	if len(degraded) > 0 {
+		set(appv1.ConditionDegraded, metav1.ConditionTrue, degraded[0], strings.Join(degraded, ","))
+	} else {
+		set(appv1.ConditionDegraded, metav1.ConditionFalse, "Healthy", "No degradation conditions are True")
+	}
+
+	if syncStatusSummary(cluster) {
+		changed = true
+	}
+
+	return changed
+}
+
+// syncStatusSummary sets the Phase and Selector fields shown by kubectl and read through the scale
+// subresource, and exports the phase as the redis_operator_cluster_phase metric. It reports whether
+// any field changed. CurrentMasters is observed from the Redis cluster by observeCurrentMasters.
+func syncStatusSummary(cluster *appv1.RedisCluster) bool {
+	phase := appv1.RedisClusterPhaseReady
+	switch {
+	case !cluster.Status.Initialized:
+		phase = appv1.RedisClusterPhasePending
+	case isScaling(cluster):
+		phase = appv1.RedisClusterPhaseScaling
+	case meta.IsStatusConditionTrue(cluster.Status.Conditions, appv1.ConditionDegraded):
+		phase = appv1.RedisClusterPhaseDegraded
+	}
+
+	observePhase(cluster, phase)
+
+	selector := labels.SelectorFromSet(getLabels(cluster)).String()
+	if cluster.Spec.ExistingCluster {
+		selector = labels.SelectorFromSet(cluster.Spec.PodSelector).String()
+	}
+
+	if cluster.Status.Phase == phase && cluster.Status.Selector == selector {
+		return false
+	}
+	cluster.Status.Phase = phase
+	cluster.Status.Selector = selector
+	return true
+}
+
+// observeCurrentMasters sets CurrentMasters, the status of the scale subresource, to the number of
+// live masters that own slots in the Redis cluster. It reports the observed topology, so a
+// spec.masters change that has not been carried out by a scaling operation does not show as done.
+func (r *RedisClusterReconciler) observeCurrentMasters(ctx context.Context, cluster *appv1.RedisCluster) error {
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	masters := int32(redis.ServingMasters(nodes))
+	if masters == cluster.Status.CurrentMasters {
+		return nil
+	}
+	cluster.Status.CurrentMasters = masters
+	return r.Status().Update(ctx, cluster)
+}
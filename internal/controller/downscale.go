@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
 )
 
 //go:embed scripts/drain.sh
@@ -27,6 +28,7 @@ var cleanupStandbyScript string
 // checkDrainStatus monitors the scale-down operation progress.
 // It creates the drain job if needed, monitors its progress, and finalizes the scale-down
 // by decrementing the master count and updating the standby pod reference.
+// A SimpleRemove drain job removes the shed pods from the cluster itself, so no cleanup job follows it.
 func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	jobName := cluster.Name + "-drain"
@@ -39,23 +41,28 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 		destPod1 := cluster.Status.DrainDestPod1
 		destPod2 := cluster.Status.DrainDestPod2
 
-		if podName == "" || destPod1 == "" {
+		simpleRemove := cluster.Spec.ScaleDownStrategy == appv1.ScaleDownSimpleRemove
+		if podName == "" || (destPod1 == "" && !simpleRemove) {
 			logger.Error(fmt.Errorf("IsDraining is true but drain info is incomplete"), "State error")
+			endOperation(cluster)
 			cluster.Status.IsDraining = false
 			cluster.Status.PodToDrain = ""
 			cluster.Status.DrainDestPod1 = ""
 			cluster.Status.DrainDestPod2 = ""
+			cluster.Status.DrainRotatePod = ""
 			_ = r.Status().Update(ctx, cluster)
 			return ctrl.Result{}, nil
 		}
 
-		if podName == cluster.Status.StandbyPod {
+		if isStandbyPod(cluster, podName) {
 			logger.Error(fmt.Errorf("attempted to drain standby pod"), "Invalid operation",
 				"standbyPod", cluster.Status.StandbyPod)
+			endOperation(cluster)
 			cluster.Status.IsDraining = false
 			cluster.Status.PodToDrain = ""
 			cluster.Status.DrainDestPod1 = ""
 			cluster.Status.DrainDestPod2 = ""
+			cluster.Status.DrainRotatePod = ""
 			_ = r.Status().Update(ctx, cluster)
 			return ctrl.Result{}, nil
 		}
@@ -63,9 +70,37 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 		logger.Info("Creating drain job",
 			"pod", podName,
 			"dest1", destPod1,
-			"dest2", destPod2)
+			"dest2", destPod2,
+			"rotatePod", cluster.Status.DrainRotatePod)
 
-		job := r.drainJobForRedisCluster(cluster, podName, destPod1, destPod2)
+		if moved, err := r.standbyMoved(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to verify standby before creating drain job")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		} else if moved != "" {
+			return r.abortForMovedStandby(ctx, cluster, moved)
+		}
+
+		// A rollback returns the slots a master holds above this baseline to the drained master.
+		slots, err := r.masterSlotCounts(ctx, cluster)
+		if err != nil {
+			logger.Error(err, "Failed to record slot baseline before creating drain job")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		cluster.Status.DrainSlotBaseline = make(map[string]int32, len(slots))
+		for pod, count := range slots {
+			cluster.Status.DrainSlotBaseline[pod] = int32(count)
+		}
+		if err := r.Status().Update(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to record slot baseline before creating drain job")
+			return ctrl.Result{}, err
+		}
+
+		var job *batchv1.Job
+		if simpleRemove {
+			job = r.simpleRemoveJobForRedisCluster(cluster, podName)
+		} else {
+			job = r.drainJobForRedisCluster(cluster, podName, destPod1, destPod2)
+		}
 		if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
 			logger.Error(err, "Failed to set owner reference on drain job")
 			return ctrl.Result{}, err
@@ -74,6 +109,7 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 			logger.Error(err, "Failed to create drain job")
 			return ctrl.Result{}, err
 		}
+		r.recordNormal(cluster, "DrainStarted", "Created drain job %s for %s", jobName, podName)
 		return ctrl.Result{Requeue: true}, nil
 
 	} else if err != nil {
@@ -82,79 +118,98 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 	}
 
 	if drainJob.Status.Succeeded > 0 {
-		logger.Info("Drain job succeeded, pod is empty, removing old standby from cluster")
-
 		drainedPod := cluster.Status.PodToDrain
 		oldStandby := cluster.Status.StandbyPod
 
-		// Check if cleanup job exists to remove old standby from cluster
-		cleanupJobName := cluster.Name + "-cleanup-standby"
-		cleanupJob := &batchv1.Job{}
-		err := r.Get(ctx, client.ObjectKey{Name: cleanupJobName, Namespace: cluster.Namespace}, cleanupJob)
-
-		if err != nil && errors.IsNotFound(err) {
-			logger.Info("Creating cleanup job to remove old standby from cluster",
-				"oldStandby", oldStandby,
-				"drainedPod", drainedPod)
-
-			job := r.cleanupStandbyJobForRedisCluster(cluster, oldStandby, drainedPod)
-			if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
-				logger.Error(err, "Failed to set owner reference on cleanup job")
+		var cleanupJob *batchv1.Job
+		if isSimpleRemoveJob(drainJob) {
+			logger.Info("Simple removal job succeeded, slots rebalanced and shed pods removed from cluster, scaling down StatefulSet")
+		} else {
+			logger.Info("Drain job succeeded, pod is empty, removing old standby from cluster")
+
+			// Check if cleanup job exists to remove old standby from cluster
+			cleanupJobName := cluster.Name + "-cleanup-standby"
+			cleanupJob = &batchv1.Job{}
+			err := r.Get(ctx, client.ObjectKey{Name: cleanupJobName, Namespace: cluster.Namespace}, cleanupJob)
+
+			if err != nil && errors.IsNotFound(err) {
+				logger.Info("Creating cleanup job to remove old standby from cluster",
+					"oldStandby", oldStandby,
+					"drainedPod", drainedPod)
+
+				job := r.cleanupStandbyJobForRedisCluster(cluster, oldStandby, drainedPod)
+				if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
+					logger.Error(err, "Failed to set owner reference on cleanup job")
+					return ctrl.Result{}, err
+				}
+				if err := r.Create(ctx, job); err != nil {
+					logger.Error(err, "Failed to create cleanup job")
+					return ctrl.Result{}, err
+				}
+				r.recordNormal(cluster, "CleanupStarted", "Drain of %s succeeded, created cleanup job %s", drainedPod, cleanupJobName)
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+
+			} else if err != nil {
+				logger.Error(err, "Failed to get cleanup job")
 				return ctrl.Result{}, err
 			}
-			if err := r.Create(ctx, job); err != nil {
-				logger.Error(err, "Failed to create cleanup job")
-				return ctrl.Result{}, err
-			}
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 
-		} else if err != nil {
-			logger.Error(err, "Failed to get cleanup job")
-			return ctrl.Result{}, err
-		}
+			// Wait for cleanup job to complete
+			if cleanupJob.Status.Succeeded == 0 && cleanupJob.Status.Failed == 0 {
+				logger.Info("Cleanup job is still running, waiting...")
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
 
-		// Wait for cleanup job to complete
-		if cleanupJob.Status.Succeeded == 0 && cleanupJob.Status.Failed == 0 {
-			logger.Info("Cleanup job is still running, waiting...")
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
-		}
+			if cleanupJob.Status.Failed > 0 {
+				logger.Error(fmt.Errorf("cleanup job failed"), "Failed to remove old standby from cluster")
+				r.recordWarning(cluster, "CleanupFailed", "Cleanup job %s failed, retrying", cleanupJobName)
+				// Clean up the failed job to allow retry
+				_ = r.Delete(ctx, cleanupJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			}
 
-		if cleanupJob.Status.Failed > 0 {
-			logger.Error(fmt.Errorf("cleanup job failed"), "Failed to remove old standby from cluster")
-			// Clean up the failed job to allow retry
-			_ = r.Delete(ctx, cleanupJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			// Cleanup job succeeded - now safe to scale down StatefulSet
+			logger.Info("Cleanup job succeeded, old standby removed from cluster, scaling down StatefulSet")
 		}
 
-		// Cleanup job succeeded - now safe to scale down StatefulSet
-		logger.Info("Cleanup job succeeded, old standby removed from cluster, scaling down StatefulSet")
-
 		// Decrement masters count
-		cluster.Spec.Masters--
-		if err := r.Update(ctx, cluster); err != nil {
+		if err := r.patchCluster(ctx, cluster, func() { cluster.Spec.Masters-- }); err != nil {
 			logger.Error(err, "Failed to update spec to decrease masters after drain")
 			return ctrl.Result{}, err
 		}
 
 		// Scale down StatefulSet to remove old standby pod
 		// The StatefulSet will delete the highest-index pod (the old standby)
-		sts := r.statefulSetForRedisCluster(cluster)
 		logger.Info("Scaling down StatefulSet to remove old standby",
 			"oldStandby", oldStandby,
 			"newStandby", drainedPod)
-		if err := r.reconcileStatefulSet(ctx, cluster, sts); err != nil {
+		if err := r.scaleStatefulSet(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to reconcile StatefulSet after scale-down")
 		}
 
-		// Drained pod becomes the new standby
-		cluster.Status.StandbyPod = drainedPod
+		// Drained pod becomes the first standby of the pool
+		setStandbyPool(cluster, standbyPoolPods(cluster))
 
 		cluster.Status.IsDraining = false
 		cluster.Status.PodToDrain = ""
 		cluster.Status.DrainDestPod1 = ""
 		cluster.Status.DrainDestPod2 = ""
+		cluster.Status.DrainRotatePod = ""
+		cluster.Status.DrainSlotBaseline = nil
 		now := metav1.Now()
 		cluster.Status.LastScaleTime = &now
+		cluster.Status.ConsecutiveScaleDowns++
+		if target := cluster.Status.IdleConsolidationTarget; target > 0 && cluster.Spec.Masters <= target {
+			r.recordNormal(cluster, "IdleConsolidationComplete", "Consolidated idle cluster to %d masters", cluster.Spec.Masters)
+			cluster.Status.IdleConsolidationTarget = 0
+		}
+		recordScalingEvent(cluster, scaleDirectionDown, scalingOutcomeSucceeded, drainedPod,
+			fmt.Sprintf("%s drained and is the new standby", drainedPod), cluster.Spec.Masters+1, cluster.Spec.Masters)
+		resetScaleFailures(cluster)
+		r.verifyKeyspaceIntegrity(ctx, cluster, "scale-down")
+		r.recordNormal(cluster, "ScaleDownComplete", "Drained %s is the new standby, cluster now has %d masters",
+			drainedPod, cluster.Spec.Masters)
+		endOperation(cluster)
 
 		if err := r.Status().Update(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to update status after drain")
@@ -162,7 +217,9 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 		}
 
 		_ = r.Delete(ctx, drainJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
-		_ = r.Delete(ctx, cleanupJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if cleanupJob != nil {
+			_ = r.Delete(ctx, cleanupJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		}
 
 		logger.Info("Scale-down complete, StatefulSet will delete old standby pods",
 			"newMasters", cluster.Spec.Masters,
@@ -173,11 +230,22 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 
 	if drainJob.Status.Failed > 0 {
 		logger.Error(fmt.Errorf("drain job %s failed", jobName), "Draining failed")
+		r.recordWarning(cluster, "DrainFailed", "Drain job %s failed", jobName)
+		r.recordJobFailureLog(ctx, cluster, drainJob)
 		_ = r.Delete(ctx, drainJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		recordScalingEvent(cluster, scaleDirectionDown, scalingOutcomeFailed, cluster.Status.PodToDrain,
+			fmt.Sprintf("drain job %s failed", jobName), cluster.Spec.Masters, cluster.Spec.Masters)
+		r.recordScaleFailure(cluster, "drain")
+		if cluster.Spec.AutoRollbackOnScaleFailure {
+			return r.startRollback(ctx, cluster, rollbackOperationDrain, cluster.Status.PodToDrain)
+		}
+		endOperation(cluster)
 		cluster.Status.IsDraining = false
 		cluster.Status.PodToDrain = ""
 		cluster.Status.DrainDestPod1 = ""
 		cluster.Status.DrainDestPod2 = ""
+		cluster.Status.DrainRotatePod = ""
+		cluster.Status.DrainSlotBaseline = nil
 		if err := r.Status().Update(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to update status after failed drain")
 			return ctrl.Result{}, err
@@ -192,6 +260,8 @@ func (r *RedisClusterReconciler) checkDrainStatus(ctx context.Context, cluster *
 // drainJobForRedisCluster creates a Kubernetes Job that performs the scale-down draining.
 // It uses pre-seeding via replication to speed up the migration, then moves slots from the
 // drained pod to the destination pod(s). The drained pod becomes the new standby.
+// When DrainRotatePod is set, that pod's slots go to the destinations instead and the drained
+// pod's slots are rotated into it.
 func (r *RedisClusterReconciler) drainJobForRedisCluster(
 	cluster *appv1.RedisCluster,
 	podToDrain string,
@@ -200,38 +270,45 @@ func (r *RedisClusterReconciler) drainJobForRedisCluster(
 ) *batchv1.Job {
 	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
 		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
-	entrypoint := fmt.Sprintf("%s:6379", anyPodHost)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
 
 	timeout := int64(cluster.Spec.ReshardTimeoutSeconds)
 	backoff := int32(0)
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name + "-drain",
-			Namespace: cluster.Namespace,
-			Labels:    getLabels(cluster),
+			Name:        cluster.Name + "-drain",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
 		},
 		Spec: batchv1.JobSpec{
 			ActiveDeadlineSeconds: &timeout,
 			BackoffLimit:          &backoff,
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyNever,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Name:    "smart-drain",
-							Image:   fmt.Sprintf("redis:%s", cluster.Spec.RedisVersion),
+							Image:   redisImage(cluster),
 							Command: []string{"sh", "-c"},
-							Args:    []string{drainScript},
+							Args:    []string{redis.Script(drainScript, redis.PollScript, redis.ResolveIPScript, redis.NodeLookupScript, redis.SlotCountScript, redis.MigrateScript)},
 							Env: []corev1.EnvVar{
 								{Name: "POD_TO_DRAIN", Value: podToDrain},
 								{Name: "DEST_POD_1", Value: destPod1},
 								{Name: "DEST_POD_2", Value: destPod2},
+								{Name: "ROTATE_POD", Value: cluster.Status.DrainRotatePod},
 								{Name: "STANDBY_POD", Value: cluster.Status.StandbyPod},
 								{Name: "SERVICE_NAME", Value: cluster.Name + "-headless"},
 								{Name: "NAMESPACE", Value: cluster.Namespace},
 								{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
 								{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
+								{Name: "MAX_ATTEMPTS", Value: fmt.Sprintf("%d", cluster.Spec.MigrationRetryAttempts)},
+								{Name: "MIGRATE_TIMEOUT_MS", Value: fmt.Sprintf("%d", cluster.Spec.MigrateTimeoutMillis)},
+								{Name: "SKIP_GHOST_CLEANUP", Value: fmt.Sprintf("%t", cluster.Spec.SkipGhostCleanup)},
+								{Name: "WRITE_PAUSE_MS", Value: fmt.Sprintf("%d", cluster.Spec.DrainWritePauseMilliseconds)},
 							},
 						},
 					},
@@ -239,41 +316,57 @@ func (r *RedisClusterReconciler) drainJobForRedisCluster(
 			},
 		},
 	}
+	applyJobPolling(cluster, &job.Spec.Template.Spec)
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Drain)
+	return job
 }
 
 // cleanupStandbyJobForRedisCluster creates a Kubernetes Job that removes the old standby pods from the Redis cluster.
 // This job removes both the new standby (drained pod + replicas) and old standby (previous standby + replicas),
-// then re-adds the new standby pods fresh to the cluster.
+// then re-adds the new standby with StandbyReplicaCount replicas fresh to the cluster. Each reset is verified
+// to leave a clean single-node cluster before the node is re-added, and the job fails if it does not.
+// Any further replicas of the drained pod are left out and deleted when the StatefulSet scales down.
 func (r *RedisClusterReconciler) cleanupStandbyJobForRedisCluster(cluster *appv1.RedisCluster, standbyPod string, drainedPod string) *batchv1.Job {
 	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
 		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
-	entrypoint := fmt.Sprintf("%s:6379", anyPodHost)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
 
-	// Calculate indices
+	// Calculate indices. The drained master joins the front of the pool and the last standby is
+	// removed. Unless it is the only standby, the drained shard keeps its full width, so its pods
+	// beyond the standby replicas are reset to idle instead of being deleted.
 	newStandbyIndex := (cluster.Spec.Masters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
-	oldStandbyIndex := cluster.Spec.Masters * (1 + cluster.Spec.ReplicasPerMaster)
+	oldStandbyIndex := lastStandbyIndex(cluster)
+	resetReplicas := cluster.StandbyReplicaCount()
+	if cluster.Spec.StandbyCount > 1 {
+		resetReplicas = cluster.Spec.ReplicasPerMaster
+	}
 
 	timeout := int64(300) // 5 minutes should be enough
 	backoff := int32(3)
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name + "-cleanup-standby",
-			Namespace: cluster.Namespace,
-			Labels:    getLabels(cluster),
+			Name:        cluster.Name + "-cleanup-standby",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
 		},
 		Spec: batchv1.JobSpec{
 			ActiveDeadlineSeconds: &timeout,
 			BackoffLimit:          &backoff,
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyNever,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Name:    "cleanup-standby",
-							Image:   fmt.Sprintf("redis:%s", cluster.Spec.RedisVersion),
+							Image:   redisImage(cluster),
 							Command: []string{"sh", "-c"},
-							Args:    []string{cleanupStandbyScript},
+							Args:    []string{redis.Script(cleanupStandbyScript, redis.PollScript, redis.ResolveIPScript, redis.NodeLookupScript)},
 							Env: []corev1.EnvVar{
 								{Name: "CLUSTER_NAME", Value: cluster.Name},
 								{Name: "SERVICE_NAME", Value: cluster.Name + "-headless"},
@@ -281,6 +374,8 @@ func (r *RedisClusterReconciler) cleanupStandbyJobForRedisCluster(cluster *appv1
 								{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
 								{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
 								{Name: "REPLICAS_PER_MASTER", Value: fmt.Sprintf("%d", cluster.Spec.ReplicasPerMaster)},
+								{Name: "STANDBY_REPLICAS", Value: fmt.Sprintf("%d", cluster.StandbyReplicaCount())},
+								{Name: "RESET_REPLICAS", Value: fmt.Sprintf("%d", resetReplicas)},
 								{Name: "NEW_STANDBY_INDEX", Value: fmt.Sprintf("%d", newStandbyIndex)},
 								{Name: "OLD_STANDBY_INDEX", Value: fmt.Sprintf("%d", oldStandbyIndex)},
 							},
@@ -290,4 +385,10 @@ func (r *RedisClusterReconciler) cleanupStandbyJobForRedisCluster(cluster *appv1
 			},
 		},
 	}
+	applyJobPolling(cluster, &job.Spec.Template.Spec)
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Cleanup)
+	return job
 }
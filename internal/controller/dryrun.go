@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// dryRunNoScaling is the LastDecision recorded in AutoScaleDryRun mode when no scaling is needed.
+const dryRunNoScaling = "no scaling: all pods within acceptable ranges"
+
+// recordDryRunDecision reports a scaling decision the autoscaler would carry out outside
+// AutoScaleDryRun mode. It announces the decision with an event and records it as
+// Status.LastDecision, touching no other status field.
+func (r *RedisClusterReconciler) recordDryRunDecision(ctx context.Context, cluster *appv1.RedisCluster, direction string, targetMasters int32, plan, reason string) error {
+	decision := fmt.Sprintf("would scale %s to %d masters (%s): %s", direction, targetMasters, plan, reason)
+	log.FromContext(ctx).Info("Dry run, not scaling", "decision", decision)
+	r.recordNormal(cluster, "DryRunScaleDecision", "Dry run: %s", decision)
+	return r.setLastDecision(ctx, cluster, decision)
+}
+
+// setLastDecision persists Status.LastDecision when it changes.
+func (r *RedisClusterReconciler) setLastDecision(ctx context.Context, cluster *appv1.RedisCluster, decision string) error {
+	if cluster.Status.LastDecision == decision {
+		return nil
+	}
+	cluster.Status.LastDecision = decision
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to record dry-run decision: %w", err)
+	}
+	return nil
+}
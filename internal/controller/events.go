@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// operationIDKey is the label/annotation key carrying the correlation ID of a scaling operation.
+// It is stamped on every Job and Event belonging to the operation so a single logical
+// bootstrap, scale-up, or scale-down can be traced across its jobs and reconcile cycles, e.g.
+// kubectl get jobs -l cache.example.com/operation-id=<id>.
+const operationIDKey = "cache.example.com/operation-id"
+
+// beginOperation assigns a new correlation ID to the cluster's active operation.
+// The caller is responsible for persisting the status.
+func beginOperation(cluster *appv1.RedisCluster) string {
+	cluster.Status.ActiveOperation = string(uuid.NewUUID())
+	return cluster.Status.ActiveOperation
+}
+
+// endOperation clears the active operation's correlation ID and standby snapshot.
+// The caller is responsible for persisting the status.
+func endOperation(cluster *appv1.RedisCluster) {
+	cluster.Status.ActiveOperation = ""
+	cluster.Status.ScalingStandbySnapshot = ""
+}
+
+// maxScalingEvents is the number of entries kept in the ScalingEvents history.
+const maxScalingEvents = 10
+
+// Outcomes of a ScalingEvent.
+const (
+	scalingOutcomeTriggered = "Triggered"
+	scalingOutcomeSucceeded = "Succeeded"
+	scalingOutcomeFailed    = "Failed"
+)
+
+// recordScalingEvent appends an entry to the scaling history, dropping the oldest entries beyond
+// maxScalingEvents. The entry carries the active operation ID. The caller is responsible for
+// persisting the status.
+func recordScalingEvent(cluster *appv1.RedisCluster, direction, outcome, triggerPod, reason string, mastersBefore, mastersAfter int32) {
+	events := append(cluster.Status.ScalingEvents, appv1.ScalingEvent{
+		Time:          metav1.Now(),
+		Direction:     direction,
+		Outcome:       outcome,
+		OperationID:   cluster.Status.ActiveOperation,
+		TriggerPod:    triggerPod,
+		Reason:        reason,
+		MastersBefore: mastersBefore,
+		MastersAfter:  mastersAfter,
+	})
+	if len(events) > maxScalingEvents {
+		events = events[len(events)-maxScalingEvents:]
+	}
+	cluster.Status.ScalingEvents = events
+}
+
+// withOperationLogger returns a context whose logger includes the active operation ID,
+// so every log line emitted while the operation is in flight can be correlated.
+func withOperationLogger(ctx context.Context, cluster *appv1.RedisCluster) context.Context {
+	if cluster.Status.ActiveOperation == "" {
+		return ctx
+	}
+	logger := log.FromContext(ctx).WithValues("operationID", cluster.Status.ActiveOperation)
+	return log.IntoContext(ctx, logger)
+}
+
+// jobLabels returns the labels for a Job created by the operator: CommonLabels, the cluster's
+// labels, and the active operation ID when one is set. A copy is returned so the cluster's
+// PodSelector is never mutated.
+func jobLabels(cluster *appv1.RedisCluster) map[string]string {
+	labels := withCommonLabels(cluster, getLabels(cluster))
+	if cluster.Status.ActiveOperation != "" {
+		labels[operationIDKey] = cluster.Status.ActiveOperation
+	}
+	return labels
+}
+
+// recordEvent emits a Kubernetes Event on the RedisCluster. When an operation is active, the
+// event is annotated with its correlation ID and the ID is prefixed to the message.
+func (r *RedisClusterReconciler) recordEvent(cluster *appv1.RedisCluster, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+
+	message := fmt.Sprintf(messageFmt, args...)
+	if cluster.Status.ActiveOperation == "" {
+		r.Recorder.Event(cluster, eventType, reason, message)
+		return
+	}
+
+	r.Recorder.AnnotatedEventf(cluster,
+		map[string]string{operationIDKey: cluster.Status.ActiveOperation},
+		eventType, reason, "[operation %s] %s", cluster.Status.ActiveOperation, message)
+}
+
+// recordWarning emits a Warning event on the RedisCluster.
+func (r *RedisClusterReconciler) recordWarning(cluster *appv1.RedisCluster, reason, messageFmt string, args ...interface{}) {
+	r.recordEvent(cluster, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// recordNormal emits a Normal event on the RedisCluster.
+func (r *RedisClusterReconciler) recordNormal(cluster *appv1.RedisCluster, reason, messageFmt string, args ...interface{}) {
+	r.recordEvent(cluster, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
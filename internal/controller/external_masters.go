@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+//go:embed scripts/remove-masters.sh
+var removeMastersScript string
+
+// verifyNoUnexpectedMasters checks that no more masters serve slots than Masters. A master added
+// by hand with redis-cli --cluster add-node breaks every index-based operation, so the operator
+// reacts according to ExternalMasterPolicy: Reject blocks scaling, Adopt raises Masters to the
+// observed count, and Remove migrates the slots of masters no cluster pod holds and removes them.
+// It returns an error while scaling must wait.
+func (r *RedisClusterReconciler) verifyNoUnexpectedMasters(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	if cluster.Spec.ExternalMasterPolicy == appv1.ExternalMasterRemove {
+		if err := r.checkMasterRemovalJob(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	_, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	podIPs := make(map[string]bool)
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podIPs[pod.Status.PodIP] = true
+		}
+	}
+
+	var serving int
+	var external []redis.ClusterNode
+	for _, node := range nodes {
+		if !node.IsMaster() || node.IsFailed() || node.Slots == 0 {
+			continue
+		}
+		serving++
+		if !podIPs[node.IP] {
+			external = append(external, node)
+		}
+	}
+
+	expected := int(cluster.Spec.Masters)
+	if serving <= expected {
+		return r.setUnexpectedMastersCondition(ctx, cluster, metav1.ConditionFalse, "MastersMatch",
+			fmt.Sprintf("%d masters serve slots", serving))
+	}
+
+	message := fmt.Sprintf("%d masters serve slots, expected %d", serving, expected)
+	if len(external) > 0 {
+		var described []string
+		for _, node := range external {
+			described = append(described, fmt.Sprintf("%s (%s:%d, %d slots)", node.ID, node.IP, node.Port, node.Slots))
+		}
+		message += "; not held by a cluster pod: " + strings.Join(described, ", ")
+	}
+	logger.Info("Unexpected masters detected", "serving", serving, "expected", expected, "policy", cluster.Spec.ExternalMasterPolicy)
+
+	switch cluster.Spec.ExternalMasterPolicy {
+	case appv1.ExternalMasterAdopt:
+		if err := r.setUnexpectedMastersCondition(ctx, cluster, metav1.ConditionFalse, "MastersAdopted", message); err != nil {
+			return err
+		}
+		previous := cluster.Spec.Masters
+		if err := r.patchCluster(ctx, cluster, func() { cluster.Spec.Masters = int32(serving) }); err != nil {
+			return fmt.Errorf("failed to adopt %d masters: %w", serving, err)
+		}
+		r.recordWarning(cluster, "MastersAdopted", "Raised masters from %d to %d: %s", previous, serving, message)
+		return fmt.Errorf("adopted %d masters, waiting for the new layout to be reconciled", serving)
+
+	case appv1.ExternalMasterRemove:
+		if len(external) == 0 {
+			if err := r.setUnexpectedMastersCondition(ctx, cluster, metav1.ConditionTrue, "UnidentifiedMasters",
+				message+"; every serving master is a cluster pod, remove the extra one by hand"); err != nil {
+				return err
+			}
+			return fmt.Errorf("unexpected masters: %s", message)
+		}
+		if err := r.setUnexpectedMastersCondition(ctx, cluster, metav1.ConditionTrue, "RemovingMasters", message); err != nil {
+			return err
+		}
+		if err := r.startMasterRemoval(ctx, cluster, external); err != nil {
+			return err
+		}
+		return fmt.Errorf("removing unexpected masters: %s", message)
+
+	default:
+		if err := r.setUnexpectedMastersCondition(ctx, cluster, metav1.ConditionTrue, "MastersRejected", message); err != nil {
+			return err
+		}
+		return fmt.Errorf("unexpected masters: %s", message)
+	}
+}
+
+// checkMasterRemovalJob reports the outcome of a previous removal job. A running job blocks
+// scaling. A succeeded job is deleted so the topology is checked afresh; a failed job is left in
+// place so checkNoJobsRunning keeps blocking scaling until a human has reviewed the cluster.
+func (r *RedisClusterReconciler) checkMasterRemovalJob(ctx context.Context, cluster *appv1.RedisCluster) error {
+	jobName := cluster.Name + "-remove-masters"
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get %s job: %w", jobName, err)
+	}
+
+	if job.Status.Succeeded == 0 && job.Status.Failed == 0 {
+		return fmt.Errorf("%s job in progress", jobName)
+	}
+
+	if job.Status.Failed > 0 {
+		message := fmt.Sprintf("job %s failed; delete it after repairing the cluster", jobName)
+		if err := r.setUnexpectedMastersCondition(ctx, cluster, metav1.ConditionTrue, "RemovalFailed", message); err != nil {
+			return err
+		}
+		return fmt.Errorf("unexpected masters: %s", message)
+	}
+
+	log.FromContext(ctx).Info("Unexpected masters removed")
+	r.recordNormal(cluster, "MastersRemoved", "Job %s removed the unexpected masters", jobName)
+	_ = r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return nil
+}
+
+// startMasterRemoval creates the job that migrates the slots off the given masters and removes them.
+func (r *RedisClusterReconciler) startMasterRemoval(ctx context.Context, cluster *appv1.RedisCluster, masters []redis.ClusterNode) error {
+	logger := log.FromContext(ctx)
+
+	var nodeIDs []string
+	for _, node := range masters {
+		nodeIDs = append(nodeIDs, node.ID)
+	}
+
+	job := r.removeMastersJobForRedisCluster(cluster, nodeIDs)
+	if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
+		logger.Error(err, "Failed to set owner reference on remove-masters job")
+		return err
+	}
+	if err := r.Create(ctx, job); err != nil {
+		logger.Error(err, "Failed to create remove-masters job")
+		return err
+	}
+	r.recordWarning(cluster, "RemovingMasters", "Created job %s to remove masters %s", job.Name, strings.Join(nodeIDs, ", "))
+	return nil
+}
+
+// removeMastersJobForRedisCluster creates a Kubernetes Job that moves every slot off the given
+// masters with redis-cli --cluster rebalance and then removes them with del-node.
+func (r *RedisClusterReconciler) removeMastersJobForRedisCluster(cluster *appv1.RedisCluster, nodeIDs []string) *batchv1.Job {
+	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
+		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
+
+	timeout := int64(cluster.Spec.ReshardTimeoutSeconds)
+	backoff := int32(0)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name + "-remove-masters",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: &timeout,
+			BackoffLimit:          &backoff,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
+					Containers: []corev1.Container{
+						{
+							Name:    "remove-masters",
+							Image:   redisImage(cluster),
+							Command: []string{"sh", "-c"},
+							Args:    []string{removeMastersScript},
+							Env: []corev1.EnvVar{
+								{Name: "NODE_IDS", Value: strings.Join(nodeIDs, " ")},
+								{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
+								{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
+								{Name: "MIGRATE_TIMEOUT_MS", Value: fmt.Sprintf("%d", cluster.Spec.MigrateTimeoutMillis)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	return job
+}
+
+// setUnexpectedMastersCondition records the UnexpectedMasters condition, persisting the status
+// and emitting an event only when the condition status or reason changes.
+func (r *RedisClusterReconciler) setUnexpectedMastersCondition(ctx context.Context, cluster *appv1.RedisCluster, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionUnexpectedMasters)
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+	if current == nil && status == metav1.ConditionFalse {
+		// Nothing to report and nothing to clear.
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionUnexpectedMasters,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if status == metav1.ConditionTrue {
+		r.recordWarning(cluster, appv1.ConditionUnexpectedMasters, "Scaling blocked: %s", message)
+	} else {
+		r.recordNormal(cluster, "UnexpectedMastersResolved", "%s", message)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update unexpected masters condition: %w", err)
+	}
+	return nil
+}
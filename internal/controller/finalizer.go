@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// redisClusterFinalizer lets the operator tear a cluster down before its owned resources are
+// garbage-collected.
+const redisClusterFinalizer = "rediscluster.cache.example.com/cleanup"
+
+// operationJobSuffixes are the name suffixes of every Job the operator creates for a cluster.
+var operationJobSuffixes = []string{"-bootstrap", "-reshard", "-drain", "-cleanup-standby", "-join-nodes", "-rollback", "-rebalance", "-remove-masters"}
+
+// finalizeRedisCluster tears the cluster down when the RedisCluster is deleted: running Jobs are
+// cancelled so they stop migrating slots while pods are going away, and for managed clusters with
+// ResetClusterOnDelete the Redis pods are flushed and reset. The data of an ExistingCluster is
+// never touched; if a scaling operation was in flight, a Warning event points at the half-migrated
+// slots its cancelled Job may have left. The finalizer is removed once the cleanup has run.
+func (r *RedisClusterReconciler) finalizeRedisCluster(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(cluster, redisClusterFinalizer) {
+		return nil
+	}
+
+	logger.Info("Finalizing RedisCluster")
+	if err := r.cancelOperationJobs(ctx, cluster); err != nil {
+		return err
+	}
+
+	if cluster.Spec.ExistingCluster {
+		if isScaling(cluster) {
+			r.recordWarning(cluster, "ScalingInterrupted",
+				"Deleted while scaling; run redis-cli --cluster fix against the cluster to close any half-migrated slots")
+		}
+	} else if cluster.Spec.ResetClusterOnDelete {
+		r.resetRedisPods(ctx, cluster)
+	}
+
+	if err := r.patchCluster(ctx, cluster, func() {
+		controllerutil.RemoveFinalizer(cluster, redisClusterFinalizer)
+	}); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	forgetClusterMetrics(cluster)
+	releaseScalingSlot(cluster)
+	logger.Info("RedisCluster finalized")
+	return nil
+}
+
+// cancelOperationJobs deletes the cluster's operation Jobs together with their pods.
+func (r *RedisClusterReconciler) cancelOperationJobs(ctx context.Context, cluster *appv1.RedisCluster) error {
+	for _, suffix := range operationJobSuffixes {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: cluster.Name + suffix, Namespace: cluster.Namespace}}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to cancel job %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// resetRedisPods flushes and hard-resets every running Redis pod. A master holding keys refuses
+// CLUSTER RESET, hence the FLUSHALL first. Failures are logged and do not block deletion.
+func (r *RedisClusterReconciler) resetRedisPods(ctx context.Context, cluster *appv1.RedisCluster) {
+	logger := log.FromContext(ctx)
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		logger.Error(err, "Failed to list pods for cluster reset")
+		return
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if _, err := r.execRedisCLI(ctx, pod.Namespace, pod.Name, "flushall"); err != nil {
+			logger.Error(err, "Failed to flush pod", "pod", pod.Name)
+			continue
+		}
+		if _, err := r.execRedisCLI(ctx, pod.Namespace, pod.Name, "cluster", "reset", "hard"); err != nil {
+			logger.Error(err, "Failed to reset pod", "pod", pod.Name)
+			continue
+		}
+		logger.Info("Reset Redis pod", "pod", pod.Name)
+	}
+}
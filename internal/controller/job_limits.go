@@ -0,0 +1,28 @@
+package controller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// applyJobLimits overrides the builder's default retry limit and deadline of a Job with the
+// settings from JobConfig that are set.
+func applyJobLimits(jobSpec *batchv1.JobSpec, settings appv1.JobSettings) {
+	if settings.BackoffLimit != nil {
+		backoff := *settings.BackoffLimit
+		jobSpec.BackoffLimit = &backoff
+	}
+	if settings.ActiveDeadlineSeconds != nil {
+		deadline := *settings.ActiveDeadlineSeconds
+		jobSpec.ActiveDeadlineSeconds = &deadline
+	}
+}
+
+// applyJobResources gives every container of a Job pod the JobResources of the cluster.
+func applyJobResources(cluster *appv1.RedisCluster, podSpec *corev1.PodSpec) {
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Resources = *cluster.Spec.JobResources.DeepCopy()
+	}
+}
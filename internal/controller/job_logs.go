@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+const (
+	// failureLogLines is how many lines are read from the end of a failed Job pod's log.
+	failureLogLines = 50
+
+	// maxFailureLogBytes caps Status.LastFailureLog so a noisy Job cannot bloat the object.
+	maxFailureLogBytes = 4096
+
+	// maxFailureEventBytes caps the log excerpt in the failure event, which the API server
+	// truncates at 1024 bytes.
+	maxFailureEventBytes = 800
+)
+
+// recordJobFailureLog keeps the tail of a failed Job's log before the Job is deleted: it is
+// stored in Status.LastFailureLog and a shorter excerpt is emitted as a Warning event. Failing to
+// read the log is only logged. The caller is responsible for persisting the status.
+func (r *RedisClusterReconciler) recordJobFailureLog(ctx context.Context, cluster *appv1.RedisCluster, job *batchv1.Job) {
+	output, err := r.jobPodLog(ctx, job)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to read log of failed job", "job", job.Name)
+		return
+	}
+
+	cluster.Status.LastFailureLog = fmt.Sprintf("%s:\n%s", job.Name, lastBytes(output, maxFailureLogBytes))
+	r.recordWarning(cluster, "JobFailureLog", "Job %s failed, log tail:\n%s", job.Name, lastBytes(output, maxFailureEventBytes))
+}
+
+// jobPodLog returns the last failureLogLines lines of the log of the Job's most recent pod.
+func (r *RedisClusterReconciler) jobPodLog(ctx context.Context, job *batchv1.Job) (string, error) {
+	if r.Config == nil {
+		return "", fmt.Errorf("cannot read job logs: no REST config available")
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", fmt.Errorf("failed to list pods of job %s: %w", job.Name, err)
+	}
+	if len(podList.Items) == 0 {
+		return "", fmt.Errorf("job %s has no pods", job.Name)
+	}
+	latest := &podList.Items[0]
+	for i := range podList.Items {
+		if podList.Items[i].CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = &podList.Items[i]
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(r.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create clientset: %w", err)
+	}
+	tailLines := int64(failureLogLines)
+	raw, err := clientset.CoreV1().Pods(latest.Namespace).GetLogs(latest.Name, &corev1.PodLogOptions{TailLines: &tailLines}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log of pod %s: %w", latest.Name, err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// lastBytes returns at most the last n bytes of s, starting at a line boundary when one is
+// available so the excerpt does not open mid-line.
+func lastBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	tail := s[len(s)-n:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return "...\n" + tail
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// applyJobPolling sets POLL_TIMEOUT_SECONDS, the bound of the redis.PollScript helpers, on every
+// container of a Job pod whose script waits with them (bootstrap, reshard, join-nodes and
+// cleanup-standby).
+func applyJobPolling(cluster *appv1.RedisCluster, podSpec *corev1.PodSpec) {
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env,
+			corev1.EnvVar{Name: "POLL_TIMEOUT_SECONDS", Value: fmt.Sprintf("%d", cluster.Spec.JobPollTimeoutSeconds)})
+	}
+}
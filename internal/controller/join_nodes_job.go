@@ -8,18 +8,23 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
 )
 
 // joinNodesJobForRedisCluster creates a Kubernetes Job that joins new standby pods to the cluster.
-// The job adds the standby master and its replicas to the cluster.
+// The operator normally joins the pods itself first (joinStandbyPods), in which case every add-node
+// below is skipped and the job only verifies and prints the resulting topology.
+// The job first attaches any replicas the just-activated master is missing (when the standby runs
+// with fewer replicas than active masters), then adds the new standby master and its replicas.
 func (r *RedisClusterReconciler) joinNodesJobForRedisCluster(cluster *appv1.RedisCluster) *batchv1.Job {
 	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
 		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
-	anyPodPort := "6379"
+	anyPodPort := fmt.Sprintf("%d", redisPort(cluster))
 	entrypoint := fmt.Sprintf("%s:%s", anyPodHost, anyPodPort)
 
 	// Calculate new standby indices
-	newStandbyIndex := cluster.Spec.Masters * (1 + cluster.Spec.ReplicasPerMaster)
+	newStandbyIndex := lastStandbyIndex(cluster)
+	activatedMasterIndex := (cluster.Spec.Masters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
 
 	timeout := int64(300) // 5 minutes should be enough to join nodes
 	backoff := int32(3)   // Retry up to 3 times
@@ -37,32 +42,69 @@ SERVICE_NAME="$SERVICE_NAME"
 NAMESPACE="$NAMESPACE"
 NEW_STANDBY_INDEX="$NEW_STANDBY_INDEX"
 REPLICAS_PER_MASTER="$REPLICAS_PER_MASTER"
+STANDBY_REPLICAS="$STANDBY_REPLICAS"
+ACTIVATED_MASTER_INDEX="$ACTIVATED_MASTER_INDEX"
+
+# Step 0: Top up the just-activated master to REPLICAS_PER_MASTER replicas.
+# It ran as the standby with only STANDBY_REPLICAS replicas; the StatefulSet has
+# now created the missing ones directly after its existing replicas.
+if [ "$STANDBY_REPLICAS" -lt "$REPLICAS_PER_MASTER" ]; then
+  ACTIVATED_POD="${CLUSTER_NAME}-${ACTIVATED_MASTER_INDEX}"
+  ACTIVATED_FQDN="${ACTIVATED_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+  ACTIVATED_IP=$(resolve_ip $ACTIVATED_FQDN)
+  cluster_nodes_output=$(redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes)
+  ACTIVATED_NODE_ID=$(master_id "$cluster_nodes_output" $ACTIVATED_IP)
+
+  if [ -z "$ACTIVATED_NODE_ID" ]; then
+    echo "ERROR: Could not find activated master $ACTIVATED_POD in cluster"
+    exit 1
+  fi
+
+  for i in $(seq $((STANDBY_REPLICAS + 1)) $REPLICAS_PER_MASTER); do
+    REPLICA_POD="${CLUSTER_NAME}-$((ACTIVATED_MASTER_INDEX + i))"
+    REPLICA_FQDN="${REPLICA_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+    REPLICA_IP=$(resolve_ip $REPLICA_FQDN)
+
+    if [ -z "$REPLICA_IP" ]; then
+      echo "ERROR: Could not resolve replica pod $REPLICA_POD for activated master"
+      exit 1
+    fi
+
+    if echo "$cluster_nodes_output" | grep -q "$REPLICA_IP:$REDIS_PORT"; then
+      echo "Replica $REPLICA_POD already in cluster"
+    else
+      echo "Adding replica: $REPLICA_POD ($REPLICA_IP:$REDIS_PORT) as slave of activated master $ACTIVATED_NODE_ID"
+      redis-cli --cluster add-node ${REPLICA_IP}:$REDIS_PORT $ENTRYPOINT --cluster-slave --cluster-master-id $ACTIVATED_NODE_ID
+      wait_node_known $ANY_POD_HOST $REPLICA_IP
+    fi
+  done
+fi
 
 # Step 1: Add standby master
 STANDBY_POD="${CLUSTER_NAME}-${NEW_STANDBY_INDEX}"
 STANDBY_FQDN="${STANDBY_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-STANDBY_IP=$(getent hosts $STANDBY_FQDN | awk '{print $1}')
+STANDBY_IP=$(resolve_ip $STANDBY_FQDN)
 
 if [ -z "$STANDBY_IP" ]; then
   echo "ERROR: Could not resolve standby pod $STANDBY_POD"
   exit 1
 fi
 
-echo "Adding standby master: $STANDBY_POD ($STANDBY_IP:6379)"
+echo "Adding standby master: $STANDBY_POD ($STANDBY_IP:$REDIS_PORT)"
 
 # Check if node is already in cluster
 cluster_nodes_output=$(redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes)
-if echo "$cluster_nodes_output" | grep -q "$STANDBY_IP:6379"; then
+if echo "$cluster_nodes_output" | grep -q "$STANDBY_IP:$REDIS_PORT"; then
   echo "Standby master already in cluster"
-  STANDBY_NODE_ID=$(echo "$cluster_nodes_output" | grep "$STANDBY_IP:6379" | awk '{print $1}')
+  STANDBY_NODE_ID=$(node_id "$cluster_nodes_output" $STANDBY_IP)
 else
   echo "Adding standby master to cluster"
-  redis-cli --cluster add-node ${STANDBY_IP}:6379 $ENTRYPOINT
-  sleep 5
+  redis-cli --cluster add-node ${STANDBY_IP}:$REDIS_PORT $ENTRYPOINT
+  wait_node_known $ANY_POD_HOST $STANDBY_IP
 
   # Get the node ID of the newly added standby
   cluster_nodes_output=$(redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes)
-  STANDBY_NODE_ID=$(echo "$cluster_nodes_output" | grep "$STANDBY_IP:6379" | awk '{print $1}')
+  STANDBY_NODE_ID=$(node_id "$cluster_nodes_output" $STANDBY_IP)
 
   if [ -z "$STANDBY_NODE_ID" ]; then
     echo "ERROR: Failed to get standby node ID after adding"
@@ -72,55 +114,59 @@ else
 fi
 
 # Step 2: Add replicas for the standby master
-if [ "$REPLICAS_PER_MASTER" -gt 0 ]; then
-  echo "Adding $REPLICAS_PER_MASTER replica(s) for standby master"
+if [ "$STANDBY_REPLICAS" -gt 0 ]; then
+  echo "Adding $STANDBY_REPLICAS replica(s) for standby master"
 
-  for i in $(seq 1 $REPLICAS_PER_MASTER); do
+  for i in $(seq 1 $STANDBY_REPLICAS); do
     REPLICA_INDEX=$((NEW_STANDBY_INDEX + i))
     REPLICA_POD="${CLUSTER_NAME}-${REPLICA_INDEX}"
     REPLICA_FQDN="${REPLICA_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-    REPLICA_IP=$(getent hosts $REPLICA_FQDN | awk '{print $1}')
+    REPLICA_IP=$(resolve_ip $REPLICA_FQDN)
 
     if [ -z "$REPLICA_IP" ]; then
       echo "WARNING: Could not resolve replica pod $REPLICA_POD, skipping"
       continue
     fi
 
-    echo "Adding replica: $REPLICA_POD ($REPLICA_IP:6379) as slave of $STANDBY_NODE_ID"
+    echo "Adding replica: $REPLICA_POD ($REPLICA_IP:$REDIS_PORT) as slave of $STANDBY_NODE_ID"
 
     # Check if replica is already in cluster
-    if echo "$cluster_nodes_output" | grep -q "$REPLICA_IP:6379"; then
+    if echo "$cluster_nodes_output" | grep -q "$REPLICA_IP:$REDIS_PORT"; then
       echo "Replica $REPLICA_POD already in cluster"
     else
-      redis-cli --cluster add-node ${REPLICA_IP}:6379 $ENTRYPOINT --cluster-slave --cluster-master-id $STANDBY_NODE_ID
-      sleep 3
+      redis-cli --cluster add-node ${REPLICA_IP}:$REDIS_PORT $ENTRYPOINT --cluster-slave --cluster-master-id $STANDBY_NODE_ID
+      wait_node_known $ANY_POD_HOST $REPLICA_IP
       echo "Replica $REPLICA_POD added"
     fi
   done
 fi
 
+wait_cluster_ok $ANY_POD_HOST
+
 echo "=== Successfully Joined All New Pods to Cluster ==="
 redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes | grep -E "(${STANDBY_IP}|master|slave)"
 `
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name + "-join-nodes",
-			Namespace: cluster.Namespace,
-			Labels:    getLabels(cluster),
+			Name:        cluster.Name + "-join-nodes",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
 		},
 		Spec: batchv1.JobSpec{
 			ActiveDeadlineSeconds: &timeout,
 			BackoffLimit:          &backoff,
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyNever,
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Name:    "join-nodes",
-							Image:   fmt.Sprintf("redis:%s", cluster.Spec.RedisVersion),
+							Image:   redisImage(cluster),
 							Command: []string{"sh", "-c"},
-							Args:    []string{cliCmd},
+							Args:    []string{redis.Script(cliCmd, redis.PollScript, redis.ResolveIPScript, redis.NodeLookupScript)},
 							Env: []corev1.EnvVar{
 								{Name: "ANY_POD_HOST", Value: anyPodHost},
 								{Name: "ANY_POD_PORT", Value: anyPodPort},
@@ -130,6 +176,8 @@ redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes | grep -E "(${STANDBY_
 								{Name: "NAMESPACE", Value: cluster.Namespace},
 								{Name: "NEW_STANDBY_INDEX", Value: fmt.Sprintf("%d", newStandbyIndex)},
 								{Name: "REPLICAS_PER_MASTER", Value: fmt.Sprintf("%d", cluster.Spec.ReplicasPerMaster)},
+								{Name: "STANDBY_REPLICAS", Value: fmt.Sprintf("%d", cluster.StandbyReplicaCount())},
+								{Name: "ACTIVATED_MASTER_INDEX", Value: fmt.Sprintf("%d", activatedMasterIndex)},
 							},
 						},
 					},
@@ -137,4 +185,10 @@ redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes | grep -E "(${STANDBY_
 			},
 		},
 	}
+	applyJobPolling(cluster, &job.Spec.Template.Spec)
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Join)
+	return job
 }
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// countClusterKeys returns the cluster-wide key count as the sum of DBSIZE across every master
+// that serves slots. Replicas are not counted, so each key is counted exactly once.
+func (r *RedisClusterReconciler) countClusterKeys(ctx context.Context, cluster *appv1.RedisCluster) (int64, error) {
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return 0, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podByIP := make(map[string]string)
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			podByIP[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	var total int64
+	for _, node := range nodes {
+		if !node.IsMaster() || node.IsFailed() || node.Slots == 0 {
+			continue
+		}
+		podName, ok := podByIP[node.IP]
+		if !ok {
+			return 0, fmt.Errorf("no running pod for master %s at %s", node.ID, node.IP)
+		}
+		output, err := r.execRedisCLI(ctx, cluster.Namespace, podName, "dbsize")
+		if err != nil {
+			return 0, fmt.Errorf("failed to query DBSIZE on %s: %w", podName, err)
+		}
+		keys, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected DBSIZE output from %s: %q", podName, strings.TrimSpace(output))
+		}
+		total += keys
+	}
+	return total, nil
+}
+
+// recordKeyCountBefore records the cluster-wide key count as a scaling operation starts.
+// It is a no-op unless VerifyKeyspaceIntegrity is enabled. A failed count is logged and leaves
+// the operation unverified rather than blocking it. The caller is responsible for persisting the status.
+func (r *RedisClusterReconciler) recordKeyCountBefore(ctx context.Context, cluster *appv1.RedisCluster) {
+	cluster.Status.KeyCountBeforeScale = nil
+	if !cluster.Spec.VerifyKeyspaceIntegrity {
+		return
+	}
+
+	keys, err := r.countClusterKeys(ctx, cluster)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to count keys before scaling, operation will not be verified")
+		return
+	}
+	log.FromContext(ctx).Info("Recorded key count before scaling", "keys", keys)
+	cluster.Status.KeyCountBeforeScale = &keys
+}
+
+// verifyKeyspaceIntegrity compares the cluster-wide key count with the count recorded when the
+// operation started. The counts are appended to LastScaleDecision, and a drop beyond
+// KeyspaceIntegrityTolerancePercent sets the KeyspaceIntegrityViolated condition and emits a
+// critical Warning event. New writes during the operation can only raise the count, so only a
+// drop is treated as a discrepancy. The caller is responsible for persisting the status.
+func (r *RedisClusterReconciler) verifyKeyspaceIntegrity(ctx context.Context, cluster *appv1.RedisCluster, operation string) {
+	logger := log.FromContext(ctx)
+	if cluster.Status.KeyCountBeforeScale == nil {
+		return
+	}
+	before := *cluster.Status.KeyCountBeforeScale
+	cluster.Status.KeyCountBeforeScale = nil
+
+	after, err := r.countClusterKeys(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Failed to count keys after scaling, keyspace integrity not verified", "operation", operation)
+		cluster.Status.LastScaleDecision += fmt.Sprintf("; keys before=%d after=unknown", before)
+		return
+	}
+	cluster.Status.LastScaleDecision += fmt.Sprintf("; keys before=%d after=%d", before, after)
+
+	allowedDrop := before * int64(cluster.Spec.KeyspaceIntegrityTolerancePercent) / 100
+	lost := before - after
+	if lost > allowedDrop {
+		logger.Info("Keys lost during scaling", "operation", operation, "before", before, "after", after, "allowedDrop", allowedDrop)
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               appv1.ConditionKeyspaceIntegrityViolated,
+			Status:             metav1.ConditionTrue,
+			Reason:             "KeysLost",
+			Message:            fmt.Sprintf("%s lost %d keys (before %d, after %d, tolerance %d%%)", operation, lost, before, after, cluster.Spec.KeyspaceIntegrityTolerancePercent),
+			ObservedGeneration: cluster.Generation,
+		})
+		r.recordWarning(cluster, appv1.ConditionKeyspaceIntegrityViolated,
+			"severity=critical cluster=%s/%s operation=%s keysBefore=%d keysAfter=%d tolerancePercent=%d",
+			cluster.Namespace, cluster.Name, operation, before, after, cluster.Spec.KeyspaceIntegrityTolerancePercent)
+		return
+	}
+
+	logger.Info("Keyspace integrity verified", "operation", operation, "before", before, "after", after)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionKeyspaceIntegrityViolated,
+		Status:             metav1.ConditionFalse,
+		Reason:             "KeysPreserved",
+		Message:            fmt.Sprintf("%s preserved keys (before %d, after %d)", operation, before, after),
+		ObservedGeneration: cluster.Generation,
+	})
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// manualScaleUpAnnotation and manualScaleDownAnnotation force one scale-up or scale-down
+// regardless of metrics, e.g. ahead of a known traffic event. The value names who requested it
+// and is reported in the event. The annotation is consumed once the request is carried out or
+// refused.
+const (
+	manualScaleUpAnnotation   = "rediscluster.cache.example.com/scale-up"
+	manualScaleDownAnnotation = "rediscluster.cache.example.com/scale-down"
+)
+
+// manualScaleRequested reports whether a manual scale-up or scale-down has been requested.
+func manualScaleRequested(cluster *appv1.RedisCluster) bool {
+	return cluster.Annotations[manualScaleUpAnnotation] != "" || cluster.Annotations[manualScaleDownAnnotation] != ""
+}
+
+// handleManualScaleRequest carries out a requested scale-up or scale-down. The metric thresholds,
+// stabilization, and cooldown are bypassed, but MinMasters, MaxMasters, and the health and
+// namespace concurrency checks of automatic scaling still apply. A request that would cross a
+// bound, or that asks for both directions, is refused and consumed; one blocked by a transient
+// condition is left in place and retried.
+func (r *RedisClusterReconciler) handleManualScaleRequest(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	requeueInterval := time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second
+
+	upRequester := cluster.Annotations[manualScaleUpAnnotation]
+	downRequester := cluster.Annotations[manualScaleDownAnnotation]
+
+	var refusal string
+	switch {
+	case upRequester != "" && downRequester != "":
+		refusal = "both a scale-up and a scale-down were requested"
+	case upRequester != "" && atMaxMasters(cluster):
+		refusal = fmt.Sprintf("the cluster already runs maxMasters (%d) masters", cluster.Spec.MaxMasters)
+	case downRequester != "" && cluster.Spec.Masters <= cluster.Spec.MinMasters:
+		refusal = fmt.Sprintf("the cluster already runs minMasters (%d) masters", cluster.Spec.MinMasters)
+	}
+	if refusal != "" {
+		if err := r.consumeManualScaleRequest(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("Refusing manual scaling request", "reason", refusal)
+		r.recordWarning(cluster, "ManualScaleRefused", "Manual scaling request refused: %s", refusal)
+		return ctrl.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	healthStatus := r.clusterHealthForScaling(ctx, cluster, false)
+	if !healthStatus.IsHealthy {
+		logger.Info("Deferring manual scaling request", "reason", healthStatus.Reason)
+		return ctrl.Result{RequeueAfter: healthStatus.RequeueAfter}, nil
+	}
+	if err := r.checkNamespaceScalingSlot(ctx, cluster); err != nil {
+		logger.Info("Deferring manual scaling request", "reason", err.Error())
+		return ctrl.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	// Metrics only pick the pods involved; the request does not depend on them.
+	podLoads, err := r.queryPodMetrics(ctx, cluster)
+	if err != nil {
+		logger.Info("Metrics unavailable for manual scaling, using the default pod choice", "reason", err.Error())
+	}
+
+	if upRequester != "" {
+		triggerPod := PodLoad{PodName: cluster.Name + "-0"}
+		for _, load := range podLoads {
+			if load.MemoryUsage > triggerPod.MemoryUsage {
+				triggerPod = load
+			}
+		}
+		if err := r.consumeManualScaleRequest(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+		reason := fmt.Sprintf("manual scale-up requested by %s", upRequester)
+		r.recordNormal(cluster, "ManualScaleUp", "Scale-up to %d masters requested by %s", cluster.Spec.Masters+1, upRequester)
+		return r.triggerScaleUp(ctx, cluster, triggerPod, reason)
+	}
+
+	plan, err := r.planScaleDown(ctx, cluster, podLoads)
+	if err != nil {
+		logger.Error(err, "Cannot plan manual scale-down")
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	if err := checkScaleDownHeadroom(cluster, plan, podLoads); err != nil {
+		if consumeErr := r.consumeManualScaleRequest(ctx, cluster); consumeErr != nil {
+			return ctrl.Result{}, consumeErr
+		}
+		r.recordWarning(cluster, "ManualScaleRefused", "Manual scale-down requested by %s refused: %v", downRequester, err)
+		return ctrl.Result{RequeueAfter: requeueInterval}, nil
+	}
+	if err := r.consumeManualScaleRequest(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+	reason := fmt.Sprintf("manual scale-down requested by %s", downRequester)
+	r.recordNormal(cluster, "ManualScaleDown", "Scale-down to %d masters requested by %s", cluster.Spec.Masters-1, downRequester)
+	return r.triggerScaleDown(ctx, cluster, plan, reason)
+}
+
+// consumeManualScaleRequest removes the manual scaling annotations. It updates the object, so it
+// must run before any status change the caller wants to keep.
+func (r *RedisClusterReconciler) consumeManualScaleRequest(ctx context.Context, cluster *appv1.RedisCluster) error {
+	if err := r.patchCluster(ctx, cluster, func() {
+		delete(cluster.Annotations, manualScaleUpAnnotation)
+		delete(cluster.Annotations, manualScaleDownAnnotation)
+	}); err != nil {
+		return fmt.Errorf("failed to consume manual scaling annotation: %w", err)
+	}
+	return nil
+}
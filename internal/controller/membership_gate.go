@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// clusterMemberConditionType is the pod readiness gate that the operator sets once a pod is a
+// Redis cluster member. It is only added to the pod template when ClusterMemberReadinessGate is
+// set, and keeps pods that are still joining or syncing out of the Service endpoints.
+const clusterMemberConditionType corev1.PodConditionType = "cache.example.com/cluster-member"
+
+// clusterMemberCheckScript runs inside the redis container and prints "ready: <reason>" or
+// "not-ready: <reason>". Only the pod's own membership is checked: it must know other nodes or own
+// slots, and a replica must have a live link and no initial sync in progress. The cluster-wide
+// cluster_state is deliberately ignored, so a degraded cluster does not take every pod out of
+// Ready. The headless Service publishes not-ready addresses, so a pod failing the check stays
+// resolvable.
+const clusterMemberCheckScript = `
+redis-cli $REDIS_CLI_ARGS ping >/dev/null 2>&1 || { echo "not-ready: redis is not responding"; exit 0; }
+info=$(redis-cli $REDIS_CLI_ARGS cluster info | tr -d '\r')
+known=$(echo "$info" | grep '^cluster_known_nodes:' | cut -d: -f2)
+assigned=$(echo "$info" | grep '^cluster_slots_assigned:' | cut -d: -f2)
+if [ "${known:-0}" -le 1 ] && [ "${assigned:-0}" -eq 0 ]; then
+  echo "not-ready: not joined to a cluster"
+  exit 0
+fi
+repl=$(redis-cli $REDIS_CLI_ARGS info replication | tr -d '\r')
+if echo "$repl" | grep -q '^role:slave'; then
+  link=$(echo "$repl" | grep '^master_link_status:' | cut -d: -f2)
+  if [ "$link" != "up" ]; then
+    echo "not-ready: replication link is $link"
+    exit 0
+  fi
+  if echo "$repl" | grep -q '^master_sync_in_progress:1'; then
+    echo "not-ready: replica sync in progress"
+    exit 0
+  fi
+fi
+echo "ready: connected cluster member"
+`
+
+// clusterMemberReadinessGates returns the pod readiness gates of the Redis pods, which are empty
+// unless ClusterMemberReadinessGate is set.
+func clusterMemberReadinessGates(cluster *appv1.RedisCluster) []corev1.PodReadinessGate {
+	if !cluster.Spec.ClusterMemberReadinessGate {
+		return nil
+	}
+	return []corev1.PodReadinessGate{{ConditionType: clusterMemberConditionType}}
+}
+
+// reconcileMembershipGates evaluates the cluster membership of the running Redis pods whose
+// clusterMemberConditionType gate is missing or False, and sets the gate on those whose state has
+// changed. A pod that has passed the gate is not checked again, so the operator does not exec into
+// every pod on every reconcile. Pods whose containers are not ready are skipped since they cannot
+// be Ready regardless.
+func (r *RedisClusterReconciler) reconcileMembershipGates(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || !containersReady(pod) || clusterMemberGatePassed(pod) {
+			continue
+		}
+
+		output, err := r.execInPod(ctx, pod.Namespace, pod.Name, redisContainerName,
+			[]string{"sh", "-c", clusterMemberCheckScript})
+		if err != nil {
+			logger.Error(err, "Failed to check cluster membership", "pod", pod.Name)
+			continue
+		}
+
+		verdict, message, _ := strings.Cut(strings.TrimSpace(output), ": ")
+		status := corev1.ConditionFalse
+		reason := "NotClusterMember"
+		if verdict == "ready" {
+			status = corev1.ConditionTrue
+			reason = "ClusterMember"
+		}
+
+		if err := r.setMembershipGate(ctx, pod, status, reason, message); err != nil {
+			logger.Error(err, "Failed to update cluster membership gate", "pod", pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// clusterMemberGatePassed reports whether the pod's clusterMemberConditionType condition is True.
+func clusterMemberGatePassed(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == clusterMemberConditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// joinablePodCount returns how many pods of the StatefulSet are up and can be joined to the Redis
+// cluster. Without the membership gate this is ReadyReplicas. With it, a pod only turns Ready once
+// it is a cluster member, so the operator, which waits for pods before joining them, counts the
+// running pods whose containers are ready instead.
+func (r *RedisClusterReconciler) joinablePodCount(ctx context.Context, cluster *appv1.RedisCluster, sts *appsv1.StatefulSet) (int32, error) {
+	if !cluster.Spec.ClusterMemberReadinessGate {
+		return sts.Status.ReadyReplicas, nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return 0, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var count int32
+	for i := range podList.Items {
+		if podJoinable(cluster, &podList.Items[i]) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// podJoinable reports whether the pod is up and can be joined to the Redis cluster: Ready, or with
+// the membership gate, running with all containers ready.
+func podJoinable(cluster *appv1.RedisCluster, pod *corev1.Pod) bool {
+	if !cluster.Spec.ClusterMemberReadinessGate {
+		return isPodReady(pod)
+	}
+	return pod.Status.Phase == corev1.PodRunning && containersReady(pod)
+}
+
+// setMembershipGate patches the pod status with the membership condition if it differs
+// from the current value.
+func (r *RedisClusterReconciler) setMembershipGate(ctx context.Context, pod *corev1.Pod, status corev1.ConditionStatus, reason, message string) error {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == clusterMemberConditionType && condition.Status == status && condition.Reason == reason {
+			return nil
+		}
+	}
+
+	log.FromContext(ctx).Info("Updating cluster membership gate",
+		"pod", pod.Name,
+		"status", status,
+		"message", message)
+
+	patch := client.StrategicMergeFrom(pod.DeepCopy())
+	condition := corev1.PodCondition{
+		Type:               clusterMemberConditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	updated := false
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == clusterMemberConditionType {
+			pod.Status.Conditions[i] = condition
+			updated = true
+		}
+	}
+	if !updated {
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	}
+
+	return r.Status().Patch(ctx, pod, patch)
+}
+
+// containersReady reports whether all containers in the pod are ready.
+func containersReady(pod *corev1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return false
+		}
+	}
+	return true
+}
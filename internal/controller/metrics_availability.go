@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// recordMetricsAvailability tracks whether the last metrics cycle read the load of every active
+// master. A failure (non-nil problem) sets MetricsUnavailableSince on the first failed cycle and
+// the MetricsDegraded condition once MetricsGracePeriodSeconds have passed; a successful cycle
+// clears both. The status is persisted only when it changes.
+func (r *RedisClusterReconciler) recordMetricsAvailability(ctx context.Context, cluster *appv1.RedisCluster, problem error) error {
+	logger := log.FromContext(ctx)
+	changed := false
+
+	if problem == nil {
+		if cluster.Status.MetricsUnavailableSince != nil {
+			logger.Info("Metrics available again", "unavailableSince", cluster.Status.MetricsUnavailableSince.Time)
+			cluster.Status.MetricsUnavailableSince = nil
+			changed = true
+		}
+		if meta.IsStatusConditionTrue(cluster.Status.Conditions, appv1.ConditionMetricsDegraded) {
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:               appv1.ConditionMetricsDegraded,
+				Status:             metav1.ConditionFalse,
+				Reason:             "MetricsAvailable",
+				Message:            "Metrics are available for every active master",
+				ObservedGeneration: cluster.Generation,
+			})
+			r.recordNormal(cluster, "MetricsRestored", "Metrics are available again, autoscaling resumed")
+			changed = true
+		}
+	} else {
+		if cluster.Status.MetricsUnavailableSince == nil {
+			now := metav1.Now()
+			cluster.Status.MetricsUnavailableSince = &now
+			changed = true
+		}
+		grace := time.Duration(cluster.Spec.MetricsGracePeriodSeconds) * time.Second
+		unavailableFor := time.Since(cluster.Status.MetricsUnavailableSince.Time)
+		if unavailableFor >= grace && !meta.IsStatusConditionTrue(cluster.Status.Conditions, appv1.ConditionMetricsDegraded) {
+			message := fmt.Sprintf("Metrics unavailable for %s, scale-downs are blocked: %v", unavailableFor.Round(time.Second), problem)
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:               appv1.ConditionMetricsDegraded,
+				Status:             metav1.ConditionTrue,
+				Reason:             "MetricsUnavailable",
+				Message:            message,
+				ObservedGeneration: cluster.Generation,
+			})
+			r.recordWarning(cluster, appv1.ConditionMetricsDegraded, "%s", message)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update metrics availability: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// MetricsProvider reads the load of a cluster's active masters.
+type MetricsProvider interface {
+	// PodLoads returns the load of each active master, excluding the standby pod. With
+	// IncludeReplicasInMetrics, each entry is the highest load of the master's shard.
+	PodLoads(ctx context.Context, cluster *appv1.RedisCluster) ([]PodLoad, error)
+}
+
+// metricsProvider returns the provider selected by MetricsSource.
+func (r *RedisClusterReconciler) metricsProvider(cluster *appv1.RedisCluster) MetricsProvider {
+	if cluster.Spec.MetricsSource == appv1.MetricsSourceMetricsServer {
+		return &metricsServerProvider{r: r}
+	}
+	return &prometheusProvider{r: r}
+}
+
+// prometheusProvider reads pod load from Prometheus through the configured query templates.
+type prometheusProvider struct {
+	r *RedisClusterReconciler
+}
+
+// PodLoads implements MetricsProvider.
+func (p *prometheusProvider) PodLoads(ctx context.Context, cluster *appv1.RedisCluster) ([]PodLoad, error) {
+	return p.r.queryPrometheusPodLoads(ctx, cluster)
+}
+
+// podMetricsListGVK is the metrics.k8s.io list kind served by metrics-server. It is read as
+// unstructured so the operator does not depend on the metrics client libraries.
+var podMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// metricsServerProvider reads pod load from the metrics.k8s.io API. CPU usage is reported as a
+// percentage of one core and memory usage as the working set as a percentage of the redis
+// container's memory limit, matching the default Prometheus queries. Masters are identified from
+// CLUSTER NODES, since the exporter's role label is not available. The eviction rate is always 0.
+type metricsServerProvider struct {
+	r *RedisClusterReconciler
+}
+
+// PodLoads implements MetricsProvider.
+func (p *metricsServerProvider) PodLoads(ctx context.Context, cluster *appv1.RedisCluster) ([]PodLoad, error) {
+	podList := &corev1.PodList{}
+	if err := p.r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	included := make(map[string]bool)
+	if cluster.Spec.IncludeReplicasInMetrics {
+		for _, pod := range podList.Items {
+			included[pod.Name] = true
+		}
+	} else {
+		_, nodes, err := p.r.queryClusterView(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		masterIPs := make(map[string]bool)
+		for _, node := range nodes {
+			if node.IsMaster() {
+				masterIPs[node.IP] = true
+			}
+		}
+		for _, pod := range podList.Items {
+			if pod.Status.PodIP != "" && masterIPs[pod.Status.PodIP] {
+				included[pod.Name] = true
+			}
+		}
+	}
+
+	memoryLimits := make(map[string]int64)
+	for _, pod := range podList.Items {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == "redis" {
+				memoryLimits[pod.Name] = container.Resources.Limits.Memory().Value()
+			}
+		}
+	}
+
+	metricsList := &unstructured.UnstructuredList{}
+	metricsList.SetGroupVersionKind(podMetricsListGVK)
+	if err := p.r.List(ctx, metricsList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return nil, fmt.Errorf("failed to read pod metrics from metrics.k8s.io (is metrics-server installed?): %w", err)
+	}
+
+	cpuMap := make(map[string]float64)
+	memoryMap := make(map[string]float64)
+	for _, item := range metricsList.Items {
+		podName := item.GetName()
+		if !included[podName] {
+			continue
+		}
+		cpu, memory, ok := redisContainerUsage(item.Object)
+		if !ok {
+			continue
+		}
+		cpuMap[podName] = float64(cpu.MilliValue()) / 10
+		if limit := memoryLimits[podName]; limit > 0 {
+			memoryMap[podName] = float64(memory.Value()) / float64(limit) * 100
+		}
+	}
+	if len(cpuMap) == 0 {
+		return nil, fmt.Errorf("no pod metrics available from metrics.k8s.io for %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	return p.r.buildPodLoads(ctx, cluster, cpuMap, memoryMap, nil)
+}
+
+// redisContainerUsage returns the CPU and memory usage of the redis container in a PodMetrics
+// object, or false if it is missing or malformed.
+func redisContainerUsage(podMetrics map[string]interface{}) (resource.Quantity, resource.Quantity, bool) {
+	containers, _, _ := unstructured.NestedSlice(podMetrics, "containers")
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok || container["name"] != "redis" {
+			continue
+		}
+		usage, _, _ := unstructured.NestedStringMap(container, "usage")
+		cpu, errCPU := resource.ParseQuantity(usage["cpu"])
+		memory, errMemory := resource.ParseQuantity(usage["memory"])
+		if errCPU != nil || errMemory != nil {
+			return resource.Quantity{}, resource.Quantity{}, false
+		}
+		return cpu, memory, true
+	}
+	return resource.Quantity{}, resource.Quantity{}, false
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// The metrics below describe the autoscaler itself, as opposed to the Redis exporter metrics of
+// the pods it manages. They are registered with the controller-runtime registry and served on the
+// manager's --metrics-bind-address endpoint, keyed by the namespace and name of each RedisCluster.
+var (
+	scaleOperationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "redis_operator_scale_operations_total",
+		Help: "Number of scaling operations triggered by the autoscaler, by direction.",
+	}, []string{"namespace", "cluster", "direction"})
+
+	clusterPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_operator_cluster_phase",
+		Help: "Current phase of the RedisCluster: 1 for the phase it is in, 0 for the others.",
+	}, []string{"namespace", "cluster", "phase"})
+
+	lastDecisionTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_operator_last_decision_timestamp_seconds",
+		Help: "Unix time of the last autoscaling evaluation that reached a decision, including the decision not to scale.",
+	}, []string{"namespace", "cluster"})
+
+	lastDecisionInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_operator_last_decision_info",
+		Help: "Always 1; the direction and reason labels describe the last autoscaling decision.",
+	}, []string{"namespace", "cluster", "direction", "reason"})
+
+	metricsQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "redis_operator_metrics_query_duration_seconds",
+		Help:    "Latency of the pod load queries against the metrics source.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"namespace", "cluster"})
+
+	metricsQueryErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "redis_operator_metrics_query_errors_total",
+		Help: "Number of pod load queries against the metrics source that failed.",
+	}, []string{"namespace", "cluster"})
+)
+
+// clusterPhases lists every phase so that clusterPhase reports 0 for the ones a cluster is not in.
+var clusterPhases = []appv1.RedisClusterPhase{
+	appv1.RedisClusterPhasePending,
+	appv1.RedisClusterPhaseScaling,
+	appv1.RedisClusterPhaseDegraded,
+	appv1.RedisClusterPhaseReady,
+}
+
+// decisionNone is the direction recorded when an evaluation decides not to scale.
+const decisionNone = "none"
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		scaleOperationsTotal,
+		clusterPhase,
+		lastDecisionTimestamp,
+		lastDecisionInfo,
+		metricsQueryDuration,
+		metricsQueryErrorsTotal,
+	)
+}
+
+// observeScaleOperation counts a triggered scaling operation and records it as the last decision.
+func observeScaleOperation(cluster *appv1.RedisCluster, direction string, reason string) {
+	scaleOperationsTotal.WithLabelValues(cluster.Namespace, cluster.Name, direction).Inc()
+	observeDecision(cluster, direction, reason)
+}
+
+// observeDecision records the outcome of an autoscaling evaluation. Only the latest reason is
+// kept, so each cluster has a single lastDecisionInfo series.
+func observeDecision(cluster *appv1.RedisCluster, direction string, reason string) {
+	lastDecisionTimestamp.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(time.Now().Unix()))
+	lastDecisionInfo.DeletePartialMatch(clusterMetricLabels(cluster))
+	lastDecisionInfo.WithLabelValues(cluster.Namespace, cluster.Name, direction, reason).Set(1)
+}
+
+// observeMetricsQuery records the latency and, when err is set, the failure of a pod load query.
+func observeMetricsQuery(cluster *appv1.RedisCluster, started time.Time, err error) {
+	metricsQueryDuration.WithLabelValues(cluster.Namespace, cluster.Name).Observe(time.Since(started).Seconds())
+	if err != nil {
+		metricsQueryErrorsTotal.WithLabelValues(cluster.Namespace, cluster.Name).Inc()
+	}
+}
+
+// observePhase sets clusterPhase to 1 for current and 0 for the other phases.
+func observePhase(cluster *appv1.RedisCluster, current appv1.RedisClusterPhase) {
+	for _, phase := range clusterPhases {
+		value := 0.0
+		if phase == current {
+			value = 1
+		}
+		clusterPhase.WithLabelValues(cluster.Namespace, cluster.Name, string(phase)).Set(value)
+	}
+}
+
+// forgetClusterMetrics drops every series of a deleted cluster.
+func forgetClusterMetrics(cluster *appv1.RedisCluster) {
+	labels := clusterMetricLabels(cluster)
+	scaleOperationsTotal.DeletePartialMatch(labels)
+	clusterPhase.DeletePartialMatch(labels)
+	lastDecisionTimestamp.DeletePartialMatch(labels)
+	lastDecisionInfo.DeletePartialMatch(labels)
+	metricsQueryDuration.DeletePartialMatch(labels)
+	metricsQueryErrorsTotal.DeletePartialMatch(labels)
+}
+
+func clusterMetricLabels(cluster *appv1.RedisCluster) prometheus.Labels {
+	return prometheus.Labels{"namespace": cluster.Namespace, "cluster": cluster.Name}
+}
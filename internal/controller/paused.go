@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// setPausedCondition records the Paused condition from Spec.Paused, persisting the status only
+// when the condition changes.
+func (r *RedisClusterReconciler) setPausedCondition(ctx context.Context, cluster *appv1.RedisCluster) error {
+	status, reason, message := metav1.ConditionFalse, "Active", "Autoscaling is making scaling decisions"
+	if cluster.Spec.Paused {
+		status, reason, message = metav1.ConditionTrue, "PausedBySpec", "Autoscaling is paused; operations in progress still complete"
+	}
+
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionPaused)
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+	if current == nil && status == metav1.ConditionFalse {
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionPaused,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if status == metav1.ConditionTrue {
+		r.recordNormal(cluster, "AutoscalingPaused", "%s", message)
+	} else {
+		r.recordNormal(cluster, "AutoscalingResumed", "%s", message)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update Paused condition: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// Pod annotations describing each pod's place in the observed cluster topology.
+const (
+	podRoleAnnotation       = "rediscluster.cache.example.com/role"
+	podShardIndexAnnotation = "rediscluster.cache.example.com/shard-index"
+	podIsStandbyAnnotation  = "rediscluster.cache.example.com/is-standby"
+)
+
+// reconcilePodRoleAnnotations annotates every running Redis pod with its role (master or
+// replica), its shard index, and whether it belongs to the standby shard, as reported by
+// CLUSTER NODES. A replica takes the shard index of the master it replicates, and a shard's
+// index is its master's ordinal divided by the shard size, matching the pod layout. Pods that
+// are not in the node table are left untouched.
+func (r *RedisClusterReconciler) reconcilePodRoleAnnotations(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	_, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	podByIP := make(map[string]string)
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podByIP[pod.Status.PodIP] = pod.Name
+		}
+	}
+	nodeByID := make(map[string]redis.ClusterNode)
+	nodeByIP := make(map[string]redis.ClusterNode)
+	for _, node := range nodes {
+		if !node.IsFailed() {
+			nodeByID[node.ID] = node
+			nodeByIP[node.IP] = node
+		}
+	}
+
+	shardSize := int(1 + cluster.Spec.ReplicasPerMaster)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		node, ok := nodeByIP[pod.Status.PodIP]
+		if !ok {
+			continue
+		}
+
+		role := "replica"
+		masterPod := pod.Name
+		if node.IsMaster() {
+			role = "master"
+		} else if master, ok := nodeByID[node.MasterID]; ok {
+			masterPod = podByIP[master.IP]
+		} else {
+			continue
+		}
+
+		masterIndex, ok := podOrdinal(cluster, masterPod)
+		if !ok {
+			continue
+		}
+
+		desired := map[string]string{
+			podRoleAnnotation:       role,
+			podShardIndexAnnotation: strconv.Itoa(masterIndex / shardSize),
+			podIsStandbyAnnotation:  strconv.FormatBool(isStandbyPod(cluster, masterPod)),
+		}
+		if err := r.patchPodAnnotations(ctx, pod, desired); err != nil {
+			logger.Error(err, "Failed to annotate pod with its cluster role", "pod", pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// patchPodAnnotations merges the given annotations into the pod if any of them differ.
+func (r *RedisClusterReconciler) patchPodAnnotations(ctx context.Context, pod *corev1.Pod, annotations map[string]string) error {
+	changed := false
+	for key, value := range annotations {
+		if pod.Annotations[key] != value {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Updating pod role annotations",
+		"pod", pod.Name,
+		"role", annotations[podRoleAnnotation],
+		"shard", annotations[podShardIndexAnnotation],
+		"standby", annotations[podIsStandbyAnnotation])
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	for key, value := range annotations {
+		pod.Annotations[key] = value
+	}
+	return r.Patch(ctx, pod, patch)
+}
@@ -0,0 +1,17 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// applyPodSecurity gives a Job pod the image pull secrets and security contexts of the Redis pods,
+// so Jobs pull from the same registry and meet the same pod security requirements.
+func applyPodSecurity(cluster *appv1.RedisCluster, podSpec *corev1.PodSpec) {
+	podSpec.ImagePullSecrets = cluster.Spec.ImagePullSecrets
+	podSpec.SecurityContext = cluster.Spec.PodSecurityContext
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].SecurityContext = cluster.Spec.SecurityContext
+	}
+}
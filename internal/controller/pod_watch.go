@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// podToRedisClusters maps a Redis pod to the RedisClusters whose pod selector matches it, so a
+// crash-looping or failed-over pod, in particular the standby, is noticed without waiting for
+// the next MetricsQueryInterval requeue. Pods of existing clusters are not owned by the
+// RedisCluster, hence the label lookup rather than owner references.
+func (r *RedisClusterReconciler) podToRedisClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusters := &appv1.RedisClusterList{}
+	if err := r.List(ctx, clusters, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list RedisClusters for pod event", "pod", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if labels.SelectorFromSet(getLabels(cluster)).Matches(labels.Set(obj.GetLabels())) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// podStateChanged passes pod creations and deletions, and updates that change the pod's phase,
+// IP, readiness, or container restart counts. Label and annotation updates, e.g. the role
+// annotations the operator sets itself, are filtered out.
+var podStateChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+		newPod, okNew := e.ObjectNew.(*corev1.Pod)
+		if !okOld || !okNew {
+			return false
+		}
+		return oldPod.Status.Phase != newPod.Status.Phase ||
+			oldPod.Status.PodIP != newPod.Status.PodIP ||
+			containersReady(oldPod) != containersReady(newPod) ||
+			restartCount(oldPod) != restartCount(newPod)
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// standbyNeedsRedetection reports whether the recorded standby pod is gone or not running and
+// ready, in which case the standby reference may be stale.
+func (r *RedisClusterReconciler) standbyNeedsRedetection(ctx context.Context, cluster *appv1.RedisCluster) bool {
+	if cluster.Status.StandbyPod == "" {
+		return false
+	}
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Status.StandbyPod, Namespace: cluster.Namespace}, pod); err != nil {
+		return true
+	}
+	return pod.Status.Phase != corev1.PodRunning || !containersReady(pod)
+}
+
+// restartCount returns the total restart count of the pod's containers.
+func restartCount(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
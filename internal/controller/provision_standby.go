@@ -8,6 +8,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,9 +23,13 @@ import (
 func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Calculate the new standby indices
-	newStandbyIndex := cluster.Spec.Masters * (1 + cluster.Spec.ReplicasPerMaster)
-	newStandbyPod := fmt.Sprintf("%s-%d", cluster.Name, newStandbyIndex)
+	// The new standby is appended at the end of the pool
+	newStandbyPod := fmt.Sprintf("%s-%d", cluster.Name, lastStandbyIndex(cluster))
+
+	timeout := time.Duration(cluster.Spec.StandbyProvisioningTimeoutSeconds) * time.Second
+	if start := cluster.Status.ProvisioningStartTime; start != nil && time.Since(start.Time) > timeout {
+		return r.failStandbyProvisioning(ctx, cluster, newStandbyPod, timeout)
+	}
 
 	// Check if new standby pod is ready
 	standbyPod := &corev1.Pod{}
@@ -45,21 +50,23 @@ func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cl
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	}
 
-	// Check if pod is ready
-	podReady := false
-	for _, condition := range standbyPod.Status.Conditions {
-		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
-			podReady = true
-			break
-		}
-	}
-
-	if !podReady {
+	if !podJoinable(cluster, standbyPod) {
 		logger.Info("New standby pod not yet ready, waiting",
 			"standbyPod", newStandbyPod)
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	}
 
+	if cluster.Spec.WaitForStandbyReplicas {
+		if replicaPod, err := r.firstUnreadyStandbyReplica(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		} else if replicaPod != "" {
+			logger.Info("New standby replica pod not yet ready, waiting",
+				"standbyPod", newStandbyPod,
+				"replicaPod", replicaPod)
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+
 	logger.Info("New standby pod is ready, checking for join-nodes job",
 		"standbyPod", newStandbyPod,
 		"podIP", standbyPod.Status.PodIP)
@@ -70,6 +77,11 @@ func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cl
 	err = r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, joinJob)
 
 	if err != nil && errors.IsNotFound(err) {
+		if err := r.joinStandbyPods(ctx, cluster); err != nil {
+			logger.Info("New pods not yet joined to cluster, waiting", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
 		logger.Info("Creating join-nodes job to add new pods to cluster")
 
 		job := r.joinNodesJobForRedisCluster(cluster)
@@ -81,6 +93,7 @@ func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cl
 			logger.Error(err, "Failed to create join-nodes job")
 			return ctrl.Result{}, err
 		}
+		r.recordNormal(cluster, "JoinNodesStarted", "Created join-nodes job %s for new standby %s", jobName, newStandbyPod)
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 
 	} else if err != nil {
@@ -92,9 +105,24 @@ func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cl
 	if joinJob.Status.Succeeded > 0 {
 		logger.Info("Join-nodes job succeeded, finalizing provisioning")
 
-		// Update standby pod in status and clear provisioning flag
-		cluster.Status.StandbyPod = newStandbyPod
+		// The old standby now serves migrated slots; track its replicas until they catch up.
+		cluster.Status.ReplicaSyncPendingPod = cluster.Status.StandbyPod
+
+		// The next standby in the pool is activated by the following scale-up
+		setStandbyPool(cluster, standbyPoolPods(cluster))
 		cluster.Status.IsProvisioningStandby = false
+		cluster.Status.ProvisioningStartTime = nil
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               appv1.ConditionStandbyProvisioningFailed,
+			Status:             metav1.ConditionFalse,
+			Reason:             "StandbyProvisioned",
+			Message:            fmt.Sprintf("Standby %s provisioned", newStandbyPod),
+			ObservedGeneration: cluster.Generation,
+		})
+		r.verifyKeyspaceIntegrity(ctx, cluster, "scale-up")
+		r.recordNormal(cluster, "ScaleUpComplete", "Provisioned new standby %s, cluster now has %d masters",
+			newStandbyPod, cluster.Spec.Masters)
+		endOperation(cluster)
 
 		if err := r.Status().Update(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to update status after provisioning")
@@ -110,9 +138,12 @@ func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cl
 
 	if joinJob.Status.Failed > 0 {
 		logger.Error(fmt.Errorf("join-nodes job %s failed", jobName), "Failed to join nodes")
+		r.recordWarning(cluster, "JoinNodesFailed", "Join-nodes job %s failed for new standby %s", jobName, newStandbyPod)
+		endOperation(cluster)
 		// Clean up the failed job to allow a retry
 		_ = r.Delete(ctx, joinJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		cluster.Status.IsProvisioningStandby = false
+		cluster.Status.ProvisioningStartTime = nil
 		if err := r.Status().Update(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to update status after failed join")
 			return ctrl.Result{}, err
@@ -123,3 +154,109 @@ func (r *RedisClusterReconciler) checkProvisioningStatus(ctx context.Context, cl
 	logger.Info("Join-nodes job is still running...")
 	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 }
+
+// firstUnreadyStandbyReplica returns the name of the first replica pod of the new standby that
+// does not exist yet or is not ready, or "" once all of them are ready.
+func (r *RedisClusterReconciler) firstUnreadyStandbyReplica(ctx context.Context, cluster *appv1.RedisCluster) (string, error) {
+	newStandbyIndex := lastStandbyIndex(cluster)
+	for i := int32(1); i <= cluster.StandbyReplicaCount(); i++ {
+		replicaPod := fmt.Sprintf("%s-%d", cluster.Name, newStandbyIndex+i)
+		pod := &corev1.Pod{}
+		if err := r.Get(ctx, client.ObjectKey{Name: replicaPod, Namespace: cluster.Namespace}, pod); err != nil {
+			if errors.IsNotFound(err) {
+				return replicaPod, nil
+			}
+			return "", err
+		}
+		if pod.Status.Phase != corev1.PodRunning || !podJoinable(cluster, pod) {
+			return replicaPod, nil
+		}
+	}
+	return "", nil
+}
+
+// isPodReady reports whether the pod's Ready condition is True.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// failStandbyProvisioning abandons a standby provisioning that exceeded its timeout. It clears the
+// provisioning state so the cluster is no longer stuck, removes any join job, and sets the
+// StandbyProvisioningFailed condition with what the operator was still waiting for.
+func (r *RedisClusterReconciler) failStandbyProvisioning(ctx context.Context, cluster *appv1.RedisCluster, newStandbyPod string, timeout time.Duration) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	reason := fmt.Sprintf("pod %s did not join the cluster", newStandbyPod)
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Name: newStandbyPod, Namespace: cluster.Namespace}, pod); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		reason = fmt.Sprintf("pod %s was never created", newStandbyPod)
+	} else if pod.Status.Phase != corev1.PodRunning {
+		reason = fmt.Sprintf("pod %s is %s", newStandbyPod, pod.Status.Phase)
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				reason += fmt.Sprintf(" (%s: %s)", condition.Reason, condition.Message)
+			}
+		}
+	}
+
+	message := fmt.Sprintf("Gave up provisioning standby after %s: %s", timeout, reason)
+	logger.Error(fmt.Errorf("standby provisioning timed out"), message)
+	r.recordWarning(cluster, appv1.ConditionStandbyProvisioningFailed, "%s", message)
+
+	joinJob := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Name + "-join-nodes", Namespace: cluster.Namespace}, joinJob); err == nil {
+		_ = r.Delete(ctx, joinJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionStandbyProvisioningFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Timeout",
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	endOperation(cluster)
+	cluster.Status.IsProvisioningStandby = false
+	cluster.Status.ProvisioningStartTime = nil
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status after standby provisioning timeout")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second}, nil
+}
+
+// joinStandbyPods idempotently joins the pods created by a scale-up: any replicas the just-activated
+// master is missing, the new standby master at the end of the pool, and the new standby's replicas. The join-nodes job that
+// follows then finds every pod already present and only verifies the resulting topology.
+func (r *RedisClusterReconciler) joinStandbyPods(ctx context.Context, cluster *appv1.RedisCluster) error {
+	activatedMasterIndex := (cluster.Spec.Masters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
+	activatedMasterPod := fmt.Sprintf("%s-%d", cluster.Name, activatedMasterIndex)
+	for i := cluster.StandbyReplicaCount() + 1; i <= cluster.Spec.ReplicasPerMaster; i++ {
+		replicaPod := fmt.Sprintf("%s-%d", cluster.Name, activatedMasterIndex+i)
+		if err := r.ensureClusterMember(ctx, cluster, replicaPod, activatedMasterPod); err != nil {
+			return err
+		}
+	}
+
+	newStandbyIndex := lastStandbyIndex(cluster)
+	newStandbyPod := fmt.Sprintf("%s-%d", cluster.Name, newStandbyIndex)
+	if err := r.ensureClusterMember(ctx, cluster, newStandbyPod, ""); err != nil {
+		return err
+	}
+	for i := int32(1); i <= cluster.StandbyReplicaCount(); i++ {
+		replicaPod := fmt.Sprintf("%s-%d", cluster.Name, newStandbyIndex+i)
+		if err := r.ensureClusterMember(ctx, cluster, replicaPod, newStandbyPod); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// dataVolumeName is the name of the StatefulSet's volume claim template, so the PVC of pod
+// <cluster>-<ordinal> is data-<cluster>-<ordinal>.
+const dataVolumeName = "data"
+
+// pvcRetentionPolicy returns the StatefulSet PVC retention policy: PVCs of scaled-down pods are
+// deleted when ReclaimScaledDownPVCs is set, and kept when the StatefulSet itself is deleted.
+func pvcRetentionPolicy(cluster *appv1.RedisCluster) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	if !cluster.Spec.ReclaimScaledDownPVCs {
+		return nil
+	}
+	return &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+	}
+}
+
+// reclaimScaledDownPVCs deletes the PVCs of pods beyond the StatefulSet's replica count when the
+// API server does not apply the whenScaled retention policy itself. A PVC is only deleted once
+// nothing can bring its pod back soon: no scaling operation is in progress, the StatefulSet has
+// settled at the replica count the spec asks for, and the pod no longer exists. A later scale-up
+// then starts the pod on a fresh volume, which is what the cleanup job leaves it as anyway.
+func (r *RedisClusterReconciler) reclaimScaledDownPVCs(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	if isScaling(cluster) {
+		return nil
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}, sts); err != nil {
+		return fmt.Errorf("failed to get StatefulSet: %w", err)
+	}
+	if policy := sts.Spec.PersistentVolumeClaimRetentionPolicy; policy != nil &&
+		policy.WhenScaled == appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+		return nil
+	}
+	if sts.Spec.Replicas == nil || *sts.Spec.Replicas != desiredPodCount(cluster) || sts.Status.Replicas != *sts.Spec.Replicas {
+		return nil
+	}
+	replicas := int(*sts.Spec.Replicas)
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list PVCs: %w", err)
+	}
+
+	prefix := fmt.Sprintf("%s-%s-", dataVolumeName, sts.Name)
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if !strings.HasPrefix(pvc.Name, prefix) {
+			continue
+		}
+		var ordinal int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(pvc.Name, prefix), "%d", &ordinal); err != nil || ordinal < replicas {
+			continue
+		}
+
+		podName := fmt.Sprintf("%s-%d", sts.Name, ordinal)
+		err := r.Get(ctx, client.ObjectKey{Name: podName, Namespace: cluster.Namespace}, &corev1.Pod{})
+		if err == nil {
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get pod %s: %w", podName, err)
+		}
+
+		logger.Info("Deleting PVC of scaled-down pod", "pvc", pvc.Name, "pod", podName)
+		if err := r.Delete(ctx, pvc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete PVC %s: %w", pvc.Name, err)
+		}
+		r.recordNormal(cluster, "PVCReclaimed", "Deleted PVC %s of scaled-down pod %s", pvc.Name, podName)
+	}
+
+	return nil
+}
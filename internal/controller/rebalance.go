@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// rebalanceAnnotation requests an on-demand slot rebalance across the active masters when set to
+// any non-empty value. It is consumed once the rebalance starts.
+const rebalanceAnnotation = "cache.example.com/rebalance"
+
+// rebalanceRequested reports whether a manual rebalance has been requested.
+func rebalanceRequested(cluster *appv1.RedisCluster) bool {
+	return cluster.Annotations[rebalanceAnnotation] != ""
+}
+
+// handleRebalanceRequest starts a requested rebalance once the cluster passes the same health,
+// cooldown, and namespace concurrency checks as a scaling operation. Until then the request is
+// left in place and retried, so it is never lost to a transient condition.
+func (r *RedisClusterReconciler) handleRebalanceRequest(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	requeueInterval := time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second
+
+	healthStatus := r.isClusterHealthyForScaling(ctx, cluster)
+	if !healthStatus.IsHealthy {
+		logger.Info("Deferring requested rebalance", "reason", healthStatus.Reason)
+		return ctrl.Result{RequeueAfter: healthStatus.RequeueAfter}, nil
+	}
+	if err := r.checkNamespaceScalingSlot(ctx, cluster); err != nil {
+		logger.Info("Deferring requested rebalance", "reason", err.Error())
+		return ctrl.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	request := cluster.Annotations[rebalanceAnnotation]
+	if err := r.patchCluster(ctx, cluster, func() { delete(cluster.Annotations, rebalanceAnnotation) }); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to consume rebalance annotation: %w", err)
+	}
+
+	beginOperation(cluster)
+	ctx = withOperationLogger(ctx, cluster)
+	logger = log.FromContext(ctx)
+
+	cluster.Status.IsRebalancing = true
+	cluster.Status.LastScaleDecision = fmt.Sprintf("rebalance of %d masters: requested (%s)", cluster.Spec.Masters, request)
+	r.recordKeyCountBefore(ctx, cluster)
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status to IsRebalancing")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Triggered requested rebalance", "request", request, "masters", cluster.Spec.Masters)
+	r.recordNormal(cluster, "RebalanceTriggered", "Rebalancing slots across %d masters (request %s)", cluster.Spec.Masters, request)
+	return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+}
+
+// checkRebalanceStatus creates the rebalance job if needed and records its outcome. A failed
+// rebalance leaves every slot on a live master, so no rollback is needed; the job is removed so a
+// new request can be made after the failure has been reviewed.
+func (r *RedisClusterReconciler) checkRebalanceStatus(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	jobName := cluster.Name + "-rebalance"
+
+	rebalanceJob := &batchv1.Job{}
+	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, rebalanceJob)
+
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating rebalance job")
+
+		job := r.reshardJobForRedisCluster(cluster, reshardRebalance, "", "")
+		if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
+			logger.Error(err, "Failed to set owner reference on rebalance job")
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			logger.Error(err, "Failed to create rebalance job")
+			return ctrl.Result{}, err
+		}
+		r.recordNormal(cluster, "RebalanceStarted", "Created rebalance job %s", jobName)
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+
+	} else if err != nil {
+		logger.Error(err, "Failed to get rebalance job")
+		return ctrl.Result{}, err
+	}
+
+	if rebalanceJob.Status.Succeeded == 0 && rebalanceJob.Status.Failed == 0 {
+		logger.Info("Rebalance job is still running...")
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
+	now := metav1.Now()
+	if rebalanceJob.Status.Succeeded > 0 {
+		logger.Info("Rebalance job succeeded")
+		r.recordNormal(cluster, "RebalanceComplete", "Slots rebalanced across %d masters", cluster.Spec.Masters)
+	} else {
+		logger.Error(fmt.Errorf("rebalance job %s failed", jobName), "Rebalancing failed")
+		cluster.Status.LastScaleFailure = fmt.Sprintf("rebalance failed at %s; review the cluster before requesting another",
+			now.Format(time.RFC3339))
+		r.recordWarning(cluster, "RebalanceFailed", "Rebalance job %s failed", jobName)
+	}
+
+	r.verifyKeyspaceIntegrity(ctx, cluster, "rebalance")
+	endOperation(cluster)
+	cluster.Status.IsRebalancing = false
+	cluster.Status.LastScaleTime = &now
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status after rebalance")
+		return ctrl.Result{}, err
+	}
+
+	_ = r.Delete(ctx, rebalanceJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second}, nil
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// redisContainerName is the name of the Redis server container in each StatefulSet pod.
+const redisContainerName = "redis"
+
+// execRedisCLI runs redis-cli with the given arguments inside the redis container of a pod
+// and returns its stdout. The command talks to the local Redis server of that pod, using the
+// connection arguments (port, TLS) from the container's REDIS_CLI_ARGS environment variable.
+func (r *RedisClusterReconciler) execRedisCLI(ctx context.Context, namespace, podName string, args ...string) (string, error) {
+	command := append([]string{"sh", "-c", `exec redis-cli $REDIS_CLI_ARGS "$@"`, "redis-cli"}, args...)
+	return r.execInPod(ctx, namespace, podName, redisContainerName, command)
+}
+
+// execInPod executes a command in the given pod container using the Kubernetes exec API.
+// Returns stdout on success, or an error including stderr if the command failed.
+func (r *RedisClusterReconciler) execInPod(ctx context.Context, namespace, podName, container string, command []string) (string, error) {
+	if r.Config == nil {
+		return "", fmt.Errorf("cannot exec in pod %s: no REST config available", podName)
+	}
+
+	clientset, err := kubernetes.NewForConfig(r.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(r.Config, "POST", req.URL())
+	if err != nil {
+		return "", fmt.Errorf("failed to create executor for pod %s: %w", podName, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}); err != nil {
+		return "", fmt.Errorf("exec %q in pod %s failed: %w (stderr: %s)",
+			strings.Join(command, " "), podName, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// queryClusterNodes runs CLUSTER NODES on a running Redis pod that is part of the cluster
+// and returns the parsed node table.
+func (r *RedisClusterReconciler) queryClusterNodes(ctx context.Context, cluster *appv1.RedisCluster) ([]redis.ClusterNode, error) {
+	_, nodes, err := r.queryClusterView(ctx, cluster)
+	return nodes, err
+}
+
+// queryClusterView returns the name of a running pod that is part of the cluster together with
+// its CLUSTER NODES table. Pods that only know themselves (not yet joined) are skipped, since
+// their view says nothing about the rest of the cluster.
+func (r *RedisClusterReconciler) queryClusterView(ctx context.Context, cluster *appv1.RedisCluster) (string, []redis.ClusterNode, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return "", nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || !containersReady(pod) {
+			continue
+		}
+		output, err := r.execRedisCLI(ctx, pod.Namespace, pod.Name, "cluster", "nodes")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to query cluster nodes from %s: %w", pod.Name, err)
+		}
+		nodes := redis.ParseClusterNodes(output)
+		if len(nodes) > 1 {
+			return pod.Name, nodes, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("no running Redis pod that is a cluster member is available")
+}
@@ -2,23 +2,33 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"slices"
 	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 )
 
@@ -26,6 +36,12 @@ import (
 type RedisClusterReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Config is the REST config used to exec redis-cli inside Redis pods.
+	Config *rest.Config
+	// Recorder emits Kubernetes Events for scaling operations.
+	Recorder record.EventRecorder
+	// ReconcileTimeout bounds a single reconcile so a hung call cannot hold a worker. 0 disables it.
+	ReconcileTimeout time.Duration
 }
 
 // +kubebuilder:rbac:groups=cache.example.com,resources=redisclusters,verbs=get;list;watch;create;update;patch;delete
@@ -36,15 +52,88 @@ type RedisClusterReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;delete
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
 
 // Reconcile is the main reconciliation loop for RedisCluster.
 // It ensures the desired state of the cluster by:
 //  1. Creating/updating ConfigMap, Service, StatefulSet, and ServiceMonitor
 //  2. Bootstrapping the Redis cluster when first created
 //  3. Running the autoscaler if enabled
+//
+// Each reconcile is bounded by ReconcileTimeout; when it fires, the reconcile is requeued and a
+// ReconcileTimedOut event is recorded. Status writes are not cut off by it (see Status).
 func (r *RedisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.ReconcileTimeout <= 0 {
+		return r.reconcile(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.ReconcileTimeout)
+	defer cancel()
+
+	result, err := r.reconcile(ctx, req)
+	if ctx.Err() == context.DeadlineExceeded {
+		if err == nil {
+			err = ctx.Err()
+		}
+		log.FromContext(ctx).Error(err, "Reconcile timed out, requeueing", "timeout", r.ReconcileTimeout)
+		graceCtx, graceCancel := context.WithTimeout(context.Background(), statusWriteGrace)
+		defer graceCancel()
+		cluster := &appv1.RedisCluster{}
+		if getErr := r.Get(graceCtx, req.NamespacedName, cluster); getErr == nil {
+			r.recordWarning(cluster, "ReconcileTimedOut", "Reconcile exceeded %s and was requeued: %v", r.ReconcileTimeout, err)
+		}
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+	return result, err
+}
+
+// statusWriteGrace bounds a status write once it is detached from the reconcile context.
+const statusWriteGrace = 10 * time.Second
+
+// Status returns a status writer whose writes are detached from the caller's cancellation and
+// bounded by statusWriteGrace instead, so a reconcile cut off by ReconcileTimeout still persists
+// the conditions and errors it was recording rather than failing them with the expired deadline.
+func (r *RedisClusterReconciler) Status() client.SubResourceWriter {
+	return graceStatusWriter{SubResourceWriter: r.Client.Status()}
+}
+
+// graceStatusWriter runs each status write under graceContext.
+type graceStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (w graceStatusWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	ctx, cancel := graceContext(ctx)
+	defer cancel()
+	return w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+func (w graceStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, cancel := graceContext(ctx)
+	defer cancel()
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w graceStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, cancel := graceContext(ctx)
+	defer cancel()
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// graceContext keeps the values of ctx (logger, trace) but not its deadline or cancellation.
+func graceContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), statusWriteGrace)
+}
+
+// reconcile performs a single reconciliation of a RedisCluster.
+func (r *RedisClusterReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	cluster := &appv1.RedisCluster{}
@@ -58,6 +147,19 @@ func (r *RedisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	cluster.SetDefaults()
+	ctx = withOperationLogger(ctx, cluster)
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeRedisCluster(ctx, cluster)
+	}
+	if !controllerutil.ContainsFinalizer(cluster, redisClusterFinalizer) {
+		if err := r.patchCluster(ctx, cluster, func() {
+			controllerutil.AddFinalizer(cluster, redisClusterFinalizer)
+		}); err != nil {
+			logger.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
 
 	if err := cluster.ValidateSpec(); err != nil {
 		logger.Error(err, "Invalid RedisCluster spec")
@@ -68,6 +170,25 @@ func (r *RedisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	if cluster.Spec.ManageStatefulSet && cluster.Spec.ClusterMemberReadinessGate {
+		if err := r.reconcileMembershipGates(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to reconcile cluster membership readiness gates")
+		}
+	}
+
+	if syncLifecycleConditions(cluster) {
+		if err := r.Status().Update(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to update lifecycle conditions")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Evaluated ahead of handleBootstrap, which holds the reconcile while a bootstrap is pending
+	// or has failed, so a failed bootstrap still raises NeedsAttention.
+	if err := r.evaluateNeedsAttention(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to evaluate NeedsAttention condition")
+	}
+
 	if result, done, err := r.handleBootstrap(ctx, cluster); done {
 		return result, err
 	}
@@ -85,6 +206,42 @@ func (r *RedisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	if cluster.Status.Initialized {
+		if err := r.observeCurrentMasters(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to observe the serving masters")
+		}
+	}
+
+	if cluster.Status.Initialized && cluster.Spec.AnnotatePodRoles {
+		if err := r.reconcilePodRoleAnnotations(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to reconcile pod role annotations")
+		}
+	}
+
+	if cluster.Status.Initialized && cluster.Spec.AutoForgetFailedNodes && !isScaling(cluster) {
+		if err := r.reconcileClusterMembership(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to forget failed cluster nodes")
+		}
+	}
+
+	if cluster.Status.Initialized && cluster.Spec.ManageStatefulSet && !isScaling(cluster) {
+		if err := r.verifyClusterMembership(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to verify cluster membership")
+		}
+	}
+
+	if cluster.Status.Initialized && !isScaling(cluster) && clusterHealthCheckDue(cluster) {
+		if err := r.runClusterHealthCheck(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to run cluster health check")
+		}
+	}
+
+	if cluster.Status.Initialized && cluster.Spec.ManageStatefulSet && cluster.Spec.ReclaimScaledDownPVCs {
+		if err := r.reclaimScaledDownPVCs(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to reclaim PVCs of scaled-down pods")
+		}
+	}
+
 	if cluster.Status.Initialized && cluster.Spec.AutoScaleEnabled {
 		return r.handleAutoScaling(ctx, cluster)
 	}
@@ -94,16 +251,35 @@ func (r *RedisClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{RequeueAfter: requeueInterval}, nil
 }
 
+// patchCluster persists the metadata and spec changes mutate makes to cluster as a merge patch.
+// The reconciled object carries the defaults SetDefaults filled in, which an Update would write
+// into the user's spec, turning an omitted field into a pinned value; the patch only sends what
+// mutate changed. Like an Update it fails on a conflicting write, and the object the API server
+// returns is defaulted again so the caller can keep using it.
+func (r *RedisClusterReconciler) patchCluster(ctx context.Context, cluster *appv1.RedisCluster, mutate func()) error {
+	patch := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	mutate()
+	if err := r.Patch(ctx, cluster, patch); err != nil {
+		return err
+	}
+	cluster.SetDefaults()
+	return nil
+}
+
 // reconcileInfrastructure creates or updates all infrastructure resources.
-// This includes ConfigMap, Service, StatefulSet (if managed), and ServiceMonitor.
+// This includes ConfigMap (if managed), Service, StatefulSet (if managed), and ServiceMonitor.
 // For existing clusters where ManageStatefulSet=false, only ConfigMap and ServiceMonitor are managed.
 func (r *RedisClusterReconciler) reconcileInfrastructure(ctx context.Context, cluster *appv1.RedisCluster) error {
 	logger := log.FromContext(ctx)
 
-	cm := r.configMapForRedisCluster(cluster)
-	if err := r.reconcileConfigMap(ctx, cluster, cm); err != nil {
-		logger.Error(err, "Failed to reconcile ConfigMap")
-		return err
+	if cluster.Spec.ManageConfig {
+		cm := r.configMapForRedisCluster(cluster)
+		if err := r.reconcileConfigMap(ctx, cluster, cm); err != nil {
+			logger.Error(err, "Failed to reconcile ConfigMap")
+			return err
+		}
+	} else {
+		logger.Info("Skipping ConfigMap management (ManageConfig=false)")
 	}
 
 	// Only manage Service and StatefulSet if ManageStatefulSet is true
@@ -165,6 +341,7 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 			return ctrl.Result{RequeueAfter: 10 * time.Second}, true, nil
 		}
 
+		syncLifecycleConditions(cluster)
 		if err := r.Status().Update(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to update status after discovering existing cluster")
 			return ctrl.Result{}, true, err
@@ -175,6 +352,17 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 	}
 
 	// For managed clusters, proceed with standard bootstrap
+	if !cluster.Status.Initialized {
+		stale, err := r.cancelStaleBootstrap(ctx, cluster)
+		if err != nil {
+			logger.Error(err, "Failed to check bootstrap job layout")
+			return ctrl.Result{}, true, err
+		}
+		if stale {
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, true, nil
+		}
+	}
+
 	stsName := cluster.Spec.StatefulSetName
 	if stsName == "" {
 		stsName = cluster.Name
@@ -186,10 +374,15 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 		return ctrl.Result{}, true, err
 	}
 
-	totalReplicas := (cluster.Spec.Masters + 1) * (1 + cluster.Spec.ReplicasPerMaster)
-	if sts.Status.ReadyReplicas != totalReplicas {
+	totalReplicas := desiredPodCount(cluster)
+	readyReplicas, err := r.joinablePodCount(ctx, cluster, sts)
+	if err != nil {
+		logger.Error(err, "Failed to count ready pods")
+		return ctrl.Result{}, true, err
+	}
+	if readyReplicas != totalReplicas {
 		logger.Info("Waiting for all StatefulSet replicas to be ready",
-			"ready", sts.Status.ReadyReplicas,
+			"ready", readyReplicas,
 			"desired", totalReplicas)
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, true, nil
 	}
@@ -198,12 +391,24 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 		return ctrl.Result{}, false, nil
 	}
 
+	if meta.IsStatusConditionTrue(cluster.Status.Conditions, appv1.ConditionBootstrapFailed) {
+		return r.resumeFailedBootstrap(ctx, cluster)
+	}
+
 	bootstrapJob := &batchv1.Job{}
 	jobName := cluster.Name + "-bootstrap"
-	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, bootstrapJob)
+	err = r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, bootstrapJob)
 
 	if err != nil && errors.IsNotFound(err) {
-		logger.Info("Creating cluster bootstrap job")
+		if cluster.Status.ActiveOperation == "" {
+			beginOperation(cluster)
+		} else {
+			// An earlier bootstrap job of this operation was cancelled or removed after failing,
+			// and may have clustered some pods; --cluster create needs them empty.
+			logger.Info("Resetting pods left by an earlier bootstrap attempt")
+			r.resetRedisPods(ctx, cluster)
+		}
+		logger.Info("Creating cluster bootstrap job", "operationID", cluster.Status.ActiveOperation)
 		job := r.bootstrapJobForRedisCluster(cluster)
 		if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
 			logger.Error(err, "Failed to set owner reference on bootstrap job")
@@ -213,6 +418,11 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 			logger.Error(err, "Failed to create bootstrap job")
 			return ctrl.Result{}, true, err
 		}
+		r.recordNormal(cluster, "BootstrapStarted", "Created bootstrap job %s", jobName)
+		if err := r.Status().Update(ctx, cluster); err != nil {
+			logger.Error(err, "Failed to record bootstrap operation ID")
+			return ctrl.Result{}, true, err
+		}
 		return ctrl.Result{Requeue: true}, true, nil
 	} else if err != nil {
 		logger.Error(err, "Failed to get bootstrap job")
@@ -220,7 +430,23 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 	}
 
 	if bootstrapJob.Status.Succeeded > 0 {
-		logger.Info("Bootstrap job succeeded, detecting standby node")
+		logger.Info("Bootstrap job succeeded, verifying standby replicas")
+		if err := r.verifyStandbyReplicas(ctx, cluster); err != nil {
+			logger.Info("Standby replicas not ready yet, will verify again", "reason", err.Error())
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, true, nil
+		}
+
+		reassigned, err := r.ensureCrossNodeReplicaPlacement(ctx, cluster)
+		if err != nil {
+			logger.Error(err, "Failed to check cross-node replica placement")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, true, nil
+		}
+		if reassigned {
+			logger.Info("Replicas reassigned for cross-node placement, re-checking")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, true, nil
+		}
+
+		logger.Info("Standby replicas attached, detecting standby node")
 		cluster.Status.Initialized = true
 
 		if err := r.detectAndSetStandbyPod(ctx, cluster); err != nil {
@@ -228,6 +454,10 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 			return ctrl.Result{}, true, err
 		}
 
+		r.recordNormal(cluster, "BootstrapSucceeded", "Cluster bootstrapped with standby %s", cluster.Status.StandbyPod)
+		endOperation(cluster)
+		syncLifecycleConditions(cluster)
+
 		if err := r.Status().Update(ctx, cluster); err != nil {
 			logger.Error(err, "Failed to update RedisCluster status")
 			return ctrl.Result{}, true, err
@@ -237,8 +467,13 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 		return ctrl.Result{}, true, nil
 	}
 
+	if bootstrapTimedOut(cluster, bootstrapJob) {
+		return ctrl.Result{}, true, r.failBootstrap(ctx, cluster, jobName)
+	}
+
 	if bootstrapJob.Status.Failed > 0 {
 		logger.Error(fmt.Errorf("bootstrap job %s failed", jobName), "Cluster initialization failed")
+		r.recordWarning(cluster, "BootstrapFailed", "Bootstrap job %s failed", jobName)
 		return ctrl.Result{}, true, fmt.Errorf("bootstrap job %s failed", jobName)
 	}
 
@@ -246,6 +481,9 @@ func (r *RedisClusterReconciler) handleBootstrap(ctx context.Context, cluster *a
 	return ctrl.Result{Requeue: true}, true, nil
 }
 
+// redisClusterSlots is the number of hash slots in a Redis cluster.
+const redisClusterSlots = redis.TotalSlots
+
 // discoverRedisTopology discovers the Redis cluster topology for existing clusters.
 // It queries the Redis cluster to find masters, replicas, and the standby node (master with 0 slots).
 // This is used when ExistingCluster=true to work with already deployed Redis clusters.
@@ -262,68 +500,74 @@ func (r *RedisClusterReconciler) discoverRedisTopology(ctx context.Context, clus
 		return fmt.Errorf("no pods found matching selector %v", cluster.Spec.PodSelector)
 	}
 
-	// Find a running pod to query cluster info
-	var queryPod *corev1.Pod
-	for i := range podList.Items {
-		pod := &podList.Items[i]
-		if pod.Status.Phase == corev1.PodRunning {
-			queryPod = pod
-			break
-		}
+	entryPod, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to query cluster topology: %w", err)
 	}
 
-	if queryPod == nil {
-		return fmt.Errorf("no running pods found to query cluster topology")
+	var masters, replicas, slots int32
+	for _, node := range nodes {
+		if node.IsFailed() {
+			continue
+		}
+		if node.IsMaster() {
+			if node.Slots > 0 {
+				masters++
+			}
+			slots += int32(node.Slots)
+		} else if node.IsReplica() {
+			replicas++
+		}
 	}
 
-	logger.Info("Discovering Redis topology from existing cluster",
-		"queryPod", queryPod.Name,
-		"totalPods", len(podList.Items))
+	logger.Info("Discovered Redis topology from existing cluster",
+		"queryPod", entryPod,
+		"totalPods", len(podList.Items),
+		"masters", masters,
+		"replicas", replicas,
+		"slotsAssigned", slots)
+
+	if masters != cluster.Spec.Masters {
+		r.recordWarning(cluster, "TopologyMismatch", "Observed %d masters serving slots, but spec.masters is %d", masters, cluster.Spec.Masters)
+	}
+	if slots != redisClusterSlots {
+		r.recordWarning(cluster, "IncompleteSlotCoverage", "Only %d of %d slots are assigned to a live master", slots, redisClusterSlots)
+	}
 
-	// Query Redis cluster nodes to find masters, replicas, and slot distribution
-	// For now, mark cluster as initialized since it already exists
 	cluster.Status.Initialized = true
-	cluster.Status.CurrentMasters = cluster.Spec.Masters
-	cluster.Status.CurrentReplicas = cluster.Spec.Masters * cluster.Spec.ReplicasPerMaster
+	cluster.Status.CurrentMasters = masters
+	cluster.Status.CurrentReplicas = replicas
+	cluster.Status.TotalSlotsAssigned = slots
 
-	// Try to detect standby pod (master with 0 slots)
-	// This will be done in the updated detectAndSetStandbyPod function
+	// The standby pod (master with 0 slots) is detected by detectAndSetStandbyPod after discovery
 	logger.Info("Existing cluster discovered", "masters", cluster.Status.CurrentMasters)
 
 	return nil
 }
 
-// detectAndSetStandbyPod finds the standby master node (the one with 0 hash slots).
-// For managed clusters, the standby is at index (Masters * (1 + ReplicasPerMaster)).
-// For existing clusters, it queries all pods to find which master has 0 slots.
-// It verifies the pod exists and is running before setting it in the cluster status.
+// detectAndSetStandbyPod finds the standby master nodes (the ones with 0 hash slots).
+// For managed clusters, the first standby is at index (Masters * (1 + ReplicasPerMaster)) and the
+// rest of the pool follows it. For existing clusters, it queries all pods to find which masters
+// have 0 slots. It verifies the first standby pod exists and is running before setting the pool
+// in the cluster status.
 func (r *RedisClusterReconciler) detectAndSetStandbyPod(ctx context.Context, cluster *appv1.RedisCluster) error {
 	logger := log.FromContext(ctx)
 
-	// For existing clusters, use PodSelector to find all pods
+	// For existing clusters, ask Redis which masters have 0 slots
 	if cluster.Spec.ExistingCluster {
-		podList := &corev1.PodList{}
-		if err := r.List(ctx, podList, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.Spec.PodSelector)); err != nil {
-			return fmt.Errorf("failed to list Redis pods: %w", err)
+		standbyPods, err := r.findZeroSlotMasterPods(ctx, cluster)
+		if err != nil {
+			return err
 		}
-
-		// For now, we'll need to query Redis to find which pod has 0 slots
-		// This requires executing redis-cli commands, which is complex
-		// As a temporary solution, check if there's already a StandbyPod set
-		if cluster.Status.StandbyPod != "" {
-			logger.Info("Using existing standby pod", "pod", cluster.Status.StandbyPod)
-			return nil
+		if !slices.Equal(standbyPods, cluster.Status.StandbyPods) {
+			logger.Info("Standby pods detected in existing cluster", "pods", standbyPods)
 		}
-
-		// If not set, we cannot easily determine it without redis-cli
-		// This will be enhanced in a future iteration
-		logger.Info("Cannot auto-detect standby pod for existing cluster, manual configuration may be needed")
-		return fmt.Errorf("standby pod detection not yet implemented for existing clusters")
+		setStandbyPool(cluster, standbyPods)
+		return nil
 	}
 
-	// For managed clusters, use index-based detection
-	standbyIndex := cluster.Spec.Masters * (1 + cluster.Spec.ReplicasPerMaster)
-	standbyPodName := fmt.Sprintf("%s-%d", cluster.Name, standbyIndex)
+	// For managed clusters, use index-based detection of the standby activated next
+	standbyPodName := fmt.Sprintf("%s-%d", cluster.Name, standbyMasterIndex(cluster, 0))
 
 	standbyPod := &corev1.Pod{}
 	if err := r.Get(ctx, client.ObjectKey{
@@ -354,26 +598,172 @@ func (r *RedisClusterReconciler) detectAndSetStandbyPod(ctx context.Context, clu
 
 	logger.Info("Standby pod detected", "pod", standbyPodName, "podIP", standbyPod.Status.PodIP)
 
-	cluster.Status.StandbyPod = standbyPodName
+	setStandbyPool(cluster, standbyPoolPods(cluster))
 	return nil
 }
 
 // reconcileService creates or updates the headless Service for the Redis cluster.
+// Every job resolves pods through this Service's DNS records, so a selector, port or
+// publishNotReadyAddresses edited out from under the operator is corrected and reported with an
+// event. A Service that is no longer headless cannot be fixed in place (clusterIP is immutable)
+// and is recreated.
 func (r *RedisClusterReconciler) reconcileService(ctx context.Context, cluster *appv1.RedisCluster, desired *corev1.Service) error {
+	logger := log.FromContext(ctx)
+
 	if err := controllerutil.SetControllerReference(cluster, desired, r.Scheme); err != nil {
 		return err
 	}
+
+	current := &corev1.Service{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if current.Spec.ClusterIP != corev1.ClusterIPNone {
+			logger.Info("Headless Service has a cluster IP, recreating it", "service", current.Name, "clusterIP", current.Spec.ClusterIP)
+			if err := r.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete non-headless Service %s: %w", current.Name, err)
+			}
+			r.recordWarning(cluster, "ServiceRecreated", "Service %s was not headless (clusterIP %s), recreated it", current.Name, current.Spec.ClusterIP)
+			return r.Create(ctx, desired)
+		}
+
+		if drift := serviceDrift(current, desired); drift != "" {
+			logger.Info("Correcting drift on headless Service", "service", current.Name, "drift", drift)
+			r.recordWarning(cluster, "ServiceDriftCorrected", "Service %s drifted (%s), restored desired selector, ports and publishNotReadyAddresses", current.Name, drift)
+		}
+	}
+
 	return r.reconcileResource(ctx, desired)
 }
 
+// serviceDrift describes how the selector, ports and publishNotReadyAddresses of current differ
+// from desired, or returns "" if they match. Defaulted port fields (protocol, targetPort) are
+// ignored.
+func serviceDrift(current, desired *corev1.Service) string {
+	var drift []string
+	if !equality.Semantic.DeepEqual(current.Spec.Selector, desired.Spec.Selector) {
+		drift = append(drift, fmt.Sprintf("selector %v, want %v", current.Spec.Selector, desired.Spec.Selector))
+	}
+
+	portsMatch := len(current.Spec.Ports) == len(desired.Spec.Ports)
+	for i := 0; portsMatch && i < len(desired.Spec.Ports); i++ {
+		portsMatch = current.Spec.Ports[i].Name == desired.Spec.Ports[i].Name &&
+			current.Spec.Ports[i].Port == desired.Spec.Ports[i].Port
+	}
+	if !portsMatch {
+		drift = append(drift, "ports")
+	}
+	if current.Spec.PublishNotReadyAddresses != desired.Spec.PublishNotReadyAddresses {
+		drift = append(drift, fmt.Sprintf("publishNotReadyAddresses %t, want %t",
+			current.Spec.PublishNotReadyAddresses, desired.Spec.PublishNotReadyAddresses))
+	}
+	return strings.Join(drift, ", ")
+}
+
 // reconcileStatefulSet creates or updates the StatefulSet for the Redis cluster.
 func (r *RedisClusterReconciler) reconcileStatefulSet(ctx context.Context, cluster *appv1.RedisCluster, desired *appsv1.StatefulSet) error {
 	if err := controllerutil.SetControllerReference(cluster, desired, r.Scheme); err != nil {
 		return err
 	}
+
+	// podManagementPolicy and volumeClaimTemplates are immutable; keep the existing values so
+	// updates don't get rejected.
+	current := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), current); err == nil {
+		if current.Spec.PodManagementPolicy != desired.Spec.PodManagementPolicy {
+			log.FromContext(ctx).Info("Ignoring podManagementPolicy change on existing StatefulSet",
+				"current", current.Spec.PodManagementPolicy,
+				"desired", desired.Spec.PodManagementPolicy)
+			desired.Spec.PodManagementPolicy = current.Spec.PodManagementPolicy
+		}
+		if change := storageChange(current, desired); change != "" {
+			log.FromContext(ctx).Info("Ignoring storage change on existing StatefulSet", "change", change)
+			r.recordWarning(cluster, "StorageChangeIgnored",
+				"StatefulSet %s keeps its data volume template (%s): volume claim templates cannot be changed in place; "+
+					"expand existing PVCs directly or recreate the StatefulSet", current.Name, change)
+		}
+		desired.Spec.VolumeClaimTemplates = current.Spec.VolumeClaimTemplates
+	}
+
 	return r.reconcileResource(ctx, desired)
 }
 
+// scaleStatefulSet brings the StatefulSet to the pod count of the current layout after a scaling
+// operation has changed Spec.Masters. A managed StatefulSet is reconciled in full; an externally
+// managed one (ManageStatefulSet=false) is not the operator's to rewrite, so only its replica
+// count is patched and its pod template is left untouched.
+func (r *RedisClusterReconciler) scaleStatefulSet(ctx context.Context, cluster *appv1.RedisCluster) error {
+	if cluster.Spec.ManageStatefulSet {
+		return r.reconcileStatefulSet(ctx, cluster, r.statefulSetForRedisCluster(cluster))
+	}
+
+	stsName := cluster.Spec.StatefulSetName
+	if stsName == "" {
+		stsName = cluster.Name
+	}
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: stsName, Namespace: cluster.Namespace}, sts); err != nil {
+		return fmt.Errorf("failed to get StatefulSet %s: %w", stsName, err)
+	}
+
+	replicas := desiredPodCount(cluster)
+	if sts.Spec.Replicas != nil && *sts.Spec.Replicas == replicas {
+		return nil
+	}
+	log.FromContext(ctx).Info("Scaling externally managed StatefulSet", "statefulSet", stsName, "replicas", replicas)
+	patch := client.MergeFrom(sts.DeepCopy())
+	sts.Spec.Replicas = &replicas
+	if err := r.Patch(ctx, sts, patch); err != nil {
+		return fmt.Errorf("failed to scale StatefulSet %s: %w", stsName, err)
+	}
+	return nil
+}
+
+// podAffinity returns the affinity of the Redis pods: the user's when set, otherwise a preferred
+// anti-affinity that spreads the cluster's pods across hostnames.
+func podAffinity(cluster *appv1.RedisCluster) *corev1.Affinity {
+	if cluster.Spec.Affinity != nil {
+		return cluster.Spec.Affinity
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: getLabels(cluster)},
+						TopologyKey:   corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
+// storageChange describes how the data volume template of desired differs from current in size
+// or StorageClass, or returns "" if it does not.
+func storageChange(current, desired *appsv1.StatefulSet) string {
+	if len(current.Spec.VolumeClaimTemplates) == 0 || len(desired.Spec.VolumeClaimTemplates) == 0 {
+		return ""
+	}
+	currentPVC := current.Spec.VolumeClaimTemplates[0].Spec
+	desiredPVC := desired.Spec.VolumeClaimTemplates[0].Spec
+
+	var changes []string
+	currentSize := currentPVC.Resources.Requests[corev1.ResourceStorage]
+	desiredSize := desiredPVC.Resources.Requests[corev1.ResourceStorage]
+	if currentSize.Cmp(desiredSize) != 0 {
+		changes = append(changes, fmt.Sprintf("size %s -> %s", currentSize.String(), desiredSize.String()))
+	}
+	if desiredPVC.StorageClassName != nil &&
+		(currentPVC.StorageClassName == nil || *currentPVC.StorageClassName != *desiredPVC.StorageClassName) {
+		changes = append(changes, fmt.Sprintf("storageClassName -> %s", *desiredPVC.StorageClassName))
+	}
+	return strings.Join(changes, ", ")
+}
+
 // reconcileServiceMonitor creates or updates the Prometheus ServiceMonitor for metrics collection.
 func (r *RedisClusterReconciler) reconcileServiceMonitor(ctx context.Context, cluster *appv1.RedisCluster, desired *monitoringv1.ServiceMonitor) error {
 	if err := controllerutil.SetControllerReference(cluster, desired, r.Scheme); err != nil {
@@ -383,10 +773,20 @@ func (r *RedisClusterReconciler) reconcileServiceMonitor(ctx context.Context, cl
 }
 
 // reconcileConfigMap creates or updates the ConfigMap containing redis.conf.
+// A changed configuration is reported with an event; the config checksum on the StatefulSet's
+// pod template then rolls the pods so they load it.
 func (r *RedisClusterReconciler) reconcileConfigMap(ctx context.Context, cluster *appv1.RedisCluster, desired *corev1.ConfigMap) error {
 	if err := controllerutil.SetControllerReference(cluster, desired, r.Scheme); err != nil {
 		return err
 	}
+
+	current := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), current); err == nil &&
+		current.Data["redis.conf"] != desired.Data["redis.conf"] {
+		log.FromContext(ctx).Info("Redis configuration changed, pods will be restarted to apply it", "configMap", desired.Name)
+		r.recordNormal(cluster, "ConfigChanged", "redis.conf in %s changed, rolling pods to apply it", desired.Name)
+	}
+
 	return r.reconcileResource(ctx, desired)
 }
 
@@ -408,130 +808,246 @@ func (r *RedisClusterReconciler) reconcileResource(ctx context.Context, obj clie
 	return r.Update(ctx, obj)
 }
 
+// yesNo renders a boolean redis.conf value.
+func yesNo(enabled bool) string {
+	if enabled {
+		return "yes"
+	}
+	return "no"
+}
+
 // configMapForRedisCluster builds the ConfigMap containing the Redis configuration file.
 func (r *RedisClusterReconciler) configMapForRedisCluster(cluster *appv1.RedisCluster) *corev1.ConfigMap {
 	labels := getLabels(cluster)
-	config := `port 6379
-cluster-enabled yes
-cluster-config-file /data/nodes.conf
-cluster-node-timeout 5000
-appendonly yes
-bind 0.0.0.0
-`
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name + "-config",
-			Namespace: cluster.Namespace,
-			Labels:    labels,
+			Name:        cluster.Name + "-config",
+			Namespace:   cluster.Namespace,
+			Labels:      withCommonLabels(cluster, labels),
+			Annotations: withCommonAnnotations(cluster, nil),
 		},
 		Data: map[string]string{
-			"redis.conf": config,
+			"redis.conf": redisConf(cluster),
 		},
 	}
 }
 
+// redisConf renders redis.conf: the operator's base configuration with the directives from
+// Spec.RedisConfig replacing base directives of the same name or appended in key order.
+// cluster-enabled and cluster-config-file are never overridden (ValidateSpec rejects them).
+func redisConf(cluster *appv1.RedisCluster) string {
+	var base []string
+	if tlsEnabled(cluster) {
+		// TLS only: the plain port is disabled and replication and the cluster bus use TLS too.
+		base = append(base,
+			"port 0",
+			fmt.Sprintf("tls-port %d", redisPort(cluster)),
+			fmt.Sprintf("tls-cert-file %s/tls.crt", tlsMountPath),
+			fmt.Sprintf("tls-key-file %s/tls.key", tlsMountPath),
+			fmt.Sprintf("tls-ca-cert-file %s/ca.crt", tlsMountPath),
+			"tls-replication yes",
+			"tls-cluster yes",
+		)
+	} else {
+		base = append(base, fmt.Sprintf("port %d", redisPort(cluster)))
+	}
+	base = append(base,
+		"cluster-enabled yes",
+		"cluster-config-file /data/nodes.conf",
+		fmt.Sprintf("cluster-node-timeout %d", cluster.Spec.ClusterNodeTimeoutMs),
+		fmt.Sprintf("appendonly %s", yesNo(cluster.Spec.AppendOnly)),
+	)
+	if cluster.Spec.AppendOnly {
+		base = append(base, fmt.Sprintf("appendfsync %s", strings.ToLower(string(cluster.Spec.AppendFsync))))
+	}
+	base = append(base, "bind 0.0.0.0")
+
+	overrides := make(map[string]string, len(cluster.Spec.RedisConfig))
+	for key, value := range cluster.Spec.RedisConfig {
+		if appv1.IsProtectedRedisConfigKey(key) {
+			continue
+		}
+		overrides[strings.ToLower(key)] = value
+	}
+
+	var b strings.Builder
+	for _, line := range base {
+		key, _, _ := strings.Cut(line, " ")
+		if value, ok := overrides[key]; ok {
+			line = key + " " + value
+			delete(overrides, key)
+		}
+		b.WriteString(line + "\n")
+	}
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b.WriteString(key + " " + overrides[key] + "\n")
+	}
+	return b.String()
+}
+
 // serviceForRedisCluster builds the headless Service for internal pod-to-pod communication.
+// It publishes pods that are not Ready, see PublishNotReadyAddresses below.
 func (r *RedisClusterReconciler) serviceForRedisCluster(cluster *appv1.RedisCluster) *corev1.Service {
 	labels := getLabels(cluster)
+	redisServicePort := corev1.ServicePort{Name: "redis", Port: redisPort(cluster)}
+	if tlsEnabled(cluster) {
+		redisServicePort.Name = "redis-tls"
+	}
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name + "-headless",
-			Namespace: cluster.Namespace,
-			Labels:    labels,
+			Name:        cluster.Name + "-headless",
+			Namespace:   cluster.Namespace,
+			Labels:      withCommonLabels(cluster, labels),
+			Annotations: withCommonAnnotations(cluster, nil),
 		},
 		Spec: corev1.ServiceSpec{
 			ClusterIP: "None",
-			Selector:  labels,
+			// With ClusterMemberReadinessGate a pod is not Ready until it has joined the cluster,
+			// and a replica drops out of Ready while it resyncs. Jobs must still resolve those pods,
+			// above all the jobs that join them.
+			PublishNotReadyAddresses: true,
+			Selector:                 labels,
 			Ports: []corev1.ServicePort{
-				{Name: "redis", Port: 6379},
+				redisServicePort,
 				{Name: "metrics", Port: 9121},
 			},
 		},
 	}
 }
 
+// configChecksumAnnotation on the pod template carries the checksum of redis.conf, so a
+// configuration change rolls the StatefulSet and actually takes effect.
+const configChecksumAnnotation = "cache.example.com/config-checksum"
+
+// configChecksum returns the SHA-256 of the rendered redis.conf.
+func configChecksum(cluster *appv1.RedisCluster) string {
+	sum := sha256.Sum256([]byte(redisConf(cluster)))
+	return hex.EncodeToString(sum[:])
+}
+
 // statefulSetForRedisCluster builds the StatefulSet for Redis pods.
 // The replica count includes the active masters plus one standby master, each with their replicas.
 func (r *RedisClusterReconciler) statefulSetForRedisCluster(cluster *appv1.RedisCluster) *appsv1.StatefulSet {
 	labels := getLabels(cluster)
-	replicas := (cluster.Spec.Masters + 1) * (1 + cluster.Spec.ReplicasPerMaster)
+	replicas := desiredPodCount(cluster)
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cluster.Name + "-config",
+					},
+				},
+			},
+		},
+	}
+	redisMounts := []corev1.VolumeMount{
+		{Name: "config", MountPath: "/conf"},
+		{Name: dataVolumeName, MountPath: "/data"},
+	}
+	exporterArgs := []string{fmt.Sprintf("--redis.addr=redis://localhost:%d", redisPort(cluster))}
+	var exporterMounts []corev1.VolumeMount
+	scrapeScheme := "http"
+	if tlsEnabled(cluster) {
+		tlsMount := corev1.VolumeMount{Name: tlsVolumeName, MountPath: tlsMountPath, ReadOnly: true}
+		volumes = append(volumes, tlsVolume(cluster))
+		redisMounts = append(redisMounts, tlsMount)
+		exporterMounts = append(exporterMounts, tlsMount)
+		// The certificate is issued for the pod's DNS names, not localhost.
+		exporterArgs = []string{
+			fmt.Sprintf("--redis.addr=rediss://localhost:%d", redisPort(cluster)),
+			"--tls-client-cert-file=" + tlsMountPath + "/tls.crt",
+			"--tls-client-key-file=" + tlsMountPath + "/tls.key",
+			"--tls-ca-cert-file=" + tlsMountPath + "/ca.crt",
+			"--skip-tls-verification",
+		}
+		if cluster.Spec.TLS.Metrics {
+			exporterArgs = append(exporterArgs,
+				"--tls-server-cert-file="+tlsMountPath+"/tls.crt",
+				"--tls-server-key-file="+tlsMountPath+"/tls.key")
+			scrapeScheme = "https"
+		}
+	}
 
 	return &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name,
-			Namespace: cluster.Namespace,
-			Labels:    labels,
+			Name:        cluster.Name,
+			Namespace:   cluster.Namespace,
+			Labels:      withCommonLabels(cluster, labels),
+			Annotations: withCommonAnnotations(cluster, nil),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas:    &replicas,
-			ServiceName: cluster.Name + "-headless",
+			Replicas:                             &replicas,
+			ServiceName:                          cluster.Name + "-headless",
+			PodManagementPolicy:                  cluster.Spec.PodManagementPolicy,
+			PersistentVolumeClaimRetentionPolicy: pvcRetentionPolicy(cluster),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-					Annotations: map[string]string{
+					Labels: withCommonLabels(cluster, labels),
+					Annotations: withCommonAnnotations(cluster, map[string]string{
 						"prometheus.io/scrape": "true",
 						"prometheus.io/port":   "9121",
 						"prometheus.io/path":   "/metrics",
-					},
+						"prometheus.io/scheme": scrapeScheme,
+						configChecksumAnnotation: configChecksum(cluster),
+					}),
 				},
 				Spec: corev1.PodSpec{
-					Volumes: []corev1.Volume{
-						{
-							Name: "config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: cluster.Name + "-config",
-									},
-								},
-							},
-						},
-					},
-					Containers: []corev1.Container{
+					PriorityClassName:         cluster.Spec.PodPriorityClassName,
+					Affinity:                  podAffinity(cluster),
+					Tolerations:               cluster.Spec.Tolerations,
+					NodeSelector:              cluster.Spec.NodeSelector,
+					TopologySpreadConstraints: cluster.Spec.TopologySpreadConstraints,
+					ImagePullSecrets:          cluster.Spec.ImagePullSecrets,
+					SecurityContext:           cluster.Spec.PodSecurityContext,
+					ReadinessGates:            clusterMemberReadinessGates(cluster),
+					Volumes:                   volumes,
+					Containers: append([]corev1.Container{
 						{
 							Name:    "redis",
-							Image:   fmt.Sprintf("redis:%s", cluster.Spec.RedisVersion),
+							Image:   redisImage(cluster),
 							Command: []string{"redis-server", "/conf/redis.conf"},
 							Ports: []corev1.ContainerPort{
-								{ContainerPort: 6379, Name: "redis"},
-							},
-							VolumeMounts: []corev1.VolumeMount{
-								{Name: "config", MountPath: "/conf"},
-								{Name: "data", MountPath: "/data"},
+								{ContainerPort: redisPort(cluster), Name: "redis"},
 							},
+							Env:             redisConnectionEnv(cluster),
+							VolumeMounts:    redisMounts,
+							Resources:       cluster.Spec.RedisResources,
+							SecurityContext: cluster.Spec.SecurityContext,
 						},
 						{
-							Name:  "redis-exporter",
-							Image: "bitnamilegacy/redis-exporter:1.59.0",
-							Args:  []string{"--redis.addr=redis://localhost:6379"},
+							Name:         "redis-exporter",
+							Image:        "bitnamilegacy/redis-exporter:1.59.0",
+							Args:         exporterArgs,
+							VolumeMounts: exporterMounts,
 							Ports: []corev1.ContainerPort{
 								{ContainerPort: 9121, Name: "metrics"},
 							},
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("100m"),
-									corev1.ResourceMemory: resource.MustParse("128Mi"),
-								},
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("200m"),
-									corev1.ResourceMemory: resource.MustParse("256Mi"),
-								},
-							},
+							Resources:       cluster.Spec.ExporterResources,
+							SecurityContext: cluster.Spec.SecurityContext,
 						},
-					},
+					}, cluster.Spec.ExtraContainers...),
 				},
 			},
 			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
 				{
-					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					ObjectMeta: metav1.ObjectMeta{Name: dataVolumeName},
 					Spec: corev1.PersistentVolumeClaimSpec{
-						AccessModes: []corev1.PersistentVolumeAccessMode{"ReadWriteOnce"},
+						AccessModes:      []corev1.PersistentVolumeAccessMode{"ReadWriteOnce"},
+						StorageClassName: cluster.Spec.Storage.StorageClassName,
 						Resources: corev1.VolumeResourceRequirements{
 							Requests: corev1.ResourceList{
-								corev1.ResourceStorage: resource.MustParse("1Gi"),
+								corev1.ResourceStorage: cluster.Spec.Storage.Size,
 							},
 						},
 					},
@@ -543,8 +1059,8 @@ func (r *RedisClusterReconciler) statefulSetForRedisCluster(cluster *appv1.Redis
 
 // bootstrapJobForRedisCluster creates initial cluster, joining the standby master with 0 slots
 // bootstrapJobForRedisCluster creates a Kubernetes Job that initializes the Redis cluster.
-// The job creates the initial cluster with active masters and replicas, then adds the standby
-// master with 0 hash slots and its replica.
+// The job creates the initial cluster with active masters and replicas, then adds each standby
+// master with 0 hash slots and its replicas.
 func (r *RedisClusterReconciler) bootstrapJobForRedisCluster(cluster *appv1.RedisCluster) *batchv1.Job {
 	serviceName := cluster.Name + "-headless"
 	namespace := cluster.Namespace
@@ -553,18 +1069,25 @@ func (r *RedisClusterReconciler) bootstrapJobForRedisCluster(cluster *appv1.Redi
 	replicasPerMaster := cluster.Spec.ReplicasPerMaster
 
 	activeClusterReplicas := activeMasters * (1 + replicasPerMaster)
-	standbyMasterIndex := activeClusterReplicas
-	standbyReplicaIndex := activeClusterReplicas + 1
+	port := redisPort(cluster)
 
 	var activeHosts []string
 	for i := int32(0); i < activeClusterReplicas; i++ {
-		activeHosts = append(activeHosts, fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local:6379", cluster.Name, i, serviceName, namespace))
+		activeHosts = append(activeHosts, fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local:%d", cluster.Name, i, serviceName, namespace, port))
 	}
 	activeHostString := strings.Join(activeHosts, " ")
 
-	// FQDN for the Standby Master node
-	standbyMasterFQDN := fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local:6379", cluster.Name, standbyMasterIndex, serviceName, namespace)
-	standbyReplicaFQDN := fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local:6379", cluster.Name, standbyReplicaIndex, serviceName, namespace)
+	// Each standby shard is passed as "master,replica,..." of FQDN:port entries
+	var standbyShards []string
+	for standby := int32(0); standby < cluster.Spec.StandbyCount; standby++ {
+		standbyIndex := standbyMasterIndex(cluster, standby)
+		shard := []string{fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local:%d", cluster.Name, standbyIndex, serviceName, namespace, port)}
+		for i := int32(1); i <= cluster.StandbyReplicaCount(); i++ {
+			shard = append(shard, fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local:%d", cluster.Name, standbyIndex+i, serviceName, namespace, port))
+		}
+		standbyShards = append(standbyShards, strings.Join(shard, ","))
+	}
+	standbyShardString := strings.Join(standbyShards, " ")
 
 	// --- Multi-step Shell Command ---
 	cliCmd := fmt.Sprintf(`
@@ -578,97 +1101,170 @@ redis-cli --cluster create %s --cluster-replicas %d --cluster-yes
 
 # Use the first active node as the entry point for subsequent commands
 ENTRYPOINT=%s
+ENTRYPOINT_HOST=$(echo "$ENTRYPOINT" | cut -d: -f1)
+
+# Wait for the cluster configuration to propagate
+wait_cluster_ok $ENTRYPOINT_HOST
 
-# Wait a moment for the cluster configuration to propagate
-sleep 5
+for STANDBY_SHARD in %s; do
+  STANDBY_MASTER=$(echo "$STANDBY_SHARD" | cut -d, -f1)
+  STANDBY_REPLICAS=$(echo "$STANDBY_SHARD" | cut -s -d, -f2- | tr ',' ' ')
 
-# 2. Add the Standby Master (highest index)
-# It joins as a master but since all slots are taken, it receives 0 slots.
-echo "Phase 2: Adding standby master %s with 0 slots"
-redis-cli --cluster add-node %s $ENTRYPOINT || true
-sleep 5
+  # 2. Add the Standby Master
+  # It joins as a master but since all slots are taken, it receives 0 slots.
+  echo "Phase 2: Adding standby master $STANDBY_MASTER with 0 slots"
+  redis-cli --cluster add-node $STANDBY_MASTER $ENTRYPOINT || true
 
-# Get the ID of the newly added standby master
-STANDBY_MASTER_IP=$(getent hosts $(echo "%s" | cut -d: -f1) | awk '{print $1}')
-STANDBY_MASTER_ID=$(redis-cli -h $(echo "$ENTRYPOINT" | cut -d: -f1) -p 6379 cluster nodes | grep "$STANDBY_MASTER_IP" | awk '{print $1}' | head -n 1)
+  # Get the ID of the newly added standby master once the entry point knows it
+  STANDBY_MASTER_IP=$(resolve_ip $(echo "$STANDBY_MASTER" | cut -d: -f1))
+  wait_node_known $ENTRYPOINT_HOST $STANDBY_MASTER_IP
+  STANDBY_MASTER_ID=$(node_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $STANDBY_MASTER_IP)
 
-if [ -z "$STANDBY_MASTER_ID" ]; then
-  echo "ERROR: Failed to determine Standby Master ID."
-  exit 1
-fi
+  if [ -z "$STANDBY_MASTER_ID" ]; then
+    echo "ERROR: Failed to determine Standby Master ID."
+    exit 1
+  fi
 
-echo "Standby Master ID: $STANDBY_MASTER_ID"
+  echo "Standby Master ID: $STANDBY_MASTER_ID"
 
-# 3. Add the Standby Replica and assign it to the Standby Master
-echo "Phase 3: Adding standby replica %s to master ID $STANDBY_MASTER_ID"
-redis-cli --cluster add-node %s $ENTRYPOINT \
-  --cluster-slave --cluster-master-id $STANDBY_MASTER_ID || true
-sleep 5
+  # 3. Add the Standby Replicas and assign them to the Standby Master
+  for STANDBY_REPLICA in $STANDBY_REPLICAS; do
+    echo "Phase 3: Adding standby replica $STANDBY_REPLICA to master ID $STANDBY_MASTER_ID"
+    redis-cli --cluster add-node $STANDBY_REPLICA $ENTRYPOINT \
+      --cluster-slave --cluster-master-id $STANDBY_MASTER_ID || true
+    wait_node_known $ENTRYPOINT_HOST $(resolve_ip $(echo "$STANDBY_REPLICA" | cut -d: -f1))
+  done
+done
 
-echo "Bootstrap complete. Standby master is joined with 0 slots."
+wait_cluster_ok $ENTRYPOINT_HOST
+
+echo "Bootstrap complete. Standby masters are joined with 0 slots."
 `,
 		activeMasters,
 		activeHostString,
 		replicasPerMaster,
 		activeHosts[0],
-		standbyMasterFQDN,
-		standbyMasterFQDN,
-		standbyMasterFQDN,
-		standbyReplicaFQDN,
-		standbyReplicaFQDN)
+		standbyShardString)
+
+	timeout := int64(cluster.Spec.BootstrapTimeoutSeconds)
 
 	// ... (rest of the Job definition remains the same)
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name + "-bootstrap",
-			Namespace: cluster.Namespace,
-			Labels:    getLabels(cluster),
+			Name:        cluster.Name + "-bootstrap",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: withCommonAnnotations(cluster, map[string]string{bootstrapLayoutAnnotation: bootstrapLayout(cluster)}),
 		},
 		Spec: batchv1.JobSpec{
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyOnFailure,
+					RestartPolicy:     corev1.RestartPolicyOnFailure,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Name:    "bootstrap",
-							Image:   fmt.Sprintf("redis:%s", cluster.Spec.RedisVersion),
+							Image:   redisImage(cluster),
 							Command: []string{"sh", "-c"},
-							Args:    []string{cliCmd},
+							Args:    []string{redis.Script(cliCmd, redis.PollScript, redis.ResolveIPScript, redis.NodeLookupScript)},
 						},
 					},
 				},
 			},
-			BackoffLimit: new(int32),
+			ActiveDeadlineSeconds: &timeout,
+			BackoffLimit:          new(int32),
 		},
 	}
+	applyJobPolling(cluster, &job.Spec.Template.Spec)
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Bootstrap)
+	return job
+}
+
+// maxScrapeInterval caps the scrape interval derived from MetricsQueryInterval so the [1m] rate
+// window of the CPU query always holds at least two samples.
+const maxScrapeInterval = 30
+
+// scrapeInterval returns the ServiceMonitor's scrape interval: ServiceMonitor.Interval when set,
+// otherwise MetricsQueryInterval capped at maxScrapeInterval seconds.
+func scrapeInterval(cluster *appv1.RedisCluster) monitoringv1.Duration {
+	if cluster.Spec.ServiceMonitor.Interval != "" {
+		return monitoringv1.Duration(cluster.Spec.ServiceMonitor.Interval)
+	}
+	seconds := cluster.Spec.MetricsQueryInterval
+	if seconds <= 0 || seconds > maxScrapeInterval {
+		seconds = maxScrapeInterval
+	}
+	return monitoringv1.Duration(fmt.Sprintf("%ds", seconds))
 }
 
 // serviceMonitorForRedisCluster builds the Prometheus ServiceMonitor for scraping Redis metrics.
+// ServiceMonitor.Labels are merged over the default labels.
 func (r *RedisClusterReconciler) serviceMonitorForRedisCluster(cluster *appv1.RedisCluster, svc *corev1.Service) *monitoringv1.ServiceMonitor {
+	endpoint := monitoringv1.Endpoint{
+		Port:          "metrics",
+		Interval:      scrapeInterval(cluster),
+		ScrapeTimeout: monitoringv1.Duration(cluster.Spec.ServiceMonitor.ScrapeTimeout),
+		HonorLabels:   cluster.Spec.ServiceMonitor.HonorLabels,
+		Path:          "/metrics",
+	}
+	labels := map[string]string{
+		"release": "prometheus",
+		"app":     "redis-cluster",
+	}
+	for key, value := range cluster.Spec.ServiceMonitor.Labels {
+		labels[key] = value
+	}
+	if tlsEnabled(cluster) && cluster.Spec.TLS.Metrics {
+		// Pods are scraped by IP, so verify against the headless Service name the
+		// certificate is expected to cover.
+		serverName := fmt.Sprintf("%s.%s.svc", svc.Name, cluster.Namespace)
+		endpoint.Scheme = "https"
+		endpoint.TLSConfig = &monitoringv1.TLSConfig{
+			SafeTLSConfig: monitoringv1.SafeTLSConfig{
+				ServerName: &serverName,
+				CA: monitoringv1.SecretOrConfigMap{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: cluster.Spec.TLS.CertSecretRef},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+		}
+	}
 	return &monitoringv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name,
-			Namespace: cluster.Namespace,
-			Labels: map[string]string{
-				"release": "prometheus",
-				"app":     "redis-cluster",
-			},
+			Name:        cluster.Name,
+			Namespace:   cluster.Namespace,
+			Labels:      withCommonLabels(cluster, labels),
+			Annotations: withCommonAnnotations(cluster, nil),
 		},
 		Spec: monitoringv1.ServiceMonitorSpec{
 			Selector: metav1.LabelSelector{
 				MatchLabels: svc.Labels,
 			},
-			Endpoints: []monitoringv1.Endpoint{
-				{
-					Port:     "metrics",
-					Interval: "15s",
-					Path:     "/metrics",
-				},
-			},
+			Endpoints: []monitoringv1.Endpoint{endpoint},
 		},
 	}
 }
 
+// desiredPodCount returns the number of Redis pods in a managed cluster: each active master with
+// ReplicasPerMaster replicas, followed by StandbyCount standby shards. Every standby shard but the
+// last spans a full shard width; the last holds only the standby master and its
+// StandbyReplicaCount replicas. The first standby sits at index Masters*(1+ReplicasPerMaster), so
+// activating it only appends pods at the end and the index layout of active masters never changes.
+func desiredPodCount(cluster *appv1.RedisCluster) int32 {
+	return lastStandbyIndex(cluster) + 1 + cluster.StandbyReplicaCount()
+}
+
+// redisImage returns the image of the Redis pods, which every Job of the operator runs too, so
+// redis-cli always matches the server version.
+func redisImage(cluster *appv1.RedisCluster) string {
+	return fmt.Sprintf("redis:%s", cluster.Spec.RedisVersion)
+}
+
 // getLabels returns the label selector for finding Redis pods.
 // For existing clusters, it uses the user-provided PodSelector.
 // For managed clusters, it uses the default labels.
@@ -685,6 +1281,7 @@ func getLabels(cluster *appv1.RedisCluster) map[string]string {
 }
 
 // SetupWithManager configures the controller with the Manager and sets up watches.
+// Redis pods are watched too, so a change of pod state re-runs standby detection promptly.
 func (r *RedisClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appv1.RedisCluster{}).
@@ -693,6 +1290,9 @@ func (r *RedisClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ConfigMap{}).
 		Owns(&batchv1.Job{}).
 		Owns(&monitoringv1.ServiceMonitor{}).
+		Watches(&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.podToRedisClusters),
+			builder.WithPredicates(podStateChanged)).
 		Named("rediscluster").
 		Complete(r)
 }
@@ -18,16 +18,31 @@ package controller
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cachev1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
 )
 
 var _ = Describe("RedisCluster Controller", func() {
@@ -81,4 +96,1079 @@ var _ = Describe("RedisCluster Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When persisting changes to the RedisCluster", func() {
+		const resourceName = "patch-defaults"
+
+		ctx := context.Background()
+		key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       cachev1.RedisClusterSpec{Masters: 3, MinMasters: 3, ReplicasPerMaster: 1, CpuThreshold: 80},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			cluster := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+			cluster.Finalizers = nil
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+		})
+
+		It("should not write the in-memory defaults into the spec", func() {
+			controllerReconciler := &RedisClusterReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			cluster := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+			cluster.SetDefaults()
+			Expect(cluster.Spec.PodSecurityContext).NotTo(BeNil())
+
+			Expect(controllerReconciler.patchCluster(ctx, cluster, func() {
+				cluster.Finalizers = append(cluster.Finalizers, redisClusterFinalizer)
+				cluster.Spec.Masters = 4
+			})).To(Succeed())
+			By("keeping the returned object defaulted")
+			Expect(cluster.Spec.PodSecurityContext).NotTo(BeNil())
+
+			stored := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, stored)).To(Succeed())
+			Expect(stored.Finalizers).To(ContainElement(redisClusterFinalizer))
+			Expect(stored.Spec.Masters).To(Equal(int32(4)))
+			Expect(stored.Spec.PodSecurityContext).To(BeNil())
+		})
+
+		It("should keep an explicit manageConfig: false across updates", func() {
+			cluster := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+			Expect(cluster.Spec.ManageConfig).To(BeTrue(), "the CRD defaults an omitted manageConfig")
+
+			cluster.Spec.ManageConfig = false
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+
+			By("updating an unrelated field")
+			Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+			cluster.Labels = map[string]string{"touched": "true"}
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+
+			stored := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, stored)).To(Succeed())
+			Expect(stored.Spec.ManageConfig).To(BeFalse())
+		})
+	})
+
+	Context("When a bootstrap has failed", func() {
+		const resourceName = "failed-bootstrap"
+
+		ctx := context.Background()
+		key := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		AfterEach(func() {
+			cluster := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+			cluster.Finalizers = nil
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+		})
+
+		It("should report the cluster Degraded and raise NeedsAttention", func() {
+			By("providing the StatefulSet the bootstrap waits on")
+			replicas := int32(8)
+			podLabels := map[string]string{"app": resourceName}
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:    &replicas,
+					ServiceName: resourceName + "-headless",
+					Selector:    &metav1.LabelSelector{MatchLabels: podLabels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "redis", Image: "redis:7.2"}}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sts)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, sts)).To(Succeed())
+			})
+
+			// The StatefulSet is left unmanaged so the reconcile does not need the ServiceMonitor CRD.
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: cachev1.RedisClusterSpec{
+					ManageStatefulSet:             false,
+					Masters:                       3,
+					MinMasters:                    3,
+					ReplicasPerMaster:             1,
+					CpuThreshold:                  80,
+					DegradedAlertThresholdSeconds: 60,
+				},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+			By("recording a bootstrap that timed out two hours ago")
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:               cachev1.ConditionBootstrapFailed,
+				Status:             metav1.ConditionTrue,
+				Reason:             "BootstrapTimedOut",
+				Message:            "Bootstrap did not finish",
+				ObservedGeneration: cluster.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			})
+			Expect(k8sClient.Status().Update(ctx, cluster)).To(Succeed())
+
+			controllerReconciler := &RedisClusterReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+
+			stored := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, key, stored)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(stored.Status.Conditions, cachev1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(stored.Status.Conditions, cachev1.ConditionNeedsAttention)).To(BeTrue())
+		})
+	})
+
+	Context("When scaling an externally managed StatefulSet", func() {
+		const resourceName = "external-sts"
+
+		ctx := context.Background()
+
+		stsKey := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		podLabels := map[string]string{"app": resourceName}
+
+		BeforeEach(func() {
+			By("creating a StatefulSet the operator does not own")
+			replicas := int32(3)
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: stsKey.Name, Namespace: stsKey.Namespace},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:    &replicas,
+					ServiceName: resourceName + "-headless",
+					Selector:    &metav1.LabelSelector{MatchLabels: podLabels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "redis",
+								Image: "registry.example.com/redis:custom",
+								Resources: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("3Gi")},
+								},
+							}},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sts)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			sts := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, stsKey, sts)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, sts)).To(Succeed())
+		})
+
+		It("should only change the replica count", func() {
+			controllerReconciler := &RedisClusterReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: cachev1.RedisClusterSpec{
+					ExistingCluster:   true,
+					ManageStatefulSet: false,
+					StatefulSetName:   resourceName,
+					Masters:           3,
+					ReplicasPerMaster: 0,
+					StandbyCount:      1,
+					RedisVersion:      "7.2",
+				},
+			}
+
+			By("scaling the cluster up by a master")
+			Expect(controllerReconciler.scaleStatefulSet(ctx, cluster)).To(Succeed())
+
+			sts := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, stsKey, sts)).To(Succeed())
+			Expect(*sts.Spec.Replicas).To(Equal(desiredPodCount(cluster)))
+			Expect(sts.OwnerReferences).To(BeEmpty())
+			container := sts.Spec.Template.Spec.Containers[0]
+			Expect(container.Image).To(Equal("registry.example.com/redis:custom"))
+			Expect(container.Resources.Limits.Memory().String()).To(Equal("3Gi"))
+		})
+	})
+
+	Context("When autoscaling is paused during a drain", func() {
+		const resourceName = "paused-drain"
+
+		ctx := context.Background()
+
+		clusterKey := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating a cluster that is draining a master")
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: cachev1.RedisClusterSpec{
+					Masters:          4,
+					MinMasters:       3,
+					AutoScaleEnabled: true,
+					Paused:           true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+			cluster.Status.Initialized = true
+			cluster.Status.IsDraining = true
+			cluster.Status.PodToDrain = resourceName + "-6"
+			cluster.Status.DrainDestPod1 = resourceName + "-0"
+			cluster.Status.StandbyPod = resourceName + "-8"
+			Expect(k8sClient.Status().Update(ctx, cluster)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			job := &batchv1.Job{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-drain", Namespace: "default"}, job); err == nil {
+				Expect(k8sClient.Delete(ctx, job)).To(Succeed())
+			}
+			cluster := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, clusterKey, cluster)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+		})
+
+		It("should keep driving the drain while reporting the pause", func() {
+			controllerReconciler := &RedisClusterReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			cluster := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, clusterKey, cluster)).To(Succeed())
+			cluster.SetDefaults()
+
+			_, err := controllerReconciler.handleAutoScaling(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("creating the drain job despite the pause")
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-drain", Namespace: "default"}, job)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, clusterKey, cluster)).To(Succeed())
+			Expect(cluster.Status.IsDraining).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, cachev1.ConditionPaused)).To(BeTrue())
+		})
+	})
+
+	Context("When watching a restricted set of namespaces", func() {
+		const resourceName = "scoped"
+
+		var mgrCancel context.CancelFunc
+
+		BeforeEach(func() {
+			for _, namespace := range []string{"watched", "unwatched"} {
+				ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+				if err := k8sClient.Create(ctx, ns); err != nil && !errors.IsAlreadyExists(err) {
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(k8sClient.Create(ctx, &cachev1.RedisCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			if mgrCancel != nil {
+				mgrCancel()
+			}
+			for _, namespace := range []string{"watched", "unwatched"} {
+				cluster := &cachev1.RedisCluster{}
+				key := types.NamespacedName{Name: resourceName, Namespace: namespace}
+				Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+				cluster.Finalizers = nil
+				Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+			}
+		})
+
+		It("should ignore RedisClusters outside WATCH_NAMESPACE", func() {
+			By("starting a manager whose cache is restricted to the watched namespace")
+			namespaces := ParseWatchNamespaces(" watched,,watched ")
+			Expect(namespaces).To(Equal([]string{"watched"}))
+			mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:  scheme.Scheme,
+				Metrics: metricsserver.Options{BindAddress: "0"},
+				Cache:   WatchNamespaceCacheOptions(namespaces),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			var mgrCtx context.Context
+			mgrCtx, mgrCancel = context.WithCancel(ctx)
+			go func() {
+				defer GinkgoRecover()
+				Expect(mgr.Start(mgrCtx)).To(Succeed())
+			}()
+			Expect(mgr.GetCache().WaitForCacheSync(mgrCtx)).To(BeTrue())
+
+			By("listing only the watched RedisCluster")
+			Eventually(func(g Gomega) {
+				list := &cachev1.RedisClusterList{}
+				g.Expect(mgr.GetClient().List(ctx, list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(1))
+				g.Expect(list.Items[0].Namespace).To(Equal("watched"))
+			}).Should(Succeed())
+
+			By("reconciling both clusters through the manager's client")
+			controllerReconciler := &RedisClusterReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: resourceName, Namespace: "unwatched"},
+			})
+			Expect(err).To(HaveOccurred())
+			_, _ = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: resourceName, Namespace: "watched"},
+			})
+
+			By("leaving the unwatched cluster untouched")
+			unwatched := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: "unwatched"}, unwatched)).To(Succeed())
+			Expect(unwatched.Finalizers).To(BeEmpty())
+			watched := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: "watched"}, watched)).To(Succeed())
+			Expect(watched.Finalizers).To(ContainElement(redisClusterFinalizer))
+		})
+	})
+
+	Context("When ranking scale-down destinations", func() {
+		names := func(loads []PodLoad) []string {
+			var result []string
+			for _, load := range loads {
+				result = append(result, load.PodName)
+			}
+			return result
+		}
+
+		It("should prefer masters underweight in both memory and slots", func() {
+			loads := []PodLoad{
+				{PodName: "rank-0", MemoryUsage: 20},
+				{PodName: "rank-2", MemoryUsage: 25},
+				{PodName: "rank-4", MemoryUsage: 40},
+			}
+			// rank-0 has the least memory but owns half the slots (50 points), so it ranks last.
+			slots := map[string]int{"rank-0": 8192, "rank-2": 4096, "rank-4": 4096}
+			Expect(names(rankDestinations(loads, slots))).To(Equal([]string{"rank-2", "rank-4", "rank-0"}))
+		})
+
+		It("should rank by memory alone when slot ownership is unknown", func() {
+			loads := []PodLoad{
+				{PodName: "rank-4", MemoryUsage: 30},
+				{PodName: "rank-0", MemoryUsage: 10},
+				{PodName: "rank-2", MemoryUsage: 20},
+			}
+			Expect(names(rankDestinations(loads, nil))).To(Equal([]string{"rank-0", "rank-2", "rank-4"}))
+		})
+
+		It("should break score ties by slots, then memory, then pod name", func() {
+			// 4096 slots are 25 points: every pod scores 50.
+			loads := []PodLoad{
+				{PodName: "rank-6", MemoryUsage: 50},
+				{PodName: "rank-4", MemoryUsage: 25},
+				{PodName: "rank-2", MemoryUsage: 50},
+				{PodName: "rank-0", MemoryUsage: 25},
+			}
+			slots := map[string]int{"rank-0": 4096, "rank-4": 4096}
+			Expect(names(rankDestinations(loads, slots))).To(Equal([]string{"rank-2", "rank-6", "rank-0", "rank-4"}))
+
+			By("leaving the input order untouched")
+			Expect(names(loads)).To(Equal([]string{"rank-6", "rank-4", "rank-2", "rank-0"}))
+		})
+	})
+
+	Context("When checking scale-down memory headroom", func() {
+		// headroom-N with one replica per master: masters are the even ordinals, and the
+		// zero-slot standby sits at ordinal 2*masters.
+		check := func(masters, minFree int32, plan scaleDownPlan, usage map[string]float64) error {
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "headroom", Namespace: "default"},
+				Spec: cachev1.RedisClusterSpec{
+					Masters:                            masters,
+					ReplicasPerMaster:                  1,
+					MinFreeMemoryPercentAfterScaleDown: minFree,
+				},
+			}
+			var loads []PodLoad
+			for pod, memory := range usage {
+				loads = append(loads, PodLoad{PodName: pod, MemoryUsage: memory})
+			}
+			return checkScaleDownHeadroom(cluster, plan, loads)
+		}
+		split := scaleDownPlan{PodToDrain: "headroom-4", DestPod1: "headroom-0", DestPod2: "headroom-2"}
+		simple := func(drain string) scaleDownPlan { return scaleDownPlan{PodToDrain: drain, SimpleRemove: true} }
+
+		DescribeTable("projecting the usage of every master receiving slots",
+			func(masters, minFree int32, plan scaleDownPlan, usage map[string]float64, wantErr string) {
+				err := check(masters, minFree, plan, usage)
+				if wantErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(wantErr)))
+				}
+			},
+			Entry("allows headroom just over the threshold", int32(3), int32(30), split,
+				map[string]float64{"headroom-0": 49.9, "headroom-2": 30, "headroom-4": 40}, ""),
+			Entry("allows headroom exactly at the threshold", int32(3), int32(30), split,
+				map[string]float64{"headroom-0": 50, "headroom-2": 30, "headroom-4": 40}, ""),
+			Entry("refuses headroom just under the threshold", int32(3), int32(30), split,
+				map[string]float64{"headroom-0": 50.1, "headroom-2": 30, "headroom-4": 40},
+				"leave headroom-0 with 29.9% memory free"),
+			Entry("skips the check when no minimum is set", int32(3), int32(0), split,
+				map[string]float64{"headroom-0": 95, "headroom-2": 95, "headroom-4": 95}, ""),
+			Entry("moves all usage onto a single remaining master", int32(2), int32(30), simple("headroom-2"),
+				map[string]float64{"headroom-0": 40, "headroom-2": 30}, ""),
+			Entry("refuses when the single remaining master would run short", int32(2), int32(30), simple("headroom-2"),
+				map[string]float64{"headroom-0": 40, "headroom-2": 31},
+				"leave headroom-0 with 29.0% memory free"),
+			Entry("refuses when no master would remain", int32(1), int32(30), simple("headroom-0"),
+				map[string]float64{"headroom-0": 10}, "no master left"),
+			// Counting the standby would spread 40% over three masters and leave 36.7% free.
+			Entry("excludes the zero-slot standby from the divisor", int32(3), int32(31), simple("headroom-4"),
+				map[string]float64{"headroom-0": 50, "headroom-2": 50, "headroom-4": 40, "headroom-6": 0},
+				"with 30.0% memory free"),
+		)
+	})
+
+	Context("When limiting concurrent scaling in a namespace", func() {
+		const namespace = "scaling-limit"
+
+		newCluster := func(name string, limit int32, scaling bool) cachev1.RedisCluster {
+			return cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       cachev1.RedisClusterSpec{MaxConcurrentScalingInNamespace: limit},
+				Status:     cachev1.RedisClusterStatus{IsResharding: scaling},
+			}
+		}
+		deleting := func(cluster cachev1.RedisCluster) cachev1.RedisCluster {
+			now := metav1.Now()
+			cluster.DeletionTimestamp = &now
+			cluster.Finalizers = []string{redisClusterFinalizer}
+			return cluster
+		}
+
+		AfterEach(func() {
+			scalingSlots.Lock()
+			delete(scalingSlots.taken, namespace)
+			scalingSlots.Unlock()
+		})
+
+		It("should apply the strictest limit set in the namespace", func() {
+			self := newCluster("self", 3, false)
+			Expect(namespaceScalingLimit(&self, []cachev1.RedisCluster{self})).To(Equal(3))
+			Expect(namespaceScalingLimit(&self, []cachev1.RedisCluster{self, newCluster("a", 0, false), newCluster("b", 2, false)})).To(Equal(2))
+
+			unlimited := newCluster("self", 0, false)
+			Expect(namespaceScalingLimit(&unlimited, []cachev1.RedisCluster{unlimited, newCluster("a", 0, false)})).To(BeZero())
+		})
+
+		It("should ignore the limit of a cluster being deleted", func() {
+			self := newCluster("self", 0, false)
+			Expect(namespaceScalingLimit(&self, []cachev1.RedisCluster{self, deleting(newCluster("a", 1, false))})).To(BeZero())
+		})
+
+		It("should take a slot only while the limit is not reached", func() {
+			self := newCluster("self", 2, false)
+			clusters := []cachev1.RedisCluster{self, newCluster("a", 0, true), newCluster("b", 0, false)}
+			Expect(takeScalingSlot(&self, clusters, 2)).To(ConsistOf("a"))
+			Expect(scalingSlots.taken[namespace]).To(HaveKey("self"))
+
+			By("counting the fresh reservation against the next cluster")
+			other := newCluster("b", 0, false)
+			Expect(takeScalingSlot(&other, clusters, 2)).To(ConsistOf("a", "self"))
+			Expect(scalingSlots.taken[namespace]).NotTo(HaveKey("b"))
+		})
+
+		It("should count the cluster under reconcile once, and not against itself", func() {
+			self := newCluster("self", 1, true)
+			// The listed copy of the cluster still shows the operation it is finishing.
+			Expect(takeScalingSlot(&self, []cachev1.RedisCluster{self}, 1)).To(BeEmpty())
+			Expect(takeScalingSlot(&self, []cachev1.RedisCluster{self}, 1)).To(BeEmpty())
+			Expect(scalingSlots.taken[namespace]).To(HaveLen(1))
+		})
+
+		It("should not count a cluster being deleted as scaling", func() {
+			gone := deleting(newCluster("gone", 0, true))
+			other := newCluster("gone", 0, false)
+			Expect(takeScalingSlot(&other, []cachev1.RedisCluster{other}, 1)).To(BeEmpty())
+
+			self := newCluster("self", 1, false)
+			Expect(takeScalingSlot(&self, []cachev1.RedisCluster{self, gone}, 1)).To(BeEmpty())
+			Expect(scalingSlots.taken[namespace]).To(HaveKey("self"))
+			Expect(scalingSlots.taken[namespace]).NotTo(HaveKey("gone"))
+		})
+	})
+
+	Context("When rendering redis.conf", func() {
+		newCluster := func(config map[string]string) *cachev1.RedisCluster {
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "conf", Namespace: "default"},
+				Spec:       cachev1.RedisClusterSpec{Masters: 3, AppendOnly: true, RedisConfig: config},
+			}
+			cluster.SetDefaults()
+			return cluster
+		}
+
+		It("should render the defaults", func() {
+			Expect(redisConf(newCluster(nil))).To(Equal("port 6379\n" +
+				"cluster-enabled yes\n" +
+				"cluster-config-file /data/nodes.conf\n" +
+				"cluster-node-timeout 5000\n" +
+				"appendonly yes\n" +
+				"appendfsync everysec\n" +
+				"bind 0.0.0.0\n"))
+
+			By("dropping appendfsync when AOF is disabled")
+			cluster := newCluster(nil)
+			cluster.Spec.AppendOnly = false
+			conf := redisConf(cluster)
+			Expect(conf).To(ContainSubstring("appendonly no\n"))
+			Expect(conf).NotTo(ContainSubstring("appendfsync"))
+		})
+
+		It("should append custom keys after the defaults in key order", func() {
+			conf := redisConf(newCluster(map[string]string{
+				"maxmemory-policy": "allkeys-lru",
+				"maxmemory":        "1gb",
+			}))
+			Expect(conf).To(HaveSuffix("bind 0.0.0.0\nmaxmemory 1gb\nmaxmemory-policy allkeys-lru\n"))
+		})
+
+		It("should let user overrides win over the defaults in place", func() {
+			conf := redisConf(newCluster(map[string]string{
+				"cluster-node-timeout": "15000",
+				"APPENDFSYNC":          "always",
+			}))
+			Expect(conf).To(Equal("port 6379\n" +
+				"cluster-enabled yes\n" +
+				"cluster-config-file /data/nodes.conf\n" +
+				"cluster-node-timeout 15000\n" +
+				"appendonly yes\n" +
+				"appendfsync always\n" +
+				"bind 0.0.0.0\n"))
+		})
+
+		It("should never override the cluster directives", func() {
+			conf := redisConf(newCluster(map[string]string{
+				"cluster-enabled":     "no",
+				"cluster-config-file": "/tmp/nodes.conf",
+			}))
+			Expect(conf).To(ContainSubstring("cluster-enabled yes\ncluster-config-file /data/nodes.conf\n"))
+			Expect(strings.Count(conf, "cluster-enabled")).To(Equal(1))
+			Expect(strings.Count(conf, "cluster-config-file")).To(Equal(1))
+		})
+	})
+
+	Context("When detecting drift on the headless Service", func() {
+		var desired *corev1.Service
+
+		BeforeEach(func() {
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "drift", Namespace: "default"},
+				Spec:       cachev1.RedisClusterSpec{Masters: 3, ReplicasPerMaster: 1},
+			}
+			cluster.SetDefaults()
+			desired = (&RedisClusterReconciler{}).serviceForRedisCluster(cluster)
+		})
+
+		It("should report no drift when selector and ports match", func() {
+			current := desired.DeepCopy()
+			for i := range current.Spec.Ports {
+				current.Spec.Ports[i].Protocol = corev1.ProtocolTCP
+				current.Spec.Ports[i].TargetPort = intstr.FromInt32(current.Spec.Ports[i].Port)
+			}
+			Expect(serviceDrift(current, desired)).To(BeEmpty())
+		})
+
+		It("should not count user-added annotations and labels as drift", func() {
+			current := desired.DeepCopy()
+			current.Annotations = map[string]string{"external-dns.alpha.kubernetes.io/hostname": "redis.example.com"}
+			current.Labels["team"] = "cache"
+			Expect(serviceDrift(current, desired)).To(BeEmpty())
+		})
+
+		It("should report a changed port number or name", func() {
+			current := desired.DeepCopy()
+			current.Spec.Ports[0].Port = 6380
+			Expect(serviceDrift(current, desired)).To(Equal("ports"))
+
+			current = desired.DeepCopy()
+			current.Spec.Ports[1].Name = "exporter"
+			Expect(serviceDrift(current, desired)).To(Equal("ports"))
+
+			current = desired.DeepCopy()
+			current.Spec.Ports = current.Spec.Ports[:1]
+			Expect(serviceDrift(current, desired)).To(Equal("ports"))
+		})
+
+		It("should report a changed selector", func() {
+			current := desired.DeepCopy()
+			current.Spec.Selector = map[string]string{"app": "other"}
+			Expect(serviceDrift(current, desired)).To(HavePrefix("selector map[app:other], want "))
+		})
+
+		It("should report every drifted field together", func() {
+			current := desired.DeepCopy()
+			current.Spec.Selector = nil
+			current.Spec.Ports[0].Port = 6380
+			current.Spec.PublishNotReadyAddresses = false
+			drift := serviceDrift(current, desired)
+			Expect(drift).To(HavePrefix("selector map[], want "))
+			Expect(drift).To(HaveSuffix(", ports, publishNotReadyAddresses false, want true"))
+		})
+	})
+
+	Context("When looking for split-brain slot conflicts", func() {
+		// readViews parses testdata/split-brain/<scenario>/<pod>.txt, the CLUSTER NODES output
+		// of each pod, keyed by pod name.
+		readViews := func(scenario string) map[string][]redis.ClusterNode {
+			dir := filepath.Join("testdata", "split-brain", scenario)
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			views := make(map[string][]redis.ClusterNode)
+			for _, entry := range entries {
+				raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+				Expect(err).NotTo(HaveOccurred())
+				views[strings.TrimSuffix(entry.Name(), ".txt")] = redis.ParseClusterNodes(string(raw))
+			}
+			return views
+		}
+		podNames := func(claims []slotClaim) []string {
+			var names []string
+			for _, claim := range claims {
+				names = append(names, claim.podName)
+			}
+			return names
+		}
+
+		It("should report masters whose own slot ranges overlap", func() {
+			claims := slotClaims(readViews("overlap"))
+			Expect(podNames(claims)).To(Equal([]string{"redis-0", "redis-1", "redis-2"}))
+
+			conflicts := findSlotConflicts(claims)
+			Expect(conflicts).To(HaveLen(1))
+			// redis-0 claims 0-5460 and redis-1 claims 0-5000.
+			Expect(conflicts[0].slots).To(Equal(5001))
+			Expect(podNames(conflicts[0].claims)).To(Equal([]string{"redis-0", "redis-1"}))
+			Expect(findEpochClashes(claims)).To(BeEmpty())
+		})
+
+		It("should not count importing or migrating slots as claims", func() {
+			claims := slotClaims(readViews("migrating"))
+			// redis-6 only imports slot 2730 and owns no slots yet.
+			Expect(podNames(claims)).To(Equal([]string{"redis-0", "redis-2"}))
+			Expect(claims[0].node.SlotRanges).To(Equal([]redis.SlotRange{{Start: 0, End: 5460}}))
+			Expect(findSlotConflicts(claims)).To(BeEmpty())
+		})
+
+		It("should ignore the stale claims of a failed master", func() {
+			claims := slotClaims(readViews("failed"))
+			// redis-0 still claims 0-5460, but the others flag it fail after failing it over to redis-1.
+			Expect(podNames(claims)).To(Equal([]string{"redis-1", "redis-2"}))
+			Expect(findSlotConflicts(claims)).To(BeEmpty())
+			Expect(findEpochClashes(claims)).To(BeEmpty())
+
+			By("still counting it once no other pod flags it fail")
+			views := readViews("failed")
+			delete(views, "redis-1")
+			delete(views, "redis-2")
+			views["redis-1"] = readViews("overlap")["redis-1"]
+			conflicts := findSlotConflicts(slotClaims(views))
+			Expect(conflicts).To(HaveLen(1))
+			Expect(podNames(conflicts[0].claims)).To(Equal([]string{"redis-0", "redis-1"}))
+		})
+	})
+
+	Context("When two clusters have similar names", func() {
+		foo := &cachev1.RedisCluster{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+		fooBar := &cachev1.RedisCluster{ObjectMeta: metav1.ObjectMeta{Name: "foo-bar", Namespace: "default"}}
+		usage := map[string]float64{"foo-0": 10, "foo-3": 30, "foo-bar-0": 90, "foo-bar-3": 95, "foo-x": 99}
+
+		It("should anchor the default queries to the cluster's own pods", func() {
+			for _, tmpl := range []string{defaultCPUQueryTemplate, defaultMemoryQueryTemplate} {
+				query, err := renderMetricsQuery(foo, "query", "", tmpl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(query).To(ContainSubstring(`pod=~"^foo-[0-9]+$"`))
+				Expect(query).NotTo(ContainSubstring(`pod=~"^foo-.*"`))
+			}
+		})
+
+		It("should only parse ordinals of names that are exactly <cluster>-<integer>", func() {
+			redisCluster := &cachev1.RedisCluster{ObjectMeta: metav1.ObjectMeta{Name: "redis"}}
+			for _, podName := range []string{"redisb-3", "redis-b-3", "redis-3x", "redis-", "redis--3", "redis-+3", "redis"} {
+				_, ok := podOrdinal(redisCluster, podName)
+				Expect(ok).To(BeFalse(), podName)
+			}
+			ordinal, ok := podOrdinal(redisCluster, "redis-12")
+			Expect(ok).To(BeTrue())
+			Expect(ordinal).To(Equal(12))
+
+			ordinal, ok = podOrdinal(fooBar, "foo-bar-3")
+			Expect(ok).To(BeTrue())
+			Expect(ordinal).To(Equal(3))
+		})
+
+		It("should fold only the cluster's own pods into shards", func() {
+			sharded := foo.DeepCopy()
+			sharded.Spec.ReplicasPerMaster = 2
+			Expect(aggregateByShard(sharded, usage)).To(Equal(map[string]float64{"foo-0": 10, "foo-3": 30}))
+		})
+
+		It("should keep each cluster's pod loads apart", func() {
+			r := &RedisClusterReconciler{}
+			loadsOf := func(cluster *cachev1.RedisCluster) map[string]float64 {
+				loads, err := r.buildPodLoads(ctx, cluster, usage, usage, nil)
+				Expect(err).NotTo(HaveOccurred())
+				result := make(map[string]float64)
+				for _, load := range loads {
+					result[load.PodName] = load.CPUUsage
+				}
+				return result
+			}
+
+			Expect(loadsOf(foo)).To(Equal(map[string]float64{"foo-0": 10, "foo-3": 30}))
+			Expect(loadsOf(fooBar)).To(Equal(map[string]float64{"foo-bar-0": 90, "foo-bar-3": 95}))
+		})
+	})
+
+	Context("When rendering Job scripts", func() {
+		var cluster *cachev1.RedisCluster
+		var controllerReconciler *RedisClusterReconciler
+
+		// helperFunctions maps each shared helper function to the snippet defining it.
+		helperFunctions := map[string]string{
+			"resolve_ip":      redis.ResolveIPScript,
+			"node_id":         redis.NodeLookupScript,
+			"master_id":       redis.NodeLookupScript,
+			"node_ips":        redis.NodeLookupScript,
+			"slot_count":      redis.SlotCountScript,
+			"wait_cluster_ok": redis.PollScript,
+			"wait_node_known": redis.PollScript,
+			"count_slots":     redis.MigrateScript,
+			"migrate_slots":   redis.MigrateScript,
+		}
+
+		BeforeEach(func() {
+			cluster = &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "default"},
+				Spec: cachev1.RedisClusterSpec{
+					Masters:               3,
+					ReplicasPerMaster:     1,
+					StandbyCount:          1,
+					Port:                  7000,
+					JobPollTimeoutSeconds: 45,
+				},
+			}
+			cluster.SetDefaults()
+			cluster.Status.StandbyPod = "scripts-6"
+			cluster.Status.RollbackOperation = "reshard"
+			cluster.Status.RollbackSourcePod = "scripts-0"
+			controllerReconciler = &RedisClusterReconciler{}
+		})
+
+		renderedJobs := func() map[string]*batchv1.Job {
+			return map[string]*batchv1.Job{
+				"bootstrap":       controllerReconciler.bootstrapJobForRedisCluster(cluster),
+				"reshard":         controllerReconciler.reshardJobForRedisCluster(cluster, reshardTargeted, "scripts-0", "scripts-6"),
+				"rebalance":       controllerReconciler.reshardJobForRedisCluster(cluster, reshardRebalance, "", ""),
+				"drain":           controllerReconciler.drainJobForRedisCluster(cluster, "scripts-4", "scripts-0", "scripts-2"),
+				"cleanup-standby": controllerReconciler.cleanupStandbyJobForRedisCluster(cluster, "scripts-6", "scripts-4"),
+				"simple-remove":   controllerReconciler.simpleRemoveJobForRedisCluster(cluster, "scripts-4"),
+				"join-nodes":      controllerReconciler.joinNodesJobForRedisCluster(cluster),
+				"add-shard":       controllerReconciler.addShardJobForRedisCluster(cluster, "scripts-6"),
+				"rollback":        controllerReconciler.rollbackJobForRedisCluster(cluster),
+			}
+		}
+
+		envValue := func(container corev1.Container, name string) (string, bool) {
+			for _, env := range container.Env {
+				if env.Name == name {
+					return env.Value, true
+				}
+			}
+			return "", false
+		}
+
+		It("should define every shared helper a Job script calls", func() {
+			for name, job := range renderedJobs() {
+				By("rendering the " + name + " job")
+				script := job.Spec.Template.Spec.Containers[0].Args[0]
+				for fn, snippet := range helperFunctions {
+					if !strings.Contains(script, fn+" ") {
+						continue
+					}
+					Expect(script).To(ContainSubstring(snippet), "%s calls %s", name, fn)
+				}
+				Expect(script).NotTo(ContainSubstring("getent hosts $"), "%s resolves pods inline", name)
+				Expect(script).NotTo(ContainSubstring("for(i=9"), "%s counts slots inline", name)
+			}
+		})
+
+		It("should parameterize the helpers through the Job environment", func() {
+			for name, job := range renderedJobs() {
+				By("checking the environment of the " + name + " job")
+				container := job.Spec.Template.Spec.Containers[0]
+				port, ok := envValue(container, "REDIS_PORT")
+				Expect(ok).To(BeTrue(), "%s has no REDIS_PORT", name)
+				Expect(port).To(Equal("7000"))
+
+				timeout, ok := envValue(container, "POLL_TIMEOUT_SECONDS")
+				if strings.Contains(container.Args[0], redis.PollScript) {
+					Expect(ok).To(BeTrue(), "%s polls without POLL_TIMEOUT_SECONDS", name)
+					Expect(timeout).To(Equal("45"))
+				}
+			}
+		})
+
+		It("should read node IDs and slot counts from CLUSTER NODES", func() {
+			nodes := strings.Join([]string{
+				"aaa 10.0.0.11:7000@17000 myself,master - 0 0 1 connected 0-99 200 [300->-bbb]",
+				"bbb 10.0.0.1:7000@17000 slave aaa 0 0 1 connected",
+				"ccc 10.0.0.2:7000@17000 master - 0 0 2 connected",
+			}, "\n")
+			script := redis.Script(`
+echo "$(node_id "$NODES" 10.0.0.1) $(master_id "$NODES" 10.0.0.1) $(master_id "$NODES" 10.0.0.11)"
+echo "$(slot_count "$NODES" aaa) $(slot_count "$NODES" ccc) $(slot_count "$NODES" zzz)"
+echo $(node_ips "$NODES")
+`, redis.NodeLookupScript, redis.SlotCountScript)
+
+			cmd := exec.Command("sh", "-c", script)
+			cmd.Env = append(os.Environ(), "REDIS_PORT=7000", "NODES="+nodes)
+			output, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+			Expect(strings.Split(strings.TrimSpace(string(output)), "\n")).To(Equal([]string{
+				"bbb  aaa",
+				"101 0 0",
+				"10.0.0.1 10.0.0.11 10.0.0.2",
+			}))
+		})
+
+		It("should build valid Jobs in both reshard modes", func() {
+			backoff := int32(2)
+			cluster.Spec.JobConfig.Reshard.BackoffLimit = &backoff
+			cluster.Spec.ReshardTimeoutSeconds = 900
+
+			tests := []struct {
+				mode      reshardMode
+				jobName   string
+				container string
+				script    string
+				env       map[string]string
+			}{
+				{
+					mode:      reshardTargeted,
+					jobName:   "scripts-reshard",
+					container: "smart-reshard",
+					script:    reshardScript,
+					env:       map[string]string{"OVERLOADED_POD": "scripts-0", "STANDBY_POD": "scripts-6", "POLL_TIMEOUT_SECONDS": "45"},
+				},
+				{
+					mode:      reshardRebalance,
+					jobName:   "scripts-rebalance",
+					container: "rebalance",
+					script:    rebalanceScript,
+					env:       map[string]string{"ENTRYPOINT_WITH_PORT": "scripts-0.scripts-headless.default.svc.cluster.local:7000"},
+				},
+			}
+			for _, tt := range tests {
+				By("building a " + string(tt.mode) + " job")
+				job := controllerReconciler.reshardJobForRedisCluster(cluster, tt.mode, "scripts-0", "scripts-6")
+				Expect(job.Name).To(Equal(tt.jobName))
+				Expect(job.Namespace).To(Equal("default"))
+				Expect(job.Labels).To(HaveKeyWithValue("cluster", "scripts"))
+				Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(900)))
+				Expect(*job.Spec.BackoffLimit).To(Equal(int32(2)))
+
+				podSpec := job.Spec.Template.Spec
+				Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+				Expect(podSpec.Containers).To(HaveLen(1))
+				container := podSpec.Containers[0]
+				Expect(container.Name).To(Equal(tt.container))
+				Expect(container.Image).To(Equal("redis:" + cluster.Spec.RedisVersion))
+				Expect(container.Command).To(Equal([]string{"sh", "-c"}))
+				Expect(container.Args).To(HaveLen(1))
+				Expect(container.Args[0]).To(ContainSubstring(tt.script))
+				Expect(container.Resources).To(Equal(cluster.Spec.JobResources))
+				for name, want := range tt.env {
+					value, ok := envValue(container, name)
+					Expect(ok).To(BeTrue(), "%s job has no %s", tt.mode, name)
+					Expect(value).To(Equal(want), "%s job %s", tt.mode, name)
+				}
+				_, ok := envValue(container, "REDIS_CLI_ARGS")
+				Expect(ok).To(BeTrue(), "%s job has no connection settings", tt.mode)
+			}
+		})
+
+		It("should return a failed drain's slots through the rotated master", func() {
+			cluster.Status.PodToDrain = "scripts-4"
+			cluster.Status.DrainDestPod1 = "scripts-0"
+			cluster.Status.DrainRotatePod = "scripts-2"
+			cluster.Status.DrainSlotBaseline = map[string]int32{"scripts-0": 5461, "scripts-2": 5461, "scripts-4": 5462, "scripts-6": 0}
+			Expect(drainDestinations(cluster)).To(Equal([]string{"scripts-0"}))
+			cluster.Status.RollbackOperation = rollbackOperationDrain
+			cluster.Status.RollbackSourcePod = "scripts-4"
+			cluster.Status.RollbackDestPods = drainDestinations(cluster)
+			cluster.Status.RollbackRotatePod = "scripts-2"
+
+			job := controllerReconciler.rollbackJobForRedisCluster(cluster)
+			moves, _ := envValue(job.Spec.Template.Spec.Containers[0], "RETURN_MOVES")
+			Expect(moves).To(Equal("scripts-0:scripts-2:5461 scripts-2:scripts-4:5461"))
+
+			By("spreading the return over every master when no destinations were planned")
+			cluster.Status.RollbackDestPods = drainDestinations(&cachev1.RedisCluster{Status: cachev1.RedisClusterStatus{
+				PodToDrain: "scripts-4", DrainSlotBaseline: cluster.Status.DrainSlotBaseline}})
+			cluster.Status.RollbackRotatePod = ""
+			Expect(drainReturnMoves(cluster)).To(Equal([]string{
+				"scripts-0:scripts-4:5461", "scripts-2:scripts-4:5461", "scripts-6:scripts-4:0"}))
+		})
+
+		It("should remove only the pods the StatefulSet sheds in SimpleRemove mode", func() {
+			cluster.Spec.ScaleDownStrategy = cachev1.ScaleDownSimpleRemove
+
+			By("building the drain job")
+			job := controllerReconciler.simpleRemoveJobForRedisCluster(cluster, "scripts-4")
+			Expect(job.Name).To(Equal("scripts-drain"))
+			Expect(isSimpleRemoveJob(job)).To(BeTrue())
+			Expect(isSimpleRemoveJob(controllerReconciler.drainJobForRedisCluster(cluster, "scripts-4", "scripts-0", "scripts-2"))).To(BeFalse())
+			container := job.Spec.Template.Spec.Containers[0]
+			Expect(container.Args[0]).To(ContainSubstring(simpleRemoveScript))
+			drained, _ := envValue(container, "POD_TO_DRAIN")
+			Expect(drained).To(Equal("scripts-4"))
+
+			By("keeping the drained shard and removing the last standby, replicas first")
+			removed, _ := envValue(container, "REMOVE_PODS")
+			Expect(removed).To(Equal("scripts-7 scripts-6"))
+
+			By("also removing the drained master's replicas beyond the standby replica count")
+			standbyReplicas := int32(0)
+			cluster.Spec.ReplicasPerMaster = 2
+			cluster.Spec.StandbyReplicasPerMaster = &standbyReplicas
+			Expect(simpleRemovePods(cluster)).To(Equal([]string{"scripts-8", "scripts-7", "scripts-9"}))
+		})
+
+		It("should resolve pods to an address of the configured IP family", func() {
+			By("stubbing getent with a dual-stack answer listing IPv6 first")
+			bin := GinkgoT().TempDir()
+			getent := "#!/bin/sh\n" +
+				"[ \"$1\" = ahosts ] || exit 2\n" +
+				"case \"$2\" in\n" +
+				"dual) printf 'fd00::5 STREAM dual\\nfd00::5 DGRAM\\n10.0.0.5 STREAM\\n' ;;\n" +
+				"v6) printf 'fd00::6 STREAM v6\\n' ;;\n" +
+				"*) exit 2 ;;\n" +
+				"esac\n"
+			Expect(os.WriteFile(filepath.Join(bin, "getent"), []byte(getent), 0o755)).To(Succeed())
+
+			resolve := func(family string) []string {
+				script := redis.Script(`echo "$(resolve_ip dual) $(resolve_ip v6) [$(resolve_ip missing)]"`, redis.ResolveIPScript)
+				cmd := exec.Command("sh", "-c", script)
+				cmd.Env = append(os.Environ(), "PATH="+bin+":"+os.Getenv("PATH"), "IP_FAMILY="+family)
+				output, err := cmd.CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				return strings.Fields(string(output))
+			}
+
+			By("preferring IPv4 and falling back to the only address otherwise")
+			Expect(resolve("IPv4")).To(Equal([]string{"10.0.0.5", "fd00::6", "[]"}))
+			By("preferring IPv6")
+			Expect(resolve("IPv6")).To(Equal([]string{"fd00::5", "fd00::6", "[]"}))
+
+			By("passing the family to every Job")
+			cluster.Spec.IPFamily = cachev1.IPFamilyIPv6
+			for name, job := range renderedJobs() {
+				family, ok := envValue(job.Spec.Template.Spec.Containers[0], "IP_FAMILY")
+				Expect(ok).To(BeTrue(), "%s has no IP_FAMILY", name)
+				Expect(family).To(Equal("IPv6"))
+			}
+		})
+	})
+
+	Context("When gating pod readiness on cluster membership", func() {
+		// checkMembership runs clusterMemberCheckScript against a stubbed redis-cli that answers
+		// CLUSTER INFO and INFO REPLICATION with the given fields.
+		checkMembership := func(clusterInfo, replication string) string {
+			bin := GinkgoT().TempDir()
+			redisCLI := "#!/bin/sh\n" +
+				"case \"$*\" in\n" +
+				"ping) echo PONG ;;\n" +
+				"'cluster info') printf \"$CLUSTER_INFO\" ;;\n" +
+				"'info replication') printf \"$REPLICATION\" ;;\n" +
+				"*) exit 2 ;;\n" +
+				"esac\n"
+			Expect(os.WriteFile(filepath.Join(bin, "redis-cli"), []byte(redisCLI), 0o755)).To(Succeed())
+
+			cmd := exec.Command("sh", "-c", clusterMemberCheckScript)
+			cmd.Env = append(os.Environ(), "PATH="+bin+":"+os.Getenv("PATH"),
+				"CLUSTER_INFO="+clusterInfo, "REPLICATION="+replication)
+			output, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+			return strings.TrimSpace(string(output))
+		}
+
+		It("should judge a pod by its own membership only", func() {
+			By("keeping a node that has not joined any cluster out of Ready")
+			Expect(checkMembership(`cluster_state:fail\ncluster_slots_assigned:0\ncluster_known_nodes:1\n`, `role:master\n`)).
+				To(HavePrefix("not-ready:"))
+
+			By("passing a member of a degraded cluster")
+			Expect(checkMembership(`cluster_state:fail\ncluster_slots_assigned:16000\ncluster_known_nodes:6\n`, `role:master\n`)).
+				To(HavePrefix("ready:"))
+
+			By("holding back a replica that is still syncing")
+			Expect(checkMembership(`cluster_state:ok\ncluster_slots_assigned:16384\ncluster_known_nodes:6\n`,
+				`role:slave\nmaster_link_status:up\nmaster_sync_in_progress:1\n`)).To(HavePrefix("not-ready:"))
+		})
+
+		It("should only add the gate when enabled", func() {
+			cluster := &cachev1.RedisCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "gated", Namespace: "default"},
+				Spec:       cachev1.RedisClusterSpec{Masters: 3, ReplicasPerMaster: 1},
+			}
+			cluster.SetDefaults()
+			reconciler := &RedisClusterReconciler{}
+			Expect(reconciler.statefulSetForRedisCluster(cluster).Spec.Template.Spec.ReadinessGates).To(BeEmpty())
+
+			cluster.Spec.ClusterMemberReadinessGate = true
+			Expect(reconciler.statefulSetForRedisCluster(cluster).Spec.Template.Spec.ReadinessGates).To(ConsistOf(
+				corev1.PodReadinessGate{ConditionType: clusterMemberConditionType}))
+
+			By("treating a running pod held back only by the gate as joinable")
+			pod := &corev1.Pod{Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Ready: true}},
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+					{Type: clusterMemberConditionType, Status: corev1.ConditionFalse},
+				},
+			}}
+			Expect(podJoinable(cluster, pod)).To(BeTrue())
+			Expect(clusterMemberGatePassed(pod)).To(BeFalse())
+		})
+	})
+
+	Context("When a reconcile times out", func() {
+		It("should still give status writes time to complete", func() {
+			expired, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+			defer cancel()
+			<-expired.Done()
+
+			graceCtx, graceCancel := graceContext(expired)
+			defer graceCancel()
+			Expect(graceCtx.Err()).NotTo(HaveOccurred())
+			deadline, ok := graceCtx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(time.Until(deadline)).To(BeNumerically(">", statusWriteGrace-time.Second))
+		})
+	})
 })
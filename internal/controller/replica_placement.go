@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// ensureCrossNodeReplicaPlacement checks that no replica runs on the same Kubernetes node as its
+// master after bootstrap. redis-cli --cluster create only spreads replicas across IP addresses,
+// and every pod has its own IP, so it may pair a master with a replica on the same node. Each
+// co-located replica is swapped with a replica of another serving master where both resulting
+// pairs span two nodes, via CLUSTER REPLICATE, which keeps the number of replicas per master.
+// Standby shards are checked but never swapped, since their replicas are tracked by ordinal.
+// One swap is made per call, and it reports whether a replica was reassigned; the caller should
+// re-check once gossip has settled.
+// Co-located pairs that cannot be fixed with the available nodes are reported in a Warning event.
+func (r *RedisClusterReconciler) ensureCrossNodeReplicaPlacement(ctx context.Context, cluster *appv1.RedisCluster) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	_, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return false, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podByIP := make(map[string]*corev1.Pod)
+	for i := range podList.Items {
+		if ip := podList.Items[i].Status.PodIP; ip != "" {
+			podByIP[ip] = &podList.Items[i]
+		}
+	}
+	nodeOf := func(n *redis.ClusterNode) string {
+		if pod, ok := podByIP[n.IP]; ok {
+			return pod.Spec.NodeName
+		}
+		return ""
+	}
+
+	masters := make(map[string]*redis.ClusterNode)
+	replicasOf := make(map[string][]*redis.ClusterNode)
+	for i := range nodes {
+		node := &nodes[i]
+		if node.IsFailed() {
+			continue
+		}
+		if node.IsMaster() {
+			masters[node.ID] = node
+		} else if node.MasterID != "" {
+			replicasOf[node.MasterID] = append(replicasOf[node.MasterID], node)
+		}
+	}
+
+	// findSwap returns a replica of another serving master that can take replica's place under
+	// master while replica moves to its master, with every resulting pair spanning two nodes.
+	findSwap := func(master, replica *redis.ClusterNode) *redis.ClusterNode {
+		for otherID, others := range replicasOf {
+			other := masters[otherID]
+			if otherID == master.ID || other == nil || other.Slots == 0 || nodeOf(other) == nodeOf(replica) {
+				continue
+			}
+			for _, candidate := range others {
+				if nodeOf(candidate) != "" && nodeOf(candidate) != nodeOf(master) {
+					return candidate
+				}
+			}
+		}
+		return nil
+	}
+
+	var unplaceable []string
+	for masterID, master := range masters {
+		masterNode := nodeOf(master)
+		if masterNode == "" {
+			continue
+		}
+		for _, replica := range replicasOf[masterID] {
+			if nodeOf(replica) != masterNode {
+				continue
+			}
+			pair := fmt.Sprintf("%s/%s on %s", podByIP[master.IP].Name, podByIP[replica.IP].Name, masterNode)
+			if master.Slots == 0 {
+				unplaceable = append(unplaceable, pair)
+				continue
+			}
+			swap := findSwap(master, replica)
+			if swap == nil {
+				unplaceable = append(unplaceable, pair)
+				continue
+			}
+
+			swapMasterID := swap.MasterID
+			replicaPod, swapPod := podByIP[replica.IP].Name, podByIP[swap.IP].Name
+			logger.Info("Swapping replicas to separate master and replica nodes",
+				"master", podByIP[master.IP].Name, "replica", replicaPod, "swapWith", swapPod, "node", masterNode)
+			if _, err := r.execRedisCLI(ctx, cluster.Namespace, replicaPod, "cluster", "replicate", swapMasterID); err != nil {
+				return false, fmt.Errorf("CLUSTER REPLICATE on %s failed: %w", replicaPod, err)
+			}
+			if _, err := r.execRedisCLI(ctx, cluster.Namespace, swapPod, "cluster", "replicate", masterID); err != nil {
+				return true, fmt.Errorf("CLUSTER REPLICATE on %s failed: %w", swapPod, err)
+			}
+			r.recordNormal(cluster, "ReplicaReassigned", "Swapped replicas %s and %s so no master shares a node with its replica",
+				replicaPod, swapPod)
+			return true, nil
+		}
+	}
+
+	if len(unplaceable) > 0 {
+		r.recordWarning(cluster, "ReplicaColocated",
+			"Cannot place every replica on a different node than its master with the available nodes: %s",
+			strings.Join(unplaceable, ", "))
+	}
+	return false, nil
+}
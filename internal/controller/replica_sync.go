@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// maxReplicaOffsetLag is the replication offset gap, in bytes, under which a replica is
+// considered caught up with its master.
+const maxReplicaOffsetLag = 1 << 20
+
+// checkReplicaSync holds the Ready condition False while the master activated by the last
+// scale-up still has replicas that are offline or far behind on the migrated data. Once every
+// expected replica is online and within maxReplicaOffsetLag, the pending pod is cleared and
+// Ready is set True.
+func (r *RedisClusterReconciler) checkReplicaSync(ctx context.Context, cluster *appv1.RedisCluster) error {
+	podName := cluster.Status.ReplicaSyncPendingPod
+	if podName == "" {
+		return r.setReadyCondition(ctx, cluster, metav1.ConditionTrue, "ReplicasInSync", "All shards have replicas in sync")
+	}
+
+	infoOutput, err := r.execRedisCLI(ctx, cluster.Namespace, podName, "info", "replication")
+	if err != nil {
+		return fmt.Errorf("failed to query replication info on %s: %w", podName, err)
+	}
+	info := redis.ParseInfo(infoOutput)
+	masterOffset, _ := strconv.ParseInt(info["master_repl_offset"], 10, 64)
+
+	synced := 0
+	var maxLag int64
+	for _, replica := range redis.ParseReplicas(info) {
+		lag := masterOffset - replica.Offset
+		if lag > maxLag {
+			maxLag = lag
+		}
+		if replica.State == "online" && lag <= maxReplicaOffsetLag {
+			synced++
+		}
+	}
+
+	expected := int(cluster.Spec.ReplicasPerMaster)
+	if synced < expected {
+		log.FromContext(ctx).Info("Waiting for replicas of newly activated master to catch up",
+			"master", podName,
+			"synced", synced,
+			"expected", expected,
+			"maxOffsetLag", maxLag)
+		return r.setReadyCondition(ctx, cluster, metav1.ConditionFalse, "ReplicaSyncPending",
+			fmt.Sprintf("%d/%d replicas of %s in sync (max offset lag %d bytes)", synced, expected, podName, maxLag))
+	}
+
+	log.FromContext(ctx).Info("Replicas of newly activated master caught up", "master", podName)
+	r.recordNormal(cluster, "ReplicasInSync", "Replicas of %s caught up with migrated data", podName)
+	cluster.Status.ReplicaSyncPendingPod = ""
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReplicasInSync",
+		Message:            "All shards have replicas in sync",
+		ObservedGeneration: cluster.Generation,
+	})
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to clear replica sync state: %w", err)
+	}
+	return nil
+}
+
+// setReadyCondition records the Ready condition, persisting the status only when the
+// condition status or reason changes so progress updates don't re-trigger reconciles.
+func (r *RedisClusterReconciler) setReadyCondition(ctx context.Context, cluster *appv1.RedisCluster, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionReady)
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update Ready condition: %w", err)
+	}
+	return nil
+}
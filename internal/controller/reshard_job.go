@@ -0,0 +1,104 @@
+package controller
+
+import (
+	_ "embed"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+//go:embed scripts/reshard.sh
+var reshardScript string
+
+//go:embed scripts/rebalance.sh
+var rebalanceScript string
+
+// reshardMode selects how a reshard Job moves slots between masters.
+type reshardMode string
+
+const (
+	// reshardTargeted activates the standby on scale-up by moving half of the slots of one
+	// overloaded master to it.
+	reshardTargeted reshardMode = "reshard"
+
+	// reshardRebalance evens out slots across the masters that already serve slots with
+	// redis-cli --cluster rebalance. Standby masters hold no slots and are left empty.
+	reshardRebalance reshardMode = "rebalance"
+)
+
+// reshardJobForRedisCluster creates a Kubernetes Job that moves slots between masters in the
+// given mode. sourcePod and targetPod are the overloaded master and the standby it is relieved
+// onto in reshardTargeted mode and are ignored in reshardRebalance mode. Both modes run the Redis
+// image, reach the pods through applyRedisConnection, and take their limits from JobConfig.Reshard.
+func (r *RedisClusterReconciler) reshardJobForRedisCluster(cluster *appv1.RedisCluster, mode reshardMode, sourcePod string, targetPod string) *batchv1.Job {
+	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
+		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
+
+	timeout := int64(cluster.Spec.ReshardTimeoutSeconds)
+	backoff := int32(0)
+
+	container := corev1.Container{
+		Image:   redisImage(cluster),
+		Command: []string{"sh", "-c"},
+		Env: []corev1.EnvVar{
+			{Name: "MIGRATE_TIMEOUT_MS", Value: fmt.Sprintf("%d", cluster.Spec.MigrateTimeoutMillis)},
+		},
+	}
+	switch mode {
+	case reshardRebalance:
+		container.Name = "rebalance"
+		container.Args = []string{rebalanceScript}
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
+			corev1.EnvVar{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
+		)
+	default:
+		container.Name = "smart-reshard"
+		container.Args = []string{redis.Script(reshardScript, redis.PollScript, redis.ResolveIPScript, redis.NodeLookupScript, redis.SlotCountScript, redis.MigrateScript)}
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "ANY_POD_HOST", Value: anyPodHost},
+			corev1.EnvVar{Name: "ANY_POD_PORT", Value: fmt.Sprintf("%d", redisPort(cluster))},
+			corev1.EnvVar{Name: "ANY_POD_ENTRYPOINT", Value: entrypoint},
+			corev1.EnvVar{Name: "OVERLOADED_POD", Value: sourcePod},
+			corev1.EnvVar{Name: "STANDBY_POD", Value: targetPod},
+			corev1.EnvVar{Name: "CLUSTER_NAME", Value: cluster.Name},
+			corev1.EnvVar{Name: "SERVICE_NAME", Value: cluster.Name + "-headless"},
+			corev1.EnvVar{Name: "NAMESPACE", Value: cluster.Namespace},
+			corev1.EnvVar{Name: "MAX_ATTEMPTS", Value: fmt.Sprintf("%d", cluster.Spec.MigrationRetryAttempts)},
+		)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name + "-" + string(mode),
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: &timeout,
+			BackoffLimit:          &backoff,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
+					Containers:        []corev1.Container{container},
+				},
+			},
+		},
+	}
+	if mode == reshardTargeted {
+		applyJobPolling(cluster, &job.Spec.Template.Spec)
+	}
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Reshard)
+	return job
+}
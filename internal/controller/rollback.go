@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+//go:embed scripts/rollback.sh
+var rollbackScript string
+
+const (
+	rollbackOperationReshard = "reshard"
+	rollbackOperationDrain   = "drain"
+)
+
+// startRollback transitions a failed reshard or drain into the rollback state.
+// The scaling flags are cleared and the source pod is recorded so the rollback job
+// knows where migrated slots must be returned. For a drain, the masters that may have
+// received its slots are recorded as well, before the drain fields are cleared.
+func (r *RedisClusterReconciler) startRollback(ctx context.Context, cluster *appv1.RedisCluster, operation, sourcePod string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Rolling back failed scaling operation",
+		"operation", operation,
+		"sourcePod", sourcePod,
+		"standbyPod", cluster.Status.StandbyPod)
+
+	if operation == rollbackOperationDrain {
+		cluster.Status.RollbackDestPods = drainDestinations(cluster)
+		cluster.Status.RollbackRotatePod = cluster.Status.DrainRotatePod
+	}
+
+	cluster.Status.IsResharding = false
+	cluster.Status.OverloadedPod = ""
+	cluster.Status.IsDraining = false
+	cluster.Status.PodToDrain = ""
+	cluster.Status.DrainDestPod1 = ""
+	cluster.Status.DrainDestPod2 = ""
+	cluster.Status.DrainRotatePod = ""
+
+	cluster.Status.IsRollingBack = true
+	cluster.Status.RollbackOperation = operation
+	cluster.Status.RollbackSourcePod = sourcePod
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status to IsRollingBack")
+		return ctrl.Result{}, err
+	}
+
+	r.recordWarning(cluster, "RollbackStarted", "Rolling back failed %s (source %s)", operation, sourcePod)
+
+	return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+}
+
+// checkRollbackStatus creates the rollback job if needed and records its outcome.
+// A failed rollback job is intentionally left in place: checkNoJobsRunning then blocks
+// further scaling until a human has reviewed the cluster and deleted the job.
+func (r *RedisClusterReconciler) checkRollbackStatus(ctx context.Context, cluster *appv1.RedisCluster) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	jobName := cluster.Name + "-rollback"
+	operation := cluster.Status.RollbackOperation
+
+	rollbackJob := &batchv1.Job{}
+	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: cluster.Namespace}, rollbackJob)
+
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating rollback job",
+			"operation", operation,
+			"sourcePod", cluster.Status.RollbackSourcePod)
+
+		job := r.rollbackJobForRedisCluster(cluster)
+		if err := controllerutil.SetControllerReference(cluster, job, r.Scheme); err != nil {
+			logger.Error(err, "Failed to set owner reference on rollback job")
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			logger.Error(err, "Failed to create rollback job")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+
+	} else if err != nil {
+		logger.Error(err, "Failed to get rollback job")
+		return ctrl.Result{}, err
+	}
+
+	if rollbackJob.Status.Succeeded == 0 && rollbackJob.Status.Failed == 0 {
+		logger.Info("Rollback job is still running")
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	now := metav1.Now()
+	if rollbackJob.Status.Succeeded > 0 {
+		logger.Info("Rollback job succeeded, cluster restored to pre-scale state", "operation", operation)
+		cluster.Status.LastScaleFailure = fmt.Sprintf("%s failed at %s and was rolled back; review before relying on further scaling",
+			operation, now.Format(time.RFC3339))
+		r.recordWarning(cluster, "RollbackSucceeded", "Failed %s rolled back, cluster restored", operation)
+		_ = r.Delete(ctx, rollbackJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	} else {
+		logger.Error(fmt.Errorf("rollback job %s failed", jobName), "Rollback failed, manual intervention required")
+		cluster.Status.LastScaleFailure = fmt.Sprintf("%s failed at %s and rollback also failed; delete job %s after repairing the cluster",
+			operation, now.Format(time.RFC3339), jobName)
+		r.recordWarning(cluster, "RollbackFailed", "Rollback of failed %s failed, manual intervention required", operation)
+	}
+
+	r.verifyKeyspaceIntegrity(ctx, cluster, operation+" rollback")
+	endOperation(cluster)
+	cluster.Status.IsRollingBack = false
+	cluster.Status.RollbackOperation = ""
+	cluster.Status.RollbackSourcePod = ""
+	cluster.Status.RollbackDestPods = nil
+	cluster.Status.RollbackRotatePod = ""
+	cluster.Status.DrainSlotBaseline = nil
+	cluster.Status.LastScaleTime = &now
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status after rollback")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Duration(cluster.Spec.MetricsQueryInterval) * time.Second}, nil
+}
+
+// rollbackJobForRedisCluster creates a Kubernetes Job that restores the cluster after a failed
+// reshard or drain. It closes half-migrated slots, returns the slots moved off the source master
+// (from the standby after a reshard, along drainReturnMoves after a drain), and re-enables the
+// full coverage requirement.
+func (r *RedisClusterReconciler) rollbackJobForRedisCluster(cluster *appv1.RedisCluster) *batchv1.Job {
+	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
+		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
+
+	timeout := int64(cluster.Spec.ReshardTimeoutSeconds)
+	backoff := int32(1)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name + "-rollback",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: &timeout,
+			BackoffLimit:          &backoff,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
+					Containers: []corev1.Container{
+						{
+							Name:    "rollback",
+							Image:   redisImage(cluster),
+							Command: []string{"sh", "-c"},
+							Args:    []string{redis.Script(rollbackScript, redis.ResolveIPScript, redis.NodeLookupScript, redis.SlotCountScript)},
+							Env: []corev1.EnvVar{
+								{Name: "OPERATION", Value: cluster.Status.RollbackOperation},
+								{Name: "SOURCE_POD", Value: cluster.Status.RollbackSourcePod},
+								{Name: "STANDBY_POD", Value: cluster.Status.StandbyPod},
+								{Name: "RETURN_MOVES", Value: strings.Join(drainReturnMoves(cluster), " ")},
+								{Name: "SERVICE_NAME", Value: cluster.Name + "-headless"},
+								{Name: "NAMESPACE", Value: cluster.Namespace},
+								{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
+								{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
+								{Name: "MIGRATE_TIMEOUT_MS", Value: fmt.Sprintf("%d", cluster.Spec.MigrateTimeoutMillis)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	return job
+}
+
+// drainDestinations returns the masters a drain may have moved slots to. A drain that had no
+// destinations planned (SimpleRemove) rebalanced across every master in its slot baseline.
+func drainDestinations(cluster *appv1.RedisCluster) []string {
+	if cluster.Status.DrainDestPod1 == "" {
+		var pods []string
+		for pod := range cluster.Status.DrainSlotBaseline {
+			if pod != cluster.Status.PodToDrain && pod != cluster.Status.DrainRotatePod {
+				pods = append(pods, pod)
+			}
+		}
+		sort.Strings(pods)
+		return pods
+	}
+	pods := []string{cluster.Status.DrainDestPod1}
+	if cluster.Status.DrainDestPod2 != "" {
+		pods = append(pods, cluster.Status.DrainDestPod2)
+	}
+	return pods
+}
+
+// drainReturnMoves lists the slot moves that undo a failed drain, in order, as
+// "<pod>:<target>:<baseline>" entries: the rollback job moves whatever pod holds above baseline
+// to target. The destinations return to the rotated master if there was one, which then returns
+// what it took over to the source. Masters without a recorded baseline are left as they are.
+func drainReturnMoves(cluster *appv1.RedisCluster) []string {
+	if cluster.Status.RollbackOperation != rollbackOperationDrain {
+		return nil
+	}
+	source := cluster.Status.RollbackSourcePod
+	rotate := cluster.Status.RollbackRotatePod
+	target := source
+	if rotate != "" {
+		target = rotate
+	}
+
+	var moves []string
+	for _, pod := range cluster.Status.RollbackDestPods {
+		if baseline, ok := cluster.Status.DrainSlotBaseline[pod]; ok {
+			moves = append(moves, fmt.Sprintf("%s:%s:%d", pod, target, baseline))
+		}
+	}
+	if baseline, ok := cluster.Status.DrainSlotBaseline[rotate]; ok && rotate != "" {
+		moves = append(moves, fmt.Sprintf("%s:%s:%d", rotate, source, baseline))
+	}
+	return moves
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// masterSlotCounts returns the number of hash slots each pod of the cluster serves as a live master.
+func (r *RedisClusterReconciler) masterSlotCounts(ctx context.Context, cluster *appv1.RedisCluster) (map[string]int, error) {
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podIPs := make(map[string]string)
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podIPs[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	slots := make(map[string]int)
+	for _, node := range nodes {
+		if !node.IsMaster() || node.IsFailed() {
+			continue
+		}
+		if podName, ok := podIPs[node.IP]; ok {
+			slots[podName] = node.Slots
+		}
+	}
+	return slots, nil
+}
+
+// destinationScore weighs a master as a scale-down destination: its memory usage plus its share of
+// the hash slots, both in percent. A master low on memory but owning many slots would become a
+// hotspot once it also receives the drained slots, so it scores above one underweight in both.
+func destinationScore(load PodLoad, slots int) float64 {
+	return load.MemoryUsage + float64(slots)*100/redis.TotalSlots
+}
+
+// rankDestinations returns the loads ordered from the best to the worst scale-down destination by
+// destinationScore. Ties go to the master with fewer slots, then less memory, then the lower pod
+// name, so plans are deterministic. Masters missing from slots count as owning none.
+func rankDestinations(loads []PodLoad, slots map[string]int) []PodLoad {
+	ranked := make([]PodLoad, len(loads))
+	copy(ranked, loads)
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		scoreA, scoreB := destinationScore(a, slots[a.PodName]), destinationScore(b, slots[b.PodName])
+		switch {
+		case scoreA != scoreB:
+			return scoreA < scoreB
+		case slots[a.PodName] != slots[b.PodName]:
+			return slots[a.PodName] < slots[b.PodName]
+		case a.MemoryUsage != b.MemoryUsage:
+			return a.MemoryUsage < b.MemoryUsage
+		default:
+			return a.PodName < b.PodName
+		}
+	})
+	return ranked
+}
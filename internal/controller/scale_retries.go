@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// recordScaleFailure counts a failed scaling job and, once more than MaxScaleRetries have failed
+// in a row, sets the ScaleRetriesExhausted condition so automatic scaling stops. The condition
+// records the generation it was set at; checkScaleRetries clears it once the spec is edited.
+// The caller is responsible for persisting the status.
+func (r *RedisClusterReconciler) recordScaleFailure(cluster *appv1.RedisCluster, operation string) {
+	cluster.Status.ScaleFailureCount++
+	limit := cluster.Spec.MaxScaleRetries
+	if limit == 0 || cluster.Status.ScaleFailureCount <= limit {
+		return
+	}
+	if meta.IsStatusConditionTrue(cluster.Status.Conditions, appv1.ConditionScaleRetriesExhausted) {
+		return
+	}
+
+	message := fmt.Sprintf("%d scaling jobs failed in a row, the last a %s (maxScaleRetries %d); automatic scaling stopped until the spec is edited",
+		cluster.Status.ScaleFailureCount, operation, limit)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionScaleRetriesExhausted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "TooManyFailures",
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	r.recordWarning(cluster, appv1.ConditionScaleRetriesExhausted, "%s", message)
+}
+
+// resetScaleFailures clears the failure count after a successful scaling operation.
+// The caller is responsible for persisting the status.
+func resetScaleFailures(cluster *appv1.RedisCluster) {
+	cluster.Status.ScaleFailureCount = 0
+}
+
+// checkScaleRetries blocks scaling while the ScaleRetriesExhausted condition is True. An edit of
+// the spec since the condition was set is taken as the manual intervention it waits for: the
+// condition and the failure count are then cleared and scaling resumes.
+func (r *RedisClusterReconciler) checkScaleRetries(ctx context.Context, cluster *appv1.RedisCluster) error {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionScaleRetriesExhausted)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return nil
+	}
+	if condition.ObservedGeneration == cluster.Generation {
+		return fmt.Errorf("scaling stopped after %d failed scaling jobs, edit the RedisCluster to resume", cluster.Status.ScaleFailureCount)
+	}
+
+	log.FromContext(ctx).Info("Spec edited, resuming scaling after repeated failures", "failures", cluster.Status.ScaleFailureCount)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionScaleRetriesExhausted,
+		Status:             metav1.ConditionFalse,
+		Reason:             "SpecEdited",
+		Message:            "Failure count reset by a spec edit",
+		ObservedGeneration: cluster.Generation,
+	})
+	resetScaleFailures(cluster)
+	r.recordNormal(cluster, "ScaleRetriesReset", "Spec edited, automatic scaling resumed")
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to clear ScaleRetriesExhausted condition: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+#!/bin/bash
+set -ex
+
+echo "=== Add-Shard Scale-Up: Rebalance Slots Onto New Master ==="
+ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
+
+echo "=== Running cluster fix to ensure consistency ==="
+timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-yes || {
+  echo "WARNING: Cluster fix encountered issues, but continuing..."
+}
+
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+if [ "$CLUSTER_STATE" != "ok" ]; then
+  echo "ERROR: Cluster state is '$CLUSTER_STATE' (expected: ok)"
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes || true
+  exit 1
+fi
+
+NEW_MASTER_FQDN="${NEW_MASTER_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+NEW_MASTER_IP=$(resolve_ip $NEW_MASTER_FQDN)
+if [ -z "$NEW_MASTER_IP" ]; then
+  echo "ERROR: Could not resolve new master $NEW_MASTER_POD"
+  exit 1
+fi
+
+cluster_nodes_output=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)
+NEW_MASTER_ID=$(master_id "$cluster_nodes_output" $NEW_MASTER_IP)
+if [ -z "$NEW_MASTER_ID" ]; then
+  echo "ERROR: New master $NEW_MASTER_POD is not a master in the cluster"
+  echo "$cluster_nodes_output"
+  exit 1
+fi
+echo "New master: $NEW_MASTER_POD (ID: $NEW_MASTER_ID)"
+
+# Every other empty master is a standby and must stay empty: give it weight 0 so that
+# --cluster-use-empty-masters only brings the new master into the rebalance.
+WEIGHTS=""
+for id in $(echo "$cluster_nodes_output" | awk '$3 ~ /master/ && $3 !~ /fail/ && NF == 8 {print $1}'); do
+  if [ "$id" != "$NEW_MASTER_ID" ]; then
+    WEIGHTS="$WEIGHTS $id=0"
+  fi
+done
+
+echo "=== Rebalancing slots across all masters ==="
+if [ -n "$WEIGHTS" ]; then
+  redis-cli --cluster rebalance $ENTRYPOINT \
+    --cluster-use-empty-masters \
+    --cluster-weight $WEIGHTS \
+    --cluster-timeout $MIGRATE_TIMEOUT_MS \
+    --cluster-pipeline 10
+else
+  redis-cli --cluster rebalance $ENTRYPOINT \
+    --cluster-use-empty-masters \
+    --cluster-timeout $MIGRATE_TIMEOUT_MS \
+    --cluster-pipeline 10
+fi
+
+NEW_MASTER_SLOTS=$(slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $NEW_MASTER_ID)
+if [ "$NEW_MASTER_SLOTS" -le 0 ]; then
+  echo "ERROR: New master $NEW_MASTER_POD received no slots"
+  exit 1
+fi
+
+echo "=== Add-Shard Scale-Up Complete ==="
@@ -7,15 +7,19 @@ NAMESPACE="$NAMESPACE"
 ENTRYPOINT_HOST="$ENTRYPOINT_HOST"
 ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
 REPLICAS_PER_MASTER="$REPLICAS_PER_MASTER"
+STANDBY_REPLICAS="$STANDBY_REPLICAS"
+RESET_REPLICAS="$RESET_REPLICAS"
 NEW_STANDBY_INDEX="$NEW_STANDBY_INDEX"
 OLD_STANDBY_INDEX="$OLD_STANDBY_INDEX"
 CLUSTER_NAME="$CLUSTER_NAME"
+RESET_ATTEMPTS=3
+REMOVED_IDS=""
 
 echo "New standby index: $NEW_STANDBY_INDEX (will be re-added)"
 echo "Old standby index: $OLD_STANDBY_INDEX (will be deleted)"
 
 # Get cluster nodes
-cluster_nodes_output=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes)
+cluster_nodes_output=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)
 
 # ========== STEP 1: Delete new standby pods and their replicas ==========
 echo "=== Step 1: Deleting new standby pods (index $NEW_STANDBY_INDEX + replicas) ==="
@@ -24,14 +28,14 @@ for i in $(seq 0 $REPLICAS_PER_MASTER); do
   pod_index=$((NEW_STANDBY_INDEX + i))
   POD_NAME="${CLUSTER_NAME}-${pod_index}"
   POD_FQDN="${POD_NAME}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-  POD_IP=$(getent hosts $POD_FQDN | awk '{print $1}')
+  POD_IP=$(resolve_ip $POD_FQDN)
 
   if [ -z "$POD_IP" ]; then
     echo "Pod $POD_NAME not found in DNS, skipping"
     continue
   fi
 
-  NODE_ID=$(echo "$cluster_nodes_output" | grep "$POD_IP:6379" | awk '{print $1}')
+  NODE_ID=$(node_id "$cluster_nodes_output" $POD_IP)
 
   if [ -z "$NODE_ID" ]; then
     echo "Pod $POD_NAME ($POD_IP) not found in cluster, skipping"
@@ -41,24 +45,25 @@ for i in $(seq 0 $REPLICAS_PER_MASTER); do
   echo "Deleting pod $POD_NAME (ID: $NODE_ID, IP: $POD_IP)"
   redis-cli --cluster del-node $ENTRYPOINT $NODE_ID || \
     (sleep 5 && redis-cli --cluster del-node $ENTRYPOINT $NODE_ID)
+  REMOVED_IDS="$REMOVED_IDS $NODE_ID"
   sleep 2
 done
 
 # ========== STEP 2: Delete old standby pods and their replicas ==========
 echo "=== Step 2: Deleting old standby pods (index $OLD_STANDBY_INDEX + replicas) ==="
 
-for i in $(seq 0 $REPLICAS_PER_MASTER); do
+for i in $(seq 0 $STANDBY_REPLICAS); do
   pod_index=$((OLD_STANDBY_INDEX + i))
   POD_NAME="${CLUSTER_NAME}-${pod_index}"
   POD_FQDN="${POD_NAME}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-  POD_IP=$(getent hosts $POD_FQDN | awk '{print $1}')
+  POD_IP=$(resolve_ip $POD_FQDN)
 
   if [ -z "$POD_IP" ]; then
     echo "Pod $POD_NAME not found in DNS, skipping"
     continue
   fi
 
-  NODE_ID=$(echo "$cluster_nodes_output" | grep "$POD_IP:6379" | awk '{print $1}')
+  NODE_ID=$(node_id "$cluster_nodes_output" $POD_IP)
 
   if [ -z "$NODE_ID" ]; then
     echo "Pod $POD_NAME ($POD_IP) not found in cluster, skipping"
@@ -68,21 +73,120 @@ for i in $(seq 0 $REPLICAS_PER_MASTER); do
   echo "Deleting pod $POD_NAME (ID: $NODE_ID, IP: $POD_IP)"
   redis-cli --cluster del-node $ENTRYPOINT $NODE_ID || \
     (sleep 5 && redis-cli --cluster del-node $ENTRYPOINT $NODE_ID)
+  REMOVED_IDS="$REMOVED_IDS $NODE_ID"
   sleep 2
 done
 
 echo "Finished deleting old pods from cluster"
-sleep 3
+
+# ========== Wait for a quorum of masters to forget the deleted nodes ==========
+# del-node only sends CLUSTER FORGET to the nodes the entrypoint knows, and a master that missed
+# it gossips the deleted node back once the 60s forget ban expires. Until a majority of the
+# healthy masters have dropped every deleted ID, resetting the pods could let them rejoin with
+# their old identity, so CLUSTER FORGET is re-sent each round and the wait is bounded by
+# POLL_TIMEOUT_SECONDS.
+
+# healthy_master_ips NODES prints the IPs of the masters in the CLUSTER NODES output NODES that
+# are neither failing nor among REMOVED_IDS.
+healthy_master_ips() {
+  echo "$1" | awk -v removed="$REMOVED_IDS" '
+    BEGIN { n = split(removed, ids, " "); for (i = 1; i <= n; i++) gone[ids[i]] = 1 }
+    $3 ~ /(^|,)master(,|$)/ && $3 !~ /fail|noaddr|handshake/ && !($1 in gone) {
+      split($2, a, "@"); sub(/:[0-9]+$/, "", a[1]); print a[1]
+    }'
+}
+
+# lists_removed NODES succeeds when the CLUSTER NODES output NODES still lists any of REMOVED_IDS.
+lists_removed() {
+  echo "$1" | awk -v removed="$REMOVED_IDS" '
+    BEGIN { n = split(removed, ids, " "); for (i = 1; i <= n; i++) gone[ids[i]] = 1 }
+    $1 in gone { found = 1 }
+    END { exit !found }'
+}
+
+if [ -n "$REMOVED_IDS" ]; then
+  echo "Waiting for a quorum of masters to forget:$REMOVED_IDS"
+  attempt=0
+  while true; do
+    masters=$(healthy_master_ips "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes 2>/dev/null)")
+    total=0
+    forgotten=0
+    for ip in $masters; do
+      total=$((total + 1))
+      for id in $REMOVED_IDS; do
+        redis-cli -h $ip -p $REDIS_PORT CLUSTER FORGET $id >/dev/null 2>&1 || true
+      done
+      view=$(redis-cli -h $ip -p $REDIS_PORT cluster nodes 2>/dev/null) || continue
+      if [ -n "$view" ] && ! lists_removed "$view"; then
+        forgotten=$((forgotten + 1))
+      fi
+    done
+
+    quorum=$((total / 2 + 1))
+    echo "Masters that forgot the deleted nodes: $forgotten/$total (quorum $quorum)"
+    if [ "$total" -gt 0 ] && [ "$forgotten" -ge "$quorum" ]; then
+      break
+    fi
+    attempt=$((attempt + 1))
+    if [ "$attempt" -ge "$POLL_TIMEOUT_SECONDS" ]; then
+      poll_timeout $ENTRYPOINT_HOST "a quorum of masters to forget$REMOVED_IDS ($forgotten/$total forgot)"
+    fi
+    sleep 1
+  done
+fi
+
+# node_is_clean IP
+# Succeeds when the node is a fresh single-node cluster: it knows only itself, owns no slots,
+# has epoch 0, and holds no keys. A node that still remembers peers, slots, or its epoch would
+# make the following add-node fail or rejoin with stale state.
+node_is_clean() {
+  info=$(redis-cli -h $1 -p $REDIS_PORT cluster info | tr -d '\r')
+  known=$(echo "$info" | grep '^cluster_known_nodes:' | cut -d: -f2)
+  slots=$(echo "$info" | grep '^cluster_slots_assigned:' | cut -d: -f2)
+  epoch=$(echo "$info" | grep '^cluster_current_epoch:' | cut -d: -f2)
+  nodes=$(redis-cli -h $1 -p $REDIS_PORT cluster nodes | grep -c . || true)
+  keys=$(redis-cli -h $1 -p $REDIS_PORT dbsize | tr -d '\r')
+
+  echo "Node $1: known_nodes=$known slots_assigned=$slots current_epoch=$epoch nodes=$nodes keys=$keys"
+  [ "$known" = "1" ] && [ "$slots" = "0" ] && [ "$epoch" = "0" ] && [ "$nodes" = "1" ] && [ "$keys" = "0" ]
+}
+
+# reset_node IP NAME
+# Flushes and hard-resets the node, verifying the result and retrying the reset until the node
+# is clean. Exits the job if the node is still not clean after RESET_ATTEMPTS tries, rather than
+# re-adding a node the next scale-up could not use.
+reset_node() {
+  attempt=1
+  while [ $attempt -le $RESET_ATTEMPTS ]; do
+    # Reset the node - this clears cluster state and data
+    redis-cli -h $1 -p $REDIS_PORT FLUSHALL
+    redis-cli -h $1 -p $REDIS_PORT CLUSTER RESET HARD
+    sleep 2
+
+    if node_is_clean $1; then
+      echo "Pod $2 is clean after $attempt reset(s)"
+      return 0
+    fi
+    echo "Pod $2 is not clean after reset attempt $attempt/$RESET_ATTEMPTS, retrying"
+    attempt=$((attempt + 1))
+    sleep 3
+  done
+
+  echo "ERROR: Pod $2 ($1) is still not clean after $RESET_ATTEMPTS resets"
+  redis-cli -h $1 -p $REDIS_PORT cluster nodes || true
+  exit 1
+}
 
 # ========== STEP 3: Reset new standby pods to clean state ==========
 echo "=== Step 3: Resetting new standby pods to clean state ==="
 
-# Reset the new standby master and replicas
-for i in $(seq 0 $REPLICAS_PER_MASTER); do
+# Reset the new standby master and the replicas it keeps as standby, plus the idle pods of its
+# shard when it stays in the StatefulSet (RESET_REPLICAS > STANDBY_REPLICAS)
+for i in $(seq 0 $RESET_REPLICAS); do
   pod_index=$((NEW_STANDBY_INDEX + i))
   POD_NAME="${CLUSTER_NAME}-${pod_index}"
   POD_FQDN="${POD_NAME}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-  POD_IP=$(getent hosts $POD_FQDN | awk '{print $1}')
+  POD_IP=$(resolve_ip $POD_FQDN)
 
   if [ -z "$POD_IP" ]; then
     echo "WARNING: Pod $POD_NAME not found in DNS, skipping reset"
@@ -90,12 +194,7 @@ for i in $(seq 0 $REPLICAS_PER_MASTER); do
   fi
 
   echo "Resetting pod $POD_NAME ($POD_IP)..."
-
-  # Reset the node - this clears cluster state and data
-  redis-cli -h $POD_IP -p 6379 FLUSHALL
-  redis-cli -h $POD_IP -p 6379 CLUSTER RESET HARD
-
-  sleep 2
+  reset_node $POD_IP $POD_NAME
 done
 
 echo "Reset complete, nodes are now clean"
@@ -107,21 +206,21 @@ echo "=== Step 4: Adding new standby pods to cluster ==="
 # Calculate new standby pod indices
 NEW_STANDBY_POD="${CLUSTER_NAME}-${NEW_STANDBY_INDEX}"
 NEW_STANDBY_FQDN="${NEW_STANDBY_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-NEW_STANDBY_IP=$(getent hosts $NEW_STANDBY_FQDN | awk '{print $1}')
+NEW_STANDBY_IP=$(resolve_ip $NEW_STANDBY_FQDN)
 
 if [ -z "$NEW_STANDBY_IP" ]; then
   echo "ERROR: Could not resolve new standby pod $NEW_STANDBY_POD"
   exit 1
 fi
 
-echo "Adding new standby master: $NEW_STANDBY_POD ($NEW_STANDBY_IP:6379)"
+echo "Adding new standby master: $NEW_STANDBY_POD ($NEW_STANDBY_IP:$REDIS_PORT)"
 
 # Add as fresh node (should work now after CLUSTER RESET)
-redis-cli --cluster add-node ${NEW_STANDBY_IP}:6379 $ENTRYPOINT
+redis-cli --cluster add-node ${NEW_STANDBY_IP}:$REDIS_PORT $ENTRYPOINT
 sleep 5
 
-cluster_nodes_output=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes)
-NEW_STANDBY_NODE_ID=$(echo "$cluster_nodes_output" | grep "$NEW_STANDBY_IP:6379" | awk '{print $1}')
+cluster_nodes_output=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)
+NEW_STANDBY_NODE_ID=$(node_id "$cluster_nodes_output" $NEW_STANDBY_IP)
 
 if [ -z "$NEW_STANDBY_NODE_ID" ]; then
   echo "ERROR: Failed to get new standby node ID after adding"
@@ -130,27 +229,27 @@ fi
 echo "New standby master added with ID: $NEW_STANDBY_NODE_ID"
 
 # ========== STEP 5: Add replicas for new standby ==========
-if [ "$REPLICAS_PER_MASTER" -gt 0 ]; then
+if [ "$STANDBY_REPLICAS" -gt 0 ]; then
   echo "=== Step 5: Adding replicas for new standby master ==="
 
-  for i in $(seq 1 $REPLICAS_PER_MASTER); do
+  for i in $(seq 1 $STANDBY_REPLICAS); do
     REPLICA_INDEX=$((NEW_STANDBY_INDEX + i))
     REPLICA_POD="${CLUSTER_NAME}-${REPLICA_INDEX}"
     REPLICA_FQDN="${REPLICA_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-    REPLICA_IP=$(getent hosts $REPLICA_FQDN | awk '{print $1}')
+    REPLICA_IP=$(resolve_ip $REPLICA_FQDN)
 
     if [ -z "$REPLICA_IP" ]; then
       echo "WARNING: Could not resolve replica pod $REPLICA_POD, skipping"
       continue
     fi
 
-    echo "Adding replica: $REPLICA_POD ($REPLICA_IP:6379) as slave of $NEW_STANDBY_NODE_ID"
+    echo "Adding replica: $REPLICA_POD ($REPLICA_IP:$REDIS_PORT) as slave of $NEW_STANDBY_NODE_ID"
 
     # Check if replica is already in cluster
-    if echo "$cluster_nodes_output" | grep -q "$REPLICA_IP:6379"; then
+    if echo "$cluster_nodes_output" | grep -q "$REPLICA_IP:$REDIS_PORT"; then
       echo "Replica $REPLICA_POD already in cluster"
     else
-      redis-cli --cluster add-node ${REPLICA_IP}:6379 $ENTRYPOINT --cluster-slave --cluster-master-id $NEW_STANDBY_NODE_ID
+      redis-cli --cluster add-node ${REPLICA_IP}:$REDIS_PORT $ENTRYPOINT --cluster-slave --cluster-master-id $NEW_STANDBY_NODE_ID
       sleep 3
       echo "Replica $REPLICA_POD added"
     fi
@@ -158,4 +257,4 @@ if [ "$REPLICAS_PER_MASTER" -gt 0 ]; then
 fi
 
 echo "=== Cleanup and Re-add Complete ==="
-redis-cli -h $ENTRYPOINT_HOST cluster nodes
+redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes
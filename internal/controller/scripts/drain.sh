@@ -5,19 +5,28 @@ echo "=== Smart Scale-Down with Standby Preservation ==="
 POD_TO_DRAIN="$POD_TO_DRAIN"
 DEST_POD_1="$DEST_POD_1"
 DEST_POD_2="$DEST_POD_2"
+ROTATE_POD="$ROTATE_POD"
 STANDBY_POD="$STANDBY_POD"
 SERVICE_NAME="$SERVICE_NAME"
 NAMESPACE="$NAMESPACE"
 ENTRYPOINT_HOST="$ENTRYPOINT_HOST"
 ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
+MAX_ATTEMPTS="${MAX_ATTEMPTS:-3}"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
+SKIP_GHOST_CLEANUP="${SKIP_GHOST_CLEANUP:-false}"
+GHOST_RECHECK_SECONDS="${GHOST_RECHECK_SECONDS:-30}"
+WRITE_PAUSE_MS="${WRITE_PAUSE_MS:-0}"
 
 echo "Pod to drain: $POD_TO_DRAIN (will become new standby)"
 echo "Current standby: $STANDBY_POD (will become active master)"
 echo "Destinations: $DEST_POD_1, $DEST_POD_2"
+if [ -n "$ROTATE_POD" ]; then
+  echo "Lowest-load master: $ROTATE_POD (drained to destinations, then $POD_TO_DRAIN rotated into it)"
+fi
 
 # ========== CLUSTER FIX ==========
 echo "=== Step 0: Quick cluster health check ==="
-CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
 
 if [ "$CLUSTER_STATE" = "ok" ]; then
   echo "Cluster state is OK, skipping cluster fix"
@@ -28,11 +37,11 @@ else
   }
 fi
 
-CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
 if [ "$CLUSTER_STATE" != "ok" ]; then
   echo "ERROR: Cluster state is '$CLUSTER_STATE' after fix (expected: ok)"
-  redis-cli -h $ENTRYPOINT_HOST cluster info
-  redis-cli -h $ENTRYPOINT_HOST cluster nodes
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes
   exit 1
 fi
 
@@ -40,20 +49,44 @@ echo "Cluster fix complete. State: $CLUSTER_STATE"
 
 # ========== GHOST NODE CLEANUP ==========
 echo "=== Step 0.5: Cleanup failed/disconnected nodes ==="
-FAILED_NODES=$(redis-cli -h $ENTRYPOINT_HOST -p 6379 cluster nodes | grep -E 'fail|disconnected|noaddr' | awk '{print $1}')
+# failed_node_ids prints the IDs of nodes that are failed, unreachable, or disconnected.
+failed_node_ids() {
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes | grep -E 'fail|disconnected|noaddr' | awk '{print $1}' | sort
+}
+
+FAILED_NODES=""
+if [ "$SKIP_GHOST_CLEANUP" = "true" ]; then
+  echo "Ghost node cleanup disabled, skipping"
+else
+  CANDIDATES=$(failed_node_ids)
+  if [ -n "$CANDIDATES" ]; then
+    # A node that is only flapping recovers within the re-check window; forget
+    # only the nodes that were failing on both checks.
+    echo "Found failing nodes, re-checking in ${GHOST_RECHECK_SECONDS}s before forgetting: $CANDIDATES"
+    sleep $GHOST_RECHECK_SECONDS
+    STILL_FAILED=$(failed_node_ids)
+    for id in $CANDIDATES; do
+      if echo "$STILL_FAILED" | grep -qx "$id"; then
+        FAILED_NODES="$FAILED_NODES $id"
+      else
+        echo "Node $id recovered, not forgetting"
+      fi
+    done
+  fi
+fi
 
 if [ -n "$FAILED_NODES" ]; then
   FAILED_COUNT=$(echo "$FAILED_NODES" | wc -w)
   echo "Found $FAILED_COUNT failed/ghost nodes to clean up"
 
-  HEALTHY_IPS=$(redis-cli -h $ENTRYPOINT_HOST -p 6379 cluster nodes | \
+  HEALTHY_IPS=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes | \
     grep -v -E 'fail|disconnected|noaddr' | \
     awk '{print $2}' | cut -d'@' -f1 | cut -d':' -f1 | sort -u)
 
   for failed_id in $FAILED_NODES; do
     echo "Forgetting failed node: $failed_id"
     for ip in $HEALTHY_IPS; do
-      redis-cli -h $ip -p 6379 CLUSTER FORGET $failed_id 2>/dev/null || true
+      redis-cli -h $ip -p $REDIS_PORT CLUSTER FORGET $failed_id 2>/dev/null || true
     done
   done
 
@@ -66,14 +99,14 @@ fi
 # ========== VERIFY STANDBY ==========
 echo "=== Step 1: Verify standby node ==="
 STANDBY_FQDN="${STANDBY_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-STANDBY_IP=$(getent hosts $STANDBY_FQDN | awk '{print $1}')
+STANDBY_IP=$(resolve_ip $STANDBY_FQDN)
 
 if [ -z "$STANDBY_IP" ]; then
   echo "ERROR: Could not resolve standby pod $STANDBY_POD"
   exit 1
 fi
 
-STANDBY_NODE_ID=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | grep "$STANDBY_IP:6379" | grep master | awk '{print $1}')
+STANDBY_NODE_ID=$(master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $STANDBY_IP)
 
 if [ -z "$STANDBY_NODE_ID" ]; then
   echo "ERROR: Standby node not found in cluster"
@@ -81,18 +114,7 @@ if [ -z "$STANDBY_NODE_ID" ]; then
 fi
 
 # Verify standby has no slots
-STANDBY_SLOTS=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | grep "^$STANDBY_NODE_ID " | awk '{
-  slots=0
-  for(i=9; i<=NF; i++) {
-    if($i ~ /^[0-9]+-[0-9]+$/) {
-      split($i, range, "-")
-      slots += (range[2] - range[1] + 1)
-    } else if($i ~ /^[0-9]+$/) {
-      slots += 1
-    }
-  }
-  print slots
-}')
+STANDBY_SLOTS=$(slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $STANDBY_NODE_ID)
 
 if [ "$STANDBY_SLOTS" -ne 0 ]; then
   echo "WARNING: Standby node has $STANDBY_SLOTS slots (expected 0)"
@@ -104,10 +126,10 @@ echo "Standby verified: $STANDBY_POD (ID: $STANDBY_NODE_ID)"
 # ========== RESOLVE IPs ==========
 echo "=== Step 2: Resolving pod IPs ==="
 POD_TO_DRAIN_FQDN="${POD_TO_DRAIN}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-POD_IP=$(getent hosts $POD_TO_DRAIN_FQDN | awk '{print $1}')
+POD_IP=$(resolve_ip $POD_TO_DRAIN_FQDN)
 
 DEST1_FQDN="${DEST_POD_1}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-DEST1_IP=$(getent hosts $DEST1_FQDN | awk '{print $1}')
+DEST1_IP=$(resolve_ip $DEST1_FQDN)
 
 if [ -z "$POD_IP" ]; then
   echo "ERROR: Could not resolve IP for $POD_TO_DRAIN"
@@ -126,14 +148,13 @@ echo "Destination 1: $DEST_POD_1 (IP: $DEST1_IP)"
 DEST2_IP=""
 if [ -n "$DEST_POD_2" ]; then
   DEST2_FQDN="${DEST_POD_2}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-  DEST2_IP=$(getent hosts $DEST2_FQDN | awk '{print $1}')
+  DEST2_IP=$(resolve_ip $DEST2_FQDN)
   echo "Destination 2: $DEST_POD_2 (IP: $DEST2_IP)"
 fi
 
 # ========== FIND NODE IDs ==========
 echo "=== Step 3: Finding Redis node IDs ==="
-NODE_TO_DRAIN=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | \
-  grep "$POD_IP:6379" | grep master | awk '{print $1}')
+NODE_TO_DRAIN=$(master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $POD_IP)
 
 if [ -z "$NODE_TO_DRAIN" ]; then
   echo "Node with IP $POD_IP not found. Assuming already removed."
@@ -141,8 +162,7 @@ if [ -z "$NODE_TO_DRAIN" ]; then
 fi
 echo "Node to drain: $NODE_TO_DRAIN"
 
-DEST1_ID=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | \
-  grep "$DEST1_IP:6379" | grep master | awk '{print $1}')
+DEST1_ID=$(master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $DEST1_IP)
 
 if [ -z "$DEST1_ID" ]; then
   echo "ERROR: Could not find master node for $DEST_POD_1"
@@ -152,8 +172,7 @@ echo "Destination 1 node ID: $DEST1_ID"
 
 DEST2_ID=""
 if [ -n "$DEST2_IP" ]; then
-  DEST2_ID=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | \
-    grep "$DEST2_IP:6379" | grep master | awk '{print $1}')
+  DEST2_ID=$(master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $DEST2_IP)
 
   if [ -z "$DEST2_ID" ]; then
     echo "ERROR: Could not find master node for $DEST_POD_2"
@@ -162,21 +181,22 @@ if [ -n "$DEST2_IP" ]; then
   echo "Destination 2 node ID: $DEST2_ID"
 fi
 
+ROTATE_ID=""
+if [ -n "$ROTATE_POD" ]; then
+  ROTATE_FQDN="${ROTATE_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+  ROTATE_IP=$(resolve_ip $ROTATE_FQDN)
+  ROTATE_ID=$(master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $ROTATE_IP)
+
+  if [ -z "$ROTATE_ID" ]; then
+    echo "ERROR: Could not find master node for $ROTATE_POD"
+    exit 1
+  fi
+  echo "Lowest-load node ID: $ROTATE_ID"
+fi
+
 # ========== CHECK SLOT COUNT ==========
 echo "=== Step 4: Checking slot count ==="
-SLOT_COUNT=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | \
-  grep "^$NODE_TO_DRAIN" | awk '{
-    slots=0
-    for(i=9; i<=NF; i++) {
-      if($i ~ /^[0-9]+-[0-9]+$/) {
-        split($i, range, "-")
-        slots += (range[2] - range[1] + 1)
-      } else if($i ~ /^[0-9]+$/) {
-        slots += 1
-      }
-    }
-    print slots
-  }')
+SLOT_COUNT=$(slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $NODE_TO_DRAIN)
 
 echo "Node has $SLOT_COUNT slots"
 
@@ -185,46 +205,61 @@ if [ "$SLOT_COUNT" -eq 0 ]; then
 else
   # ========== DISABLE FULL COVERAGE ==========
   echo "=== Step 5: Disabling full coverage requirement ==="
-  node_ips=$(redis-cli -h $ENTRYPOINT_HOST cluster nodes | \
-    awk '{print $2}' | cut -d'@' -f1 | cut -d':' -f1 | sort -u)
+  node_ips=$(node_ips "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)")
   for ip in $node_ips; do
-    timeout 5 redis-cli -h $ip -p 6379 CONFIG SET cluster-require-full-coverage no || true
+    timeout 5 redis-cli -h $ip -p $REDIS_PORT CONFIG SET cluster-require-full-coverage no || true
   done
   sleep 2
 
+  # ========== SETTLE IN-FLIGHT WRITES ==========
+  if [ "$WRITE_PAUSE_MS" -gt 0 ]; then
+    echo "=== Step 5.5: Pausing writes on $POD_TO_DRAIN for ${WRITE_PAUSE_MS}ms ==="
+    # The pause expires on its own, but release it explicitly on any exit as well.
+    trap 'redis-cli -h $POD_IP -p $REDIS_PORT CLIENT UNPAUSE >/dev/null 2>&1 || true' EXIT
+    redis-cli -h $POD_IP -p $REDIS_PORT CLIENT PAUSE $WRITE_PAUSE_MS WRITE
+    sleep $(awk "BEGIN {print $WRITE_PAUSE_MS / 1000}")
+    redis-cli -h $POD_IP -p $REDIS_PORT CLIENT UNPAUSE || true
+    echo "In-flight writes settled, pause released"
+  fi
+
   # ========== MIGRATE SLOTS ==========
   echo "=== Step 6: Migrating slots ==="
-  if [ -n "$DEST2_ID" ]; then
-    # Split between two destinations
-    HALF_SLOTS=$((SLOT_COUNT / 2))
-    REMAINING_SLOTS=$((SLOT_COUNT - HALF_SLOTS))
-
-    echo "Migrating $HALF_SLOTS slots to $DEST1_ID..."
-    echo "$HALF_SLOTS" | redis-cli --cluster reshard $ENTRYPOINT \
-      --cluster-from $NODE_TO_DRAIN \
-      --cluster-to $DEST1_ID \
-      --cluster-yes \
-      --cluster-timeout 10000 \
-      --cluster-pipeline 10
-
-    sleep 5
-
-    echo "Migrating remaining $REMAINING_SLOTS slots to $DEST2_ID..."
-    echo "$REMAINING_SLOTS" | redis-cli --cluster reshard $ENTRYPOINT \
-      --cluster-from $NODE_TO_DRAIN \
-      --cluster-to $DEST2_ID \
-      --cluster-yes \
-      --cluster-timeout 10000 \
-      --cluster-pipeline 10
+  # drain_to_destinations moves COUNT slots from SOURCE_ID to the destination(s).
+  drain_to_destinations() {
+    source_id=$1
+    total=$2
+    if [ -n "$DEST2_ID" ]; then
+      # Split between two destinations
+      HALF_SLOTS=$((total / 2))
+      REMAINING_SLOTS=$((total - HALF_SLOTS))
+
+      echo "Migrating $HALF_SLOTS slots to $DEST1_ID..."
+      migrate_slots $source_id $DEST1_ID $HALF_SLOTS
+
+      sleep 5
+
+      echo "Migrating remaining $REMAINING_SLOTS slots to $DEST2_ID..."
+      migrate_slots $source_id $DEST2_ID $REMAINING_SLOTS
+    else
+      # All slots go to single destination
+      echo "Migrating all $total slots to $DEST1_ID..."
+      migrate_slots $source_id $DEST1_ID $total
+    fi
+  }
+
+  if [ -n "$ROTATE_ID" ]; then
+    # Empty the lowest-load master first, then rotate the highest-index master's slots
+    # down into it so the highest-index master still ends up empty.
+    ROTATE_SLOT_COUNT=$(count_slots $ROTATE_ID)
+    if [ "$ROTATE_SLOT_COUNT" -gt 0 ]; then
+      drain_to_destinations $ROTATE_ID $ROTATE_SLOT_COUNT
+      sleep 5
+    fi
+
+    echo "Rotating all $SLOT_COUNT slots from $NODE_TO_DRAIN into $ROTATE_ID..."
+    migrate_slots $NODE_TO_DRAIN $ROTATE_ID $SLOT_COUNT
   else
-    # All slots go to single destination
-    echo "Migrating all $SLOT_COUNT slots to $DEST1_ID..."
-    echo "$SLOT_COUNT" | redis-cli --cluster reshard $ENTRYPOINT \
-      --cluster-from $NODE_TO_DRAIN \
-      --cluster-to $DEST1_ID \
-      --cluster-yes \
-      --cluster-timeout 10000 \
-      --cluster-pipeline 10
+    drain_to_destinations $NODE_TO_DRAIN $SLOT_COUNT
   fi
 
   sleep 5
@@ -232,15 +267,15 @@ else
   # ========== RE-ENABLE FULL COVERAGE ==========
   echo "=== Step 8: Re-enabling full coverage requirement ==="
   for ip in $node_ips; do
-    timeout 5 redis-cli -h $ip -p 6379 CONFIG SET cluster-require-full-coverage yes || true
+    timeout 5 redis-cli -h $ip -p $REDIS_PORT CONFIG SET cluster-require-full-coverage yes || true
   done
   sleep 2
 fi
 
 # ========== VERIFY ==========
 echo "=== Step 9: Final verification ==="
-redis-cli -h $ENTRYPOINT_HOST cluster nodes
-redis-cli -h $ENTRYPOINT_HOST cluster info
+redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes
+redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info
 
 echo "=== Smart Scale-Down Complete ==="
 echo "Drained pod $POD_TO_DRAIN now has 0 slots and will become new standby (along with its replica)"
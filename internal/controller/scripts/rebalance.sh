@@ -0,0 +1,31 @@
+#!/bin/bash
+set -ex
+
+echo "=== Manual Rebalance: Even Out Slots Across Active Masters ==="
+ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
+
+# Close any half-migrated slots left behind by earlier operations (best-effort)
+echo "=== Running cluster fix to ensure consistency ==="
+timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-yes || {
+  echo "WARNING: Cluster fix encountered issues, but continuing..."
+}
+
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+if [ "$CLUSTER_STATE" != "ok" ]; then
+  echo "ERROR: Cluster state is '$CLUSTER_STATE' (expected: ok)"
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes || true
+  exit 1
+fi
+
+# Masters without slots (the standbys) are left out: --cluster-use-empty-masters is not passed,
+# so the standby pool stays empty and ready for the next scale-up.
+echo "=== Rebalancing slots ==="
+redis-cli --cluster rebalance $ENTRYPOINT \
+  --cluster-timeout $MIGRATE_TIMEOUT_MS \
+  --cluster-pipeline 10
+
+echo "=== Verifying cluster after rebalance ==="
+redis-cli --cluster check $ENTRYPOINT
+
+echo "=== Manual Rebalance Complete ==="
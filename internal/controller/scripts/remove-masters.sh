@@ -0,0 +1,47 @@
+#!/bin/bash
+set -ex
+
+echo "=== Removing Unexpected Masters: $NODE_IDS ==="
+ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
+
+# Close any half-migrated slots left behind by the manual changes (best-effort)
+echo "=== Running cluster fix to ensure consistency ==="
+timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-yes || {
+  echo "WARNING: Cluster fix encountered issues, but continuing..."
+}
+
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+if [ "$CLUSTER_STATE" != "ok" ]; then
+  echo "ERROR: Cluster state is '$CLUSTER_STATE' (expected: ok)"
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes || true
+  exit 1
+fi
+
+# A weight of 0 moves every slot off the node. Masters without slots (the standbys) are left out
+# because --cluster-use-empty-masters is not passed.
+WEIGHTS=""
+for NODE_ID in $NODE_IDS; do
+  WEIGHTS="$WEIGHTS $NODE_ID=0"
+done
+
+echo "=== Migrating slots off the unexpected masters ==="
+redis-cli --cluster rebalance $ENTRYPOINT \
+  --cluster-weight $WEIGHTS \
+  --cluster-timeout $MIGRATE_TIMEOUT_MS \
+  --cluster-pipeline 10
+
+# del-node reassigns the removed master's replicas, forgets it on every node and resets it.
+for NODE_ID in $NODE_IDS; do
+  if ! redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes | grep -q "^$NODE_ID "; then
+    echo "Node $NODE_ID already left the cluster"
+    continue
+  fi
+  echo "=== Removing node $NODE_ID ==="
+  redis-cli --cluster del-node $ENTRYPOINT $NODE_ID
+done
+
+echo "=== Verifying cluster after removal ==="
+redis-cli --cluster check $ENTRYPOINT
+
+echo "=== Unexpected Masters Removed ==="
@@ -7,9 +7,12 @@ OVERLOADED_POD="$OVERLOADED_POD"
 STANDBY_POD="$STANDBY_POD"
 ANY_POD_HOST="$ANY_POD_HOST"
 ANY_POD_PORT="$ANY_POD_PORT"
+ENTRYPOINT_HOST="$ANY_POD_HOST"
 CLUSTER_NAME="$CLUSTER_NAME"
 SERVICE_NAME="$SERVICE_NAME"
 NAMESPACE="$NAMESPACE"
+MAX_ATTEMPTS="${MAX_ATTEMPTS:-3}"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
 
 wait_until=$(($(date +%s) + 600))
 
@@ -22,26 +25,20 @@ timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-fix-with-unreachable-m
   echo "WARNING: Cluster fix encountered issues, but continuing..."
 }
 
-# Verify cluster state after fix
-CLUSTER_STATE=$(redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
-if [ "$CLUSTER_STATE" != "ok" ]; then
-  echo "ERROR: Cluster state is '$CLUSTER_STATE' after fix (expected: ok)"
-  redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster info || true
-  redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes || true
-  exit 1
-fi
-echo "Cluster state: $CLUSTER_STATE"
+# Wait for the cluster to settle after the fix
+wait_cluster_ok $ANY_POD_HOST
+echo "Cluster state: ok"
 
 # Resolve standby pod
 STANDBY_FQDN="${STANDBY_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-STANDBY_IP=$(getent hosts $STANDBY_FQDN | awk '{print $1}')
+STANDBY_IP=$(resolve_ip $STANDBY_FQDN)
 if [ -z "$STANDBY_IP" ]; then
   echo "ERROR: Could not resolve standby pod $STANDBY_POD"
   exit 1
 fi
 
 cluster_nodes_output=$(redis-cli -h $ANY_POD_HOST -p $ANY_POD_PORT cluster nodes)
-STANDBY_NODE_ID=$(echo "$cluster_nodes_output" | grep "$STANDBY_IP:6379" | grep master | awk '{print $1}')
+STANDBY_NODE_ID=$(master_id "$cluster_nodes_output" $STANDBY_IP)
 if [ -z "$STANDBY_NODE_ID" ]; then
   echo "ERROR: Standby node not found in cluster nodes output"
   echo "$cluster_nodes_output"
@@ -49,18 +46,7 @@ if [ -z "$STANDBY_NODE_ID" ]; then
 fi
 
 # Verify standby has zero slots
-STANDBY_SLOTS=$(echo "$cluster_nodes_output" | grep "^$STANDBY_NODE_ID" | awk '{
-  slots=0
-  for(i=9;i<=NF;i++){
-    if($i ~ /^[0-9]+-[0-9]+$/){
-      split($i,range,"-")
-      slots += (range[2]-range[1]+1)
-    } else if($i ~ /^[0-9]+$/){
-      slots += 1
-    }
-  }
-  print slots
-}')
+STANDBY_SLOTS=$(slot_count "$cluster_nodes_output" $STANDBY_NODE_ID)
 if [ "$STANDBY_SLOTS" -ne 0 ]; then
   echo "ERROR: Standby node has $STANDBY_SLOTS slots (expected 0)"
   exit 1
@@ -69,12 +55,12 @@ echo "Standby verified: $STANDBY_POD (ID: $STANDBY_NODE_ID, IP: $STANDBY_IP, Slo
 
 # Find overloaded master
 OVERLOADED_FQDN="${OVERLOADED_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
-OVERLOADED_IP=$(getent hosts $OVERLOADED_FQDN | awk '{print $1}')
+OVERLOADED_IP=$(resolve_ip $OVERLOADED_FQDN)
 if [ -z "$OVERLOADED_IP" ]; then
   echo "ERROR: Could not resolve overloaded pod $OVERLOADED_POD"
   exit 1
 fi
-OVERLOADED_MASTER_ID=$(echo "$cluster_nodes_output" | grep "$OVERLOADED_IP:6379" | grep master | awk '{print $1}')
+OVERLOADED_MASTER_ID=$(master_id "$cluster_nodes_output" $OVERLOADED_IP)
 if [ -z "$OVERLOADED_MASTER_ID" ]; then
   echo "ERROR: Overloaded master not found in cluster nodes output"
   exit 1
@@ -82,18 +68,7 @@ fi
 echo "Overloaded master: $OVERLOADED_POD (ID: $OVERLOADED_MASTER_ID)"
 
 # Calculate slots to move (half)
-TOTAL_SLOTS=$(echo "$cluster_nodes_output" | grep "^$OVERLOADED_MASTER_ID " | awk '{
-  slots=0
-  for(i=9;i<=NF;i++){
-    if($i ~ /^[0-9]+-[0-9]+$/){
-      split($i,range,"-")
-      slots += (range[2]-range[1]+1)
-    } else if($i ~ /^[0-9]+$/){
-      slots += 1
-    }
-  }
-  print slots
-}')
+TOTAL_SLOTS=$(slot_count "$cluster_nodes_output" $OVERLOADED_MASTER_ID)
 SLOTS_TO_MOVE=$((TOTAL_SLOTS / 2))
 if [ "$SLOTS_TO_MOVE" -le 0 ]; then
   echo "Nothing to move (TOTAL_SLOTS=$TOTAL_SLOTS)"
@@ -103,27 +78,20 @@ echo "Will move $SLOTS_TO_MOVE out of $TOTAL_SLOTS slots from overloaded master
 
 # Disable full coverage temporarily on all nodes
 echo "=== Disabling full coverage check on all nodes ==="
-node_ips=$(echo "$cluster_nodes_output" | awk '{print $2}' | cut -d'@' -f1 | cut -d':' -f1 | sort -u)
+node_ips=$(node_ips "$cluster_nodes_output")
 for ip in $node_ips; do
-  timeout 5 redis-cli -h $ip -p 6379 CONFIG SET cluster-require-full-coverage no || true
+  timeout 5 redis-cli -h $ip -p $REDIS_PORT CONFIG SET cluster-require-full-coverage no || true
 done
-sleep 2
 
 # Reshard using the standby node (use smaller pipeline for smoother migration)
 echo "=== Resharding $SLOTS_TO_MOVE slots ==="
-redis-cli --cluster reshard $ENTRYPOINT \
-  --cluster-from $OVERLOADED_MASTER_ID \
-  --cluster-to $STANDBY_NODE_ID \
-  --cluster-slots $SLOTS_TO_MOVE \
-  --cluster-yes \
-  --cluster-timeout 10000 \
-  --cluster-pipeline 10
+migrate_slots $OVERLOADED_MASTER_ID $STANDBY_NODE_ID $SLOTS_TO_MOVE
 
 # Re-enable full coverage
 echo "=== Re-enabling full coverage ==="
 for ip in $node_ips; do
-  timeout 5 redis-cli -h $ip -p 6379 CONFIG SET cluster-require-full-coverage yes || true
+  timeout 5 redis-cli -h $ip -p $REDIS_PORT CONFIG SET cluster-require-full-coverage yes || true
 done
-sleep 2
+wait_cluster_ok $ANY_POD_HOST
 
 echo "=== Smart Scale-Up Complete: Standby Activated ==="
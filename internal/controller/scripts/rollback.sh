@@ -0,0 +1,125 @@
+#!/bin/bash
+set -ex
+
+echo "=== Rollback of Failed Scaling Operation ==="
+ENTRYPOINT_HOST="$ENTRYPOINT_HOST"
+ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
+OPERATION="$OPERATION"
+SOURCE_POD="$SOURCE_POD"
+STANDBY_POD="$STANDBY_POD"
+SERVICE_NAME="$SERVICE_NAME"
+NAMESPACE="$NAMESPACE"
+RETURN_MOVES="$RETURN_MOVES"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
+
+echo "Rolling back failed $OPERATION (source: $SOURCE_POD, standby: $STANDBY_POD)"
+
+# ========== CLOSE OPEN SLOTS ==========
+# A migration interrupted midway leaves slots in migrating/importing state.
+# cluster fix settles them on a single owner so every slot is served again.
+echo "=== Step 1: Closing half-migrated slots ==="
+timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-yes || {
+  echo "WARNING: Cluster fix reported issues, continuing with rollback..."
+}
+
+# ========== MOVE SLOTS BACK ==========
+if [ "$OPERATION" = "reshard" ]; then
+  echo "=== Step 2: Moving slots from standby back to $SOURCE_POD ==="
+  STANDBY_FQDN="${STANDBY_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+  STANDBY_IP=$(resolve_ip $STANDBY_FQDN)
+  SOURCE_FQDN="${SOURCE_POD}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+  SOURCE_IP=$(resolve_ip $SOURCE_FQDN)
+
+  if [ -z "$STANDBY_IP" ] || [ -z "$SOURCE_IP" ]; then
+    echo "ERROR: Could not resolve standby ($STANDBY_POD) or source ($SOURCE_POD)"
+    exit 1
+  fi
+
+  cluster_nodes_output=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)
+  STANDBY_NODE_ID=$(master_id "$cluster_nodes_output" $STANDBY_IP)
+  SOURCE_NODE_ID=$(master_id "$cluster_nodes_output" $SOURCE_IP)
+
+  if [ -z "$STANDBY_NODE_ID" ] || [ -z "$SOURCE_NODE_ID" ]; then
+    echo "ERROR: Standby or source master not found in cluster nodes output"
+    echo "$cluster_nodes_output"
+    exit 1
+  fi
+
+  STANDBY_SLOTS=$(slot_count "$cluster_nodes_output" $STANDBY_NODE_ID)
+
+  if [ "$STANDBY_SLOTS" -gt 0 ]; then
+    echo "Standby owns $STANDBY_SLOTS slots, returning them to $SOURCE_POD"
+    redis-cli --cluster reshard $ENTRYPOINT \
+      --cluster-from $STANDBY_NODE_ID \
+      --cluster-to $SOURCE_NODE_ID \
+      --cluster-slots $STANDBY_SLOTS \
+      --cluster-yes \
+      --cluster-timeout $MIGRATE_TIMEOUT_MS \
+      --cluster-pipeline 10
+  else
+    echo "Standby owns no slots, nothing to move back"
+  fi
+else
+  # Each move is <pod>:<target>:<baseline>: whatever pod holds above the slot count it had when
+  # the drain started came off the drained master (through the rotated master, if any) and goes
+  # to target. The moves are ordered so the rotated master is settled last.
+  echo "=== Step 2: Returning slots moved off $SOURCE_POD ==="
+  if [ -z "$RETURN_MOVES" ]; then
+    echo "WARNING: No slot baseline was recorded, slots stay where the drain left them"
+  fi
+
+  # pod_master_id POD prints the master node ID of the pod, or nothing.
+  pod_master_id() {
+    ip=$(resolve_ip "${1}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local")
+    [ -n "$ip" ] && master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $ip
+  }
+
+  for move in $RETURN_MOVES; do
+    FROM_POD=$(echo $move | cut -d: -f1)
+    TO_POD=$(echo $move | cut -d: -f2)
+    BASELINE=$(echo $move | cut -d: -f3)
+
+    FROM_ID=$(pod_master_id $FROM_POD || true)
+    TO_ID=$(pod_master_id $TO_POD || true)
+    if [ -z "$FROM_ID" ] || [ -z "$TO_ID" ]; then
+      echo "ERROR: $FROM_POD or $TO_POD is not a master in the cluster"
+      redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes
+      exit 1
+    fi
+
+    EXCESS=$(($(slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $FROM_ID) - BASELINE))
+    if [ "$EXCESS" -gt 0 ]; then
+      echo "$FROM_POD holds $EXCESS slots above its baseline of $BASELINE, returning them to $TO_POD"
+      redis-cli --cluster reshard $ENTRYPOINT \
+        --cluster-from $FROM_ID \
+        --cluster-to $TO_ID \
+        --cluster-slots $EXCESS \
+        --cluster-yes \
+        --cluster-timeout $MIGRATE_TIMEOUT_MS \
+        --cluster-pipeline 10
+    else
+      echo "$FROM_POD holds no slots above its baseline of $BASELINE"
+    fi
+  done
+fi
+
+# ========== RESTORE FULL COVERAGE ==========
+# Both reshard and drain disable full coverage while migrating; a failure
+# midway leaves it disabled on every node.
+echo "=== Step 3: Re-enabling full coverage requirement ==="
+node_ips=$(node_ips "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)")
+for ip in $node_ips; do
+  timeout 5 redis-cli -h $ip -p $REDIS_PORT CONFIG SET cluster-require-full-coverage yes || true
+done
+
+# ========== VERIFY ==========
+echo "=== Step 4: Verifying cluster state ==="
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+if [ "$CLUSTER_STATE" != "ok" ]; then
+  echo "ERROR: Cluster state is '$CLUSTER_STATE' after rollback (expected: ok)"
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes
+  exit 1
+fi
+
+redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes
+echo "=== Rollback Complete ==="
@@ -0,0 +1,85 @@
+#!/bin/bash
+set -ex
+
+echo "=== Simple Scale-Down: Rebalance Slots Off $POD_TO_DRAIN ==="
+ENTRYPOINT="$ENTRYPOINT_WITH_PORT"
+MIGRATE_TIMEOUT_MS="${MIGRATE_TIMEOUT_MS:-10000}"
+
+echo "Pod to drain: $POD_TO_DRAIN (stays in the cluster as the first standby)"
+echo "Pods to remove from the cluster: $REMOVE_PODS"
+
+# Close any half-migrated slots left behind by earlier operations (best-effort)
+echo "=== Running cluster fix to ensure consistency ==="
+timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-yes || {
+  echo "WARNING: Cluster fix encountered issues, but continuing..."
+}
+
+CLUSTER_STATE=$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster info | grep cluster_state | cut -d: -f2 | tr -d '\r')
+if [ "$CLUSTER_STATE" != "ok" ]; then
+  echo "ERROR: Cluster state is '$CLUSTER_STATE' (expected: ok)"
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes || true
+  exit 1
+fi
+
+POD_TO_DRAIN_FQDN="${POD_TO_DRAIN}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+POD_IP=$(resolve_ip $POD_TO_DRAIN_FQDN)
+if [ -z "$POD_IP" ]; then
+  echo "ERROR: Could not resolve IP for $POD_TO_DRAIN"
+  exit 1
+fi
+
+NODE_TO_DRAIN=$(master_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $POD_IP)
+if [ -z "$NODE_TO_DRAIN" ]; then
+  echo "ERROR: $POD_TO_DRAIN ($POD_IP) is not a master in the cluster"
+  redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes || true
+  exit 1
+fi
+echo "Node to drain: $NODE_TO_DRAIN"
+
+# A weight of 0 moves every slot off the drained master. Masters without slots (the standbys) are
+# left out because --cluster-use-empty-masters is not passed.
+SLOT_COUNT=$(slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $NODE_TO_DRAIN)
+if [ "$SLOT_COUNT" -gt 0 ]; then
+  echo "=== Rebalancing $SLOT_COUNT slots across the remaining masters ==="
+  redis-cli --cluster rebalance $ENTRYPOINT \
+    --cluster-weight $NODE_TO_DRAIN=0 \
+    --cluster-timeout $MIGRATE_TIMEOUT_MS \
+    --cluster-pipeline 10
+else
+  echo "Node has no slots. Skipping rebalance."
+fi
+
+SLOT_COUNT=$(slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $NODE_TO_DRAIN)
+if [ "$SLOT_COUNT" -ne 0 ]; then
+  echo "ERROR: $POD_TO_DRAIN still owns $SLOT_COUNT slots after rebalance"
+  exit 1
+fi
+
+# Replicas are listed before their master, so del-node never has to reassign them.
+echo "=== Removing the pods the StatefulSet sheds from the cluster ==="
+for POD_NAME in $REMOVE_PODS; do
+  POD_FQDN="${POD_NAME}.${SERVICE_NAME}.${NAMESPACE}.svc.cluster.local"
+  REMOVE_IP=$(resolve_ip $POD_FQDN)
+
+  if [ -z "$REMOVE_IP" ]; then
+    echo "Pod $POD_NAME not found in DNS, skipping"
+    continue
+  fi
+
+  NODE_ID=$(node_id "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $REMOVE_IP)
+  if [ -z "$NODE_ID" ]; then
+    echo "Pod $POD_NAME ($REMOVE_IP) not found in cluster, skipping"
+    continue
+  fi
+
+  echo "Removing pod $POD_NAME (ID: $NODE_ID, IP: $REMOVE_IP)"
+  redis-cli --cluster del-node $ENTRYPOINT $NODE_ID || \
+    (sleep 5 && redis-cli --cluster del-node $ENTRYPOINT $NODE_ID)
+  sleep 2
+done
+
+echo "=== Verifying cluster after removal ==="
+redis-cli --cluster check $ENTRYPOINT
+
+echo "=== Simple Scale-Down Complete ==="
+echo "$POD_TO_DRAIN now has 0 slots and is the new standby"
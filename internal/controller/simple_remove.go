@@ -0,0 +1,101 @@
+package controller
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+//go:embed scripts/simple-remove.sh
+var simpleRemoveScript string
+
+// simpleRemoveContainer names the container of a SimpleRemove drain Job. checkDrainStatus finishes
+// a drain Job by its container rather than by the current ScaleDownStrategy, so editing the strategy
+// during a scale-down cannot skip the cleanup of a Job that was created under PreserveStandby.
+const simpleRemoveContainer = "simple-remove"
+
+// isSimpleRemoveJob reports whether the drain Job was created for a SimpleRemove scale-down.
+func isSimpleRemoveJob(job *batchv1.Job) bool {
+	containers := job.Spec.Template.Spec.Containers
+	return len(containers) > 0 && containers[0].Name == simpleRemoveContainer
+}
+
+// simpleRemovePods returns the pods a SimpleRemove scale-down removes from the Redis cluster, each
+// shard's replicas before its master. The drained master stays as the first standby, so only its
+// replicas beyond StandbyReplicaCount go, together with the last standby shard that the
+// StatefulSet deletes once Masters is decremented.
+func simpleRemovePods(cluster *appv1.RedisCluster) []string {
+	drainedIndex := (cluster.Spec.Masters - 1) * (1 + cluster.Spec.ReplicasPerMaster)
+	standbyReplicas := cluster.StandbyReplicaCount()
+
+	var pods []string
+	for i := cluster.Spec.ReplicasPerMaster; i > standbyReplicas; i-- {
+		pods = append(pods, fmt.Sprintf("%s-%d", cluster.Name, drainedIndex+i))
+	}
+	oldStandbyIndex := lastStandbyIndex(cluster)
+	for i := standbyReplicas; i >= 0; i-- {
+		pods = append(pods, fmt.Sprintf("%s-%d", cluster.Name, oldStandbyIndex+i))
+	}
+	return pods
+}
+
+// simpleRemoveJobForRedisCluster creates the drain Job of a SimpleRemove scale-down. It rebalances
+// every slot of podToDrain across the remaining masters with redis-cli --cluster rebalance and
+// removes the pods returned by simpleRemovePods with del-node, so no cleanup Job has to follow.
+// It shares the drain Job's name, deadline and JobConfig.Drain limits.
+func (r *RedisClusterReconciler) simpleRemoveJobForRedisCluster(cluster *appv1.RedisCluster, podToDrain string) *batchv1.Job {
+	anyPodHost := fmt.Sprintf("%s-0.%s.%s.svc.cluster.local",
+		cluster.Name, cluster.Name+"-headless", cluster.Namespace)
+	entrypoint := fmt.Sprintf("%s:%d", anyPodHost, redisPort(cluster))
+
+	timeout := int64(cluster.Spec.ReshardTimeoutSeconds)
+	backoff := int32(0)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name + "-drain",
+			Namespace:   cluster.Namespace,
+			Labels:      jobLabels(cluster),
+			Annotations: jobAnnotations(cluster),
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: &timeout,
+			BackoffLimit:          &backoff,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: cluster.Spec.JobPriorityClassName,
+					Containers: []corev1.Container{
+						{
+							Name:    simpleRemoveContainer,
+							Image:   redisImage(cluster),
+							Command: []string{"sh", "-c"},
+							Args:    []string{redis.Script(simpleRemoveScript, redis.ResolveIPScript, redis.NodeLookupScript, redis.SlotCountScript)},
+							Env: []corev1.EnvVar{
+								{Name: "POD_TO_DRAIN", Value: podToDrain},
+								{Name: "REMOVE_PODS", Value: strings.Join(simpleRemovePods(cluster), " ")},
+								{Name: "SERVICE_NAME", Value: cluster.Name + "-headless"},
+								{Name: "NAMESPACE", Value: cluster.Namespace},
+								{Name: "ENTRYPOINT_HOST", Value: anyPodHost},
+								{Name: "ENTRYPOINT_WITH_PORT", Value: entrypoint},
+								{Name: "MIGRATE_TIMEOUT_MS", Value: fmt.Sprintf("%d", cluster.Spec.MigrateTimeoutMillis)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	applyRedisConnection(cluster, &job.Spec.Template.Spec)
+	applyPodSecurity(cluster, &job.Spec.Template.Spec)
+	applyJobResources(cluster, &job.Spec.Template.Spec)
+	applyJobLimits(&job.Spec, cluster.Spec.JobConfig.Drain)
+	return job
+}
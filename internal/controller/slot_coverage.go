@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// maxReportedSlotRanges bounds how many unassigned ranges an error lists.
+const maxReportedSlotRanges = 5
+
+// verifySlotCoverage checks the CLUSTER NODES table in Go before scaling: every hash slot must be
+// served by a live master, and every pod of the standby pool must be a live master that serves no
+// slots. The jobs count slots themselves, but only for the nodes they touch; a gap left by an
+// earlier failure or a standby that picked up slots would otherwise only surface mid-migration.
+func (r *RedisClusterReconciler) verifySlotCoverage(ctx context.Context, cluster *appv1.RedisCluster) error {
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	if unassigned := redis.UnassignedSlots(nodes); len(unassigned) > 0 {
+		count := 0
+		var ranges []string
+		for i, rng := range unassigned {
+			count += rng.End - rng.Start + 1
+			if i < maxReportedSlotRanges {
+				ranges = append(ranges, rng.String())
+			}
+		}
+		if len(unassigned) > maxReportedSlotRanges {
+			ranges = append(ranges, "...")
+		}
+		return fmt.Errorf("%d of %d slots have no live master (%s)", count, redis.TotalSlots, strings.Join(ranges, ","))
+	}
+
+	byIP := make(map[string]redis.ClusterNode)
+	for _, node := range nodes {
+		if !node.IsFailed() {
+			byIP[node.IP] = node
+		}
+	}
+	for _, podName := range cluster.Status.StandbyPods {
+		pod := &corev1.Pod{}
+		if err := r.Get(ctx, client.ObjectKey{Name: podName, Namespace: cluster.Namespace}, pod); err != nil {
+			return fmt.Errorf("failed to get standby pod %s: %w", podName, err)
+		}
+		node, ok := byIP[pod.Status.PodIP]
+		switch {
+		case !ok:
+			return fmt.Errorf("standby pod %s (%s) is not a live cluster member", podName, pod.Status.PodIP)
+		case !node.IsMaster():
+			return fmt.Errorf("standby pod %s is not a master (flags %s)", podName, strings.Join(node.Flags, ","))
+		case node.Slots > 0:
+			return fmt.Errorf("standby pod %s serves %d slots", podName, node.Slots)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// slotClaim is a master's own view of the slots it serves, as reported by the pod it runs in.
+type slotClaim struct {
+	podName string
+	node    redis.ClusterNode
+}
+
+// slotConflict describes masters that each believe they own the same slots.
+type slotConflict struct {
+	claims []slotClaim
+	slots  int
+}
+
+// verifyNoSplitBrain checks for masters that claim the same slots after a network partition has
+// healed. Each running pod's own CLUSTER NODES entry (the "myself" line) is what that master
+// believes it serves; two masters claiming a slot in their own views means clients are split
+// between them. The claim of a master other pods flag as failed is stale and not counted. Masters
+// sharing a config epoch are reported too, since Redis can only pick a winner for a slot between
+// different epochs. Either sets the SplitBrainDetected condition and returns an error so scaling
+// is blocked. With RepairSplitBrain set, the master with the highest config epoch in each
+// conflict bumps its epoch so its claim wins through gossip.
+func (r *RedisClusterReconciler) verifyNoSplitBrain(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	views := make(map[string][]redis.ClusterNode)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || !containersReady(pod) {
+			continue
+		}
+		output, err := r.execRedisCLI(ctx, pod.Namespace, pod.Name, "cluster", "nodes")
+		if err != nil {
+			logger.Error(err, "Failed to query cluster nodes for split-brain check", "pod", pod.Name)
+			continue
+		}
+		views[pod.Name] = redis.ParseClusterNodes(output)
+	}
+
+	claims := slotClaims(views)
+	conflicts := findSlotConflicts(claims)
+	epochClashes := findEpochClashes(claims)
+
+	if len(conflicts) == 0 && len(epochClashes) == 0 {
+		return r.setSplitBrainCondition(ctx, cluster, metav1.ConditionFalse, "NoConflicts",
+			"Every slot is claimed by a single master")
+	}
+
+	var problems []string
+	for _, conflict := range conflicts {
+		problems = append(problems, fmt.Sprintf("%d slot(s) claimed by %s", conflict.slots, describeClaims(conflict.claims)))
+	}
+	for _, clash := range epochClashes {
+		problems = append(problems, fmt.Sprintf("config epoch %d shared by %s", clash[0].node.ConfigEpoch, describeClaims(clash)))
+	}
+	message := strings.Join(problems, "; ")
+	logger.Info("Split-brain detected", "problems", message)
+
+	reason := "SlotConflict"
+	if len(conflicts) == 0 {
+		reason = "ConfigEpochCollision"
+	}
+	if err := r.setSplitBrainCondition(ctx, cluster, metav1.ConditionTrue, reason, message); err != nil {
+		return err
+	}
+
+	if cluster.Spec.RepairSplitBrain {
+		for _, conflict := range conflicts {
+			r.repairSlotConflict(ctx, cluster, conflict)
+		}
+	}
+
+	return fmt.Errorf("split-brain detected: %s", message)
+}
+
+// slotClaims returns the own slot claim of every master in views, the parsed CLUSTER NODES output
+// of each pod keyed by pod name, ordered by pod name. A master that another view flags as fail has
+// been or is being failed over; its claim is stale and Redis replaces it once the node rejoins, so
+// it is left out. Slots being imported or migrated are not part of a claim.
+func slotClaims(views map[string][]redis.ClusterNode) []slotClaim {
+	failed := make(map[string]bool)
+	for _, nodes := range views {
+		for _, node := range nodes {
+			if !node.HasFlag("myself") && node.HasFlag("fail") {
+				failed[node.ID] = true
+			}
+		}
+	}
+
+	podNames := make([]string, 0, len(views))
+	for podName := range views {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+
+	var claims []slotClaim
+	for _, podName := range podNames {
+		for _, node := range views[podName] {
+			if node.HasFlag("myself") && node.IsMaster() && node.Slots > 0 && !failed[node.ID] {
+				claims = append(claims, slotClaim{podName: podName, node: node})
+			}
+		}
+	}
+	return claims
+}
+
+// findSlotConflicts groups the masters whose own slot claims overlap.
+func findSlotConflicts(claims []slotClaim) []slotConflict {
+	var owners [redisClusterSlots][]int
+	for i, claim := range claims {
+		for _, slotRange := range claim.node.SlotRanges {
+			for slot := slotRange.Start; slot <= slotRange.End && slot < redisClusterSlots; slot++ {
+				owners[slot] = append(owners[slot], i)
+			}
+		}
+	}
+
+	byClaimants := make(map[string]*slotConflict)
+	var keys []string
+	for slot := range owners {
+		if len(owners[slot]) < 2 {
+			continue
+		}
+		var ids []string
+		for _, i := range owners[slot] {
+			ids = append(ids, claims[i].node.ID)
+		}
+		key := strings.Join(ids, ",")
+		conflict, ok := byClaimants[key]
+		if !ok {
+			conflict = &slotConflict{}
+			for _, i := range owners[slot] {
+				conflict.claims = append(conflict.claims, claims[i])
+			}
+			byClaimants[key] = conflict
+			keys = append(keys, key)
+		}
+		conflict.slots++
+	}
+
+	conflicts := make([]slotConflict, 0, len(keys))
+	for _, key := range keys {
+		conflicts = append(conflicts, *byClaimants[key])
+	}
+	return conflicts
+}
+
+// findEpochClashes groups masters that serve slots under the same non-zero config epoch.
+func findEpochClashes(claims []slotClaim) [][]slotClaim {
+	byEpoch := make(map[int64][]slotClaim)
+	for _, claim := range claims {
+		if claim.node.ConfigEpoch > 0 {
+			byEpoch[claim.node.ConfigEpoch] = append(byEpoch[claim.node.ConfigEpoch], claim)
+		}
+	}
+
+	var epochs []int64
+	for epoch, group := range byEpoch {
+		if len(group) > 1 {
+			epochs = append(epochs, epoch)
+		}
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	clashes := make([][]slotClaim, 0, len(epochs))
+	for _, epoch := range epochs {
+		clashes = append(clashes, byEpoch[epoch])
+	}
+	return clashes
+}
+
+// repairSlotConflict resolves a slot conflict the way Redis Cluster does: the claim with the
+// highest config epoch wins. The winner runs CLUSTER BUMPEPOCH so its epoch is strictly the
+// highest in the cluster, and the losing masters learn of the newer claim through gossip.
+// Ties are broken by node ID so repeated repairs pick the same winner.
+func (r *RedisClusterReconciler) repairSlotConflict(ctx context.Context, cluster *appv1.RedisCluster, conflict slotConflict) {
+	logger := log.FromContext(ctx)
+
+	winner := conflict.claims[0]
+	for _, claim := range conflict.claims[1:] {
+		if claim.node.ConfigEpoch > winner.node.ConfigEpoch ||
+			(claim.node.ConfigEpoch == winner.node.ConfigEpoch && claim.node.ID < winner.node.ID) {
+			winner = claim
+		}
+	}
+
+	logger.Info("Repairing split-brain by bumping config epoch", "winner", winner.podName, "epoch", winner.node.ConfigEpoch)
+	output, err := r.execRedisCLI(ctx, cluster.Namespace, winner.podName, "cluster", "bumpepoch")
+	if err != nil {
+		logger.Error(err, "Failed to bump config epoch", "pod", winner.podName)
+		r.recordWarning(cluster, "SplitBrainRepairFailed", "CLUSTER BUMPEPOCH on %s failed: %v", winner.podName, err)
+		return
+	}
+	r.recordWarning(cluster, "SplitBrainRepaired", "%s keeps %d conflicting slot(s) claimed by %s: %s",
+		winner.podName, conflict.slots, describeClaims(conflict.claims), strings.TrimSpace(output))
+}
+
+// describeClaims formats claims as "pod (epoch N), ...".
+func describeClaims(claims []slotClaim) string {
+	parts := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		parts = append(parts, fmt.Sprintf("%s (epoch %d)", claim.podName, claim.node.ConfigEpoch))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// setSplitBrainCondition records the SplitBrainDetected condition, persisting the status and
+// emitting an event only when the condition status or reason changes.
+func (r *RedisClusterReconciler) setSplitBrainCondition(ctx context.Context, cluster *appv1.RedisCluster, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(cluster.Status.Conditions, appv1.ConditionSplitBrainDetected)
+	if current != nil && current.Status == status && current.Reason == reason {
+		return nil
+	}
+	if current == nil && status == metav1.ConditionFalse {
+		// Nothing to report and nothing to clear.
+		return nil
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionSplitBrainDetected,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if status == metav1.ConditionTrue {
+		r.recordWarning(cluster, appv1.ConditionSplitBrainDetected, "Scaling blocked: %s", message)
+	} else {
+		r.recordNormal(cluster, "SplitBrainResolved", "%s", message)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update split-brain condition: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// verifyStandbyInvariant checks that the cluster has exactly StandbyCount healthy zero-slot masters.
+// Scale-up activates one of them and scale-down turns a drained master into one, so a failed or
+// partial operation that leaves too few or too many of them would confuse standby detection.
+// Failed zero-slot masters whose address no longer belongs to a pod are ghosts of deleted pods;
+// they are forgotten as a repair. Any other violation sets the StandbyInvariantViolated
+// condition and returns an error so scaling is blocked until the topology is fixed.
+func (r *RedisClusterReconciler) verifyStandbyInvariant(ctx context.Context, cluster *appv1.RedisCluster) error {
+	logger := log.FromContext(ctx)
+
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	podIPs := make(map[string]string)
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podIPs[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	var zeroSlotMasters []string
+	var ghosts []redis.ClusterNode
+	for _, node := range nodes {
+		if !node.IsMaster() || node.Slots > 0 {
+			continue
+		}
+		podName, hasPod := podIPs[node.IP]
+		if node.IsFailed() {
+			if !hasPod {
+				ghosts = append(ghosts, node)
+			}
+			continue
+		}
+		if !hasPod {
+			podName = node.IP
+		}
+		zeroSlotMasters = append(zeroSlotMasters, podName)
+	}
+
+	if len(ghosts) > 0 {
+		r.forgetGhostNodes(ctx, cluster, podList.Items, ghosts)
+	}
+
+	expected := int(cluster.Spec.StandbyCount)
+	if len(zeroSlotMasters) == expected {
+		return r.setStandbyInvariantCondition(ctx, cluster, metav1.ConditionFalse, "StandbysPresent",
+			fmt.Sprintf("Standby masters %s are the only zero-slot masters", strings.Join(zeroSlotMasters, ", ")))
+	}
+
+	message := fmt.Sprintf("expected exactly %d zero-slot master(s), found %d", expected, len(zeroSlotMasters))
+	if len(zeroSlotMasters) > 0 {
+		message += fmt.Sprintf(" (%s)", strings.Join(zeroSlotMasters, ", "))
+	}
+	logger.Info("Standby invariant violated", "zeroSlotMasters", zeroSlotMasters)
+
+	reason := "NoStandby"
+	if len(zeroSlotMasters) > expected {
+		reason = "MultipleStandbys"
+	} else if len(zeroSlotMasters) > 0 {
+		reason = "MissingStandbys"
+	}
+	if err := r.setStandbyInvariantCondition(ctx, cluster, metav1.ConditionTrue, reason, message); err != nil {
+		return err
+	}
+	return fmt.Errorf("standby invariant violated: %s", message)
+}
+
+// findZeroSlotMasterPods runs CLUSTER NODES inside a running cluster pod and returns the names of
+// the pods backing the StandbyCount live masters with 0 slots, in ordinal order. Node IPs are mapped
+// back to pods matching the cluster's pod selector.
+func (r *RedisClusterReconciler) findZeroSlotMasterPods(ctx context.Context, cluster *appv1.RedisCluster) ([]string, error) {
+	entryPod, nodes, err := r.queryClusterView(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getLabels(cluster))); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podIPs := make(map[string]string)
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podIPs[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	var candidates []string
+	for _, node := range nodes {
+		if !node.IsMaster() || node.IsFailed() || node.Slots > 0 {
+			continue
+		}
+		podName, ok := podIPs[node.IP]
+		if !ok {
+			return nil, fmt.Errorf("zero-slot master %s at %s does not match any pod selected by %v", node.ID, node.IP, getLabels(cluster))
+		}
+		candidates = append(candidates, podName)
+	}
+	// Order by ordinal, which for names sharing the StatefulSet prefix is shortest name first.
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i]) != len(candidates[j]) {
+			return len(candidates[i]) < len(candidates[j])
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	switch expected := int(cluster.Spec.StandbyCount); {
+	case len(candidates) == 0:
+		return nil, fmt.Errorf("no master with 0 slots found (queried %s); add an empty master to act as the standby, e.g. with redis-cli --cluster add-node", entryPod)
+	case len(candidates) != expected:
+		return nil, fmt.Errorf("found %d masters with 0 slots (%s), expected %d standby(s)", len(candidates), strings.Join(candidates, ", "), expected)
+	default:
+		return candidates, nil
+	}
+}
+
+// forgetGhostNodes issues CLUSTER FORGET for each ghost node on every running pod.
+// Failures are logged and ignored; the next health check retries.
+func (r *RedisClusterReconciler) forgetGhostNodes(ctx context.Context, cluster *appv1.RedisCluster, pods []corev1.Pod, ghosts []redis.ClusterNode) {
+	logger := log.FromContext(ctx)
+
+	for _, ghost := range ghosts {
+		logger.Info("Forgetting failed zero-slot master with no backing pod", "nodeID", ghost.ID, "ip", ghost.IP)
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+			if _, err := r.execRedisCLI(ctx, pod.Namespace, pod.Name, "cluster", "forget", ghost.ID); err != nil {
+				logger.Error(err, "Failed to forget ghost node", "pod", pod.Name, "nodeID", ghost.ID)
+			}
+		}
+		r.recordWarning(cluster, "GhostNodeForgotten", "Forgot failed zero-slot master %s (%s)", ghost.ID, ghost.IP)
+	}
+}
+
+// setStandbyInvariantCondition records the StandbyInvariantViolated condition, persisting the
+// status and emitting an event only when the condition changes.
+func (r *RedisClusterReconciler) setStandbyInvariantCondition(ctx context.Context, cluster *appv1.RedisCluster, status metav1.ConditionStatus, reason, message string) error {
+	changed := meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appv1.ConditionStandbyInvariantViolated,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cluster.Generation,
+	})
+	if !changed {
+		return nil
+	}
+
+	if status == metav1.ConditionTrue {
+		r.recordWarning(cluster, reason, "Scaling blocked: %s", message)
+	}
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update standby invariant condition: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// standbyMasterIndex returns the pod index of the i-th standby master of a managed cluster.
+// Standbys follow the active masters in activation order, one shard width apart.
+func standbyMasterIndex(cluster *appv1.RedisCluster, i int32) int32 {
+	return (cluster.Spec.Masters + i) * (1 + cluster.Spec.ReplicasPerMaster)
+}
+
+// lastStandbyIndex returns the pod index of the last standby master, the one a scale-up
+// provisions and a scale-down removes.
+func lastStandbyIndex(cluster *appv1.RedisCluster) int32 {
+	return standbyMasterIndex(cluster, cluster.Spec.StandbyCount-1)
+}
+
+// standbyPoolPods returns the names of the standby master pods of a managed cluster in
+// activation order.
+func standbyPoolPods(cluster *appv1.RedisCluster) []string {
+	pods := make([]string, 0, cluster.Spec.StandbyCount)
+	for i := int32(0); i < cluster.Spec.StandbyCount; i++ {
+		pods = append(pods, fmt.Sprintf("%s-%d", cluster.Name, standbyMasterIndex(cluster, i)))
+	}
+	return pods
+}
+
+// setStandbyPool records the standby pool in the status, with its first pod as the standby the
+// next scale-up activates.
+func setStandbyPool(cluster *appv1.RedisCluster, pods []string) {
+	cluster.Status.StandbyPods = pods
+	cluster.Status.StandbyPod = ""
+	if len(pods) > 0 {
+		cluster.Status.StandbyPod = pods[0]
+	}
+}
+
+// isStandbyPod reports whether the pod is one of the standby masters.
+func isStandbyPod(cluster *appv1.RedisCluster, podName string) bool {
+	return podName == cluster.Status.StandbyPod || slices.Contains(cluster.Status.StandbyPods, podName)
+}
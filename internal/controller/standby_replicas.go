@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+	"github.com/myuser/redis-operator/internal/redis"
+)
+
+// verifyStandbyReplicas confirms that every standby master has StandbyReplicaCount replicas
+// attached and in sync. The bootstrap job adds the standby replicas with "|| true", so a failed
+// attach would otherwise go unnoticed until a scale-up activates a standby without HA.
+// If replicas are missing, it retries the attach from each standby replica pod and returns an
+// error so the caller requeues and verifies again.
+func (r *RedisClusterReconciler) verifyStandbyReplicas(ctx context.Context, cluster *appv1.RedisCluster) error {
+	if cluster.StandbyReplicaCount() == 0 {
+		return nil
+	}
+
+	var firstErr error
+	for i := int32(0); i < cluster.Spec.StandbyCount; i++ {
+		if err := r.verifyStandbyShardReplicas(ctx, cluster, standbyMasterIndex(cluster, i)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// verifyStandbyShardReplicas verifies and repairs the replicas of the standby master at standbyIndex.
+func (r *RedisClusterReconciler) verifyStandbyShardReplicas(ctx context.Context, cluster *appv1.RedisCluster, standbyIndex int32) error {
+	logger := log.FromContext(ctx)
+
+	expected := int(cluster.StandbyReplicaCount())
+	standbyPodName := fmt.Sprintf("%s-%d", cluster.Name, standbyIndex)
+
+	infoOutput, err := r.execRedisCLI(ctx, cluster.Namespace, standbyPodName, "info", "replication")
+	if err != nil {
+		return fmt.Errorf("failed to query replication info on standby %s: %w", standbyPodName, err)
+	}
+
+	online := 0
+	for _, replica := range redis.ParseReplicas(redis.ParseInfo(infoOutput)) {
+		if replica.State == "online" {
+			online++
+		}
+	}
+
+	if online >= expected {
+		logger.Info("Standby replicas verified", "standbyPod", standbyPodName, "replicas", online)
+		return nil
+	}
+
+	logger.Info("Standby master is missing replicas, retrying attach",
+		"standbyPod", standbyPodName,
+		"online", online,
+		"expected", expected)
+
+	standbyPod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Name: standbyPodName, Namespace: cluster.Namespace}, standbyPod); err != nil {
+		return fmt.Errorf("failed to get standby pod %s: %w", standbyPodName, err)
+	}
+
+	standbyID, err := r.execRedisCLI(ctx, cluster.Namespace, standbyPodName, "cluster", "myid")
+	if err != nil {
+		return fmt.Errorf("failed to get node ID of standby %s: %w", standbyPodName, err)
+	}
+	standbyID = strings.TrimSpace(standbyID)
+
+	for i := int32(1); i <= cluster.StandbyReplicaCount(); i++ {
+		replicaPodName := fmt.Sprintf("%s-%d", cluster.Name, standbyIndex+i)
+		if err := r.attachReplica(ctx, cluster, replicaPodName, standbyPod.Status.PodIP, standbyID); err != nil {
+			logger.Error(err, "Failed to attach standby replica", "replicaPod", replicaPodName)
+		}
+	}
+
+	return fmt.Errorf("standby %s has %d/%d replicas in sync", standbyPodName, online, expected)
+}
+
+// attachReplica makes the given pod a replica of the master with masterID.
+// If the pod already replicates from masterIP it is left alone; otherwise it is introduced
+// to the master with CLUSTER MEET (a no-op if already known) and then CLUSTER REPLICATE is issued.
+func (r *RedisClusterReconciler) attachReplica(ctx context.Context, cluster *appv1.RedisCluster, replicaPodName, masterIP, masterID string) error {
+	infoOutput, err := r.execRedisCLI(ctx, cluster.Namespace, replicaPodName, "info", "replication")
+	if err != nil {
+		return err
+	}
+
+	info := redis.ParseInfo(infoOutput)
+	if info["role"] == "slave" && info["master_host"] == masterIP {
+		// Already attached, just still syncing.
+		return nil
+	}
+
+	if _, err := r.execRedisCLI(ctx, cluster.Namespace, replicaPodName, "cluster", "meet", masterIP, fmt.Sprintf("%d", redisPort(cluster))); err != nil {
+		return err
+	}
+
+	output, err := r.execRedisCLI(ctx, cluster.Namespace, replicaPodName, "cluster", "replicate", masterID)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(output), "OK") {
+		return fmt.Errorf("CLUSTER REPLICATE on %s returned: %s", replicaPodName, strings.TrimSpace(output))
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// standbyMoved re-detects the standby and checks it against the ScalingStandbySnapshot taken when
+// the scaling operation started. It returns why the standby no longer matches, e.g. because a
+// failover promoted one of its replicas, or "" if it still does. Operations started without a
+// snapshot are not checked.
+func (r *RedisClusterReconciler) standbyMoved(ctx context.Context, cluster *appv1.RedisCluster) (string, error) {
+	snapshot := cluster.Status.ScalingStandbySnapshot
+	if snapshot == "" {
+		return "", nil
+	}
+
+	detected := cluster.DeepCopy()
+	if err := r.detectAndSetStandbyPod(ctx, detected); err != nil {
+		return "", fmt.Errorf("cannot detect standby pod: %w", err)
+	}
+	if detected.Status.StandbyPod != snapshot {
+		return fmt.Sprintf("standby moved from %s to %s", snapshot, detected.Status.StandbyPod), nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Name: snapshot, Namespace: cluster.Namespace}, pod); err != nil {
+		return "", fmt.Errorf("failed to get standby pod %s: %w", snapshot, err)
+	}
+	nodes, err := r.queryClusterNodes(ctx, cluster)
+	if err != nil {
+		return "", err
+	}
+	for _, node := range nodes {
+		if node.IP != pod.Status.PodIP || node.IsFailed() {
+			continue
+		}
+		if !node.IsMaster() {
+			return fmt.Sprintf("standby %s is now a replica", snapshot), nil
+		}
+		if node.Slots > 0 {
+			return fmt.Sprintf("standby %s now owns %d slots", snapshot, node.Slots), nil
+		}
+		return "", nil
+	}
+	return fmt.Sprintf("standby %s is no longer a cluster member", snapshot), nil
+}
+
+// abortForMovedStandby ends the scale-up or scale-down before its reshard or drain Job is created
+// and stores the re-detected standby, so the next evaluation plans against the live topology.
+// Nothing has been moved yet, so the attempt does not count as a failed scale.
+func (r *RedisClusterReconciler) abortForMovedStandby(ctx context.Context, cluster *appv1.RedisCluster, moved string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Standby changed during scaling operation, aborting", "reason", moved)
+	r.recordWarning(cluster, "StandbyChanged", "Aborted scaling operation before moving slots: %s", moved)
+
+	endOperation(cluster)
+	cluster.Status.IsResharding = false
+	cluster.Status.OverloadedPod = ""
+	cluster.Status.IsDraining = false
+	cluster.Status.PodToDrain = ""
+	cluster.Status.DrainDestPod1 = ""
+	cluster.Status.DrainDestPod2 = ""
+	cluster.Status.DrainRotatePod = ""
+	if err := r.detectAndSetStandbyPod(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to re-detect standby pod, retrying on the next health check")
+	}
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error(err, "Failed to update status after aborting scaling operation")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+}
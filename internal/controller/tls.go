@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appv1 "github.com/myuser/redis-operator/api/v1"
+)
+
+const (
+	// defaultRedisPort is the port Redis listens on when Port is not set.
+	defaultRedisPort = 6379
+
+	// tlsVolumeName and tlsMountPath locate the TLS certificate Secret in Redis and Job pods.
+	tlsVolumeName = "tls"
+	tlsMountPath  = "/tls"
+)
+
+// redisCLIWrapperScript is prepended to Job scripts when TLS is enabled. It puts a redis-cli
+// wrapper carrying REDIS_CLI_ARGS first on PATH, so every redis-cli call in the script, including
+// those run through timeout, connects over TLS without the script having to know about it.
+const redisCLIWrapperScript = `
+mkdir -p /tmp/redis-cli-tls
+printf '#!/bin/sh\nexec %s %s "$@"\n' "$(command -v redis-cli)" "$REDIS_CLI_ARGS" > /tmp/redis-cli-tls/redis-cli
+chmod +x /tmp/redis-cli-tls/redis-cli
+export PATH="/tmp/redis-cli-tls:$PATH"
+`
+
+// tlsEnabled reports whether intra-cluster connections use TLS.
+func tlsEnabled(cluster *appv1.RedisCluster) bool {
+	return cluster.Spec.TLS != nil && cluster.Spec.TLS.Enabled
+}
+
+// redisPort returns the port Redis listens on: the TLS port when TLS is enabled, Port otherwise.
+func redisPort(cluster *appv1.RedisCluster) int32 {
+	if tlsEnabled(cluster) && cluster.Spec.TLS.Port != 0 {
+		return cluster.Spec.TLS.Port
+	}
+	if cluster.Spec.Port != 0 {
+		return cluster.Spec.Port
+	}
+	return defaultRedisPort
+}
+
+// redisCLIArgs returns the connection arguments redis-cli needs to reach a Redis pod of the cluster.
+func redisCLIArgs(cluster *appv1.RedisCluster) string {
+	args := fmt.Sprintf("-p %d", redisPort(cluster))
+	if tlsEnabled(cluster) {
+		args += fmt.Sprintf(" --tls --cert %[1]s/tls.crt --key %[1]s/tls.key --cacert %[1]s/ca.crt", tlsMountPath)
+	}
+	return args
+}
+
+// redisConnectionEnv returns the environment describing how to connect to Redis. REDIS_PORT and
+// IP_FAMILY are used by Job scripts to build node addresses, and REDIS_CLI_ARGS by execRedisCLI
+// and the wrapper.
+func redisConnectionEnv(cluster *appv1.RedisCluster) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "REDIS_PORT", Value: fmt.Sprintf("%d", redisPort(cluster))},
+		{Name: "IP_FAMILY", Value: string(cluster.Spec.IPFamily)},
+		{Name: "REDIS_CLI_ARGS", Value: redisCLIArgs(cluster)},
+	}
+}
+
+// tlsVolume returns the volume holding the TLS certificate Secret.
+func tlsVolume(cluster *appv1.RedisCluster) corev1.Volume {
+	return corev1.Volume{
+		Name: tlsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: cluster.Spec.TLS.CertSecretRef},
+		},
+	}
+}
+
+// applyRedisConnection configures a Job pod to reach the cluster's Redis pods: every container
+// gets the connection environment and, when TLS is enabled, the certificate mount and the
+// redis-cli wrapper in front of its sh -c script.
+func applyRedisConnection(cluster *appv1.RedisCluster, podSpec *corev1.PodSpec) {
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		container.Env = append(container.Env, redisConnectionEnv(cluster)...)
+		if !tlsEnabled(cluster) {
+			continue
+		}
+		container.VolumeMounts = append(container.VolumeMounts,
+			corev1.VolumeMount{Name: tlsVolumeName, MountPath: tlsMountPath, ReadOnly: true})
+		if len(container.Args) > 0 {
+			container.Args[0] = redisCLIWrapperScript + container.Args[0]
+		}
+	}
+	if tlsEnabled(cluster) {
+		podSpec.Volumes = append(podSpec.Volumes, tlsVolume(cluster))
+	}
+}
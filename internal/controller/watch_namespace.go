@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// WatchNamespaceEnv is the environment variable holding the comma-separated namespaces the operator
+// watches. When it is empty, RedisClusters are watched in every namespace.
+const WatchNamespaceEnv = "WATCH_NAMESPACE"
+
+// ParseWatchNamespaces splits a comma-separated namespace list, dropping blanks and duplicates.
+func ParseWatchNamespaces(value string) []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	for _, namespace := range strings.Split(value, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces
+}
+
+// WatchNamespaceCacheOptions returns the manager cache options restricting every informer, and so
+// every read of the reconciler's client, to the given namespaces. Reads of objects in any other
+// namespace fail instead of silently widening the watch. No namespaces means cluster-wide.
+func WatchNamespaceCacheOptions(namespaces []string) cache.Options {
+	if len(namespaces) == 0 {
+		return cache.Options{}
+	}
+	defaults := make(map[string]cache.Config, len(namespaces))
+	for _, namespace := range namespaces {
+		defaults[namespace] = cache.Config{}
+	}
+	return cache.Options{DefaultNamespaces: defaults}
+}
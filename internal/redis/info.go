@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ReplicaInfo describes a replica as reported in the "# Replication" section of INFO on its master.
+type ReplicaInfo struct {
+	IP     string
+	Port   int
+	State  string
+	Offset int64
+	Lag    int64
+}
+
+// ParseInfo parses the output of the Redis INFO command into a key/value map.
+// Section headers and blank lines are skipped.
+func ParseInfo(raw string) map[string]string {
+	info := make(map[string]string)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		info[key] = value
+	}
+	return info
+}
+
+// ParseReplicas extracts the replicas attached to a master from parsed INFO replication output.
+// Each replica is reported as "slaveN:ip=...,port=...,state=...,offset=...,lag=...".
+func ParseReplicas(info map[string]string) []ReplicaInfo {
+	count, _ := strconv.Atoi(info["connected_slaves"])
+
+	var replicas []ReplicaInfo
+	for i := 0; i < count; i++ {
+		raw, ok := info["slave"+strconv.Itoa(i)]
+		if !ok {
+			continue
+		}
+
+		var replica ReplicaInfo
+		for _, field := range strings.Split(raw, ",") {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "ip":
+				replica.IP = value
+			case "port":
+				replica.Port, _ = strconv.Atoi(value)
+			case "state":
+				replica.State = value
+			case "offset":
+				replica.Offset, _ = strconv.ParseInt(value, 10, 64)
+			case "lag":
+				replica.Lag, _ = strconv.ParseInt(value, 10, 64)
+			}
+		}
+		replicas = append(replicas, replica)
+	}
+
+	return replicas
+}
@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TotalSlots is the number of hash slots in a Redis cluster.
+const TotalSlots = 16384
+
+// ClusterNode describes a single line of CLUSTER NODES output.
+type ClusterNode struct {
+	ID          string
+	IP          string
+	Port        int
+	Flags       []string
+	MasterID    string
+	ConfigEpoch int64
+	LinkState   string
+	Slots       int
+	SlotRanges  []SlotRange
+}
+
+// SlotRange is an inclusive range of hash slots.
+type SlotRange struct {
+	Start int
+	End   int
+}
+
+// HasFlag reports whether the node carries the given flag (e.g. "master", "myself", "fail").
+func (n ClusterNode) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMaster reports whether the node is a master.
+func (n ClusterNode) IsMaster() bool {
+	return n.HasFlag("master")
+}
+
+// IsReplica reports whether the node is a replica. CLUSTER NODES still flags replicas as "slave".
+func (n ClusterNode) IsReplica() bool {
+	return n.HasFlag("slave") || n.HasFlag("replica")
+}
+
+// IsFailed reports whether the node is failed, unreachable, or has no address.
+func (n ClusterNode) IsFailed() bool {
+	return n.HasFlag("fail") || n.HasFlag("noaddr") || n.LinkState == "disconnected"
+}
+
+// String formats the range as CLUSTER NODES prints it: "5" for a single slot, "0-5460" otherwise.
+func (r SlotRange) String() string {
+	if r.Start == r.End {
+		return strconv.Itoa(r.Start)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// ParseClusterNodes parses the output of CLUSTER NODES.
+// Each line has the form "<id> <ip:port@cport> <flags> <master> <ping> <pong> <epoch> <link> <slot>...".
+// Lines with fewer than eight fields are skipped. Slots being imported or migrated ("[...]") are not counted.
+func ParseClusterNodes(raw string) []ClusterNode {
+	var nodes []ClusterNode
+	for _, line := range strings.Split(raw, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+
+		node := ClusterNode{
+			ID:        fields[0],
+			Flags:     strings.Split(fields[2], ","),
+			LinkState: fields[7],
+		}
+		if fields[3] != "-" {
+			node.MasterID = fields[3]
+		}
+		node.ConfigEpoch, _ = strconv.ParseInt(fields[6], 10, 64)
+
+		addr, _, _ := strings.Cut(fields[1], "@")
+		if idx := strings.LastIndex(addr, ":"); idx >= 0 {
+			node.IP = addr[:idx]
+			node.Port, _ = strconv.Atoi(addr[idx+1:])
+		}
+
+		for _, slot := range fields[8:] {
+			if strings.HasPrefix(slot, "[") {
+				continue
+			}
+			start, end, isRange := strings.Cut(slot, "-")
+			if !isRange {
+				if n, err := strconv.Atoi(start); err == nil {
+					node.Slots++
+					node.SlotRanges = append(node.SlotRanges, SlotRange{Start: n, End: n})
+				}
+				continue
+			}
+			from, err1 := strconv.Atoi(start)
+			to, err2 := strconv.Atoi(end)
+			if err1 == nil && err2 == nil && to >= from {
+				node.Slots += to - from + 1
+				node.SlotRanges = append(node.SlotRanges, SlotRange{Start: from, End: to})
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// ServingMasters returns the number of live masters in nodes that own at least one slot. Standbys,
+// which are masters without slots, and failed masters are not counted.
+func ServingMasters(nodes []ClusterNode) int {
+	count := 0
+	for _, node := range nodes {
+		if node.IsMaster() && !node.IsFailed() && node.Slots > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// UnassignedSlots returns the ranges of slots not served by a live master in nodes: slots no master
+// lists, and slots listed by a master that is failed. An empty result means every one of the
+// TotalSlots slots has a live owner.
+func UnassignedSlots(nodes []ClusterNode) []SlotRange {
+	var served [TotalSlots]bool
+	for _, node := range nodes {
+		if !node.IsMaster() || node.IsFailed() {
+			continue
+		}
+		for _, r := range node.SlotRanges {
+			for slot := max(r.Start, 0); slot <= r.End && slot < TotalSlots; slot++ {
+				served[slot] = true
+			}
+		}
+	}
+
+	var unassigned []SlotRange
+	for slot := 0; slot < TotalSlots; slot++ {
+		if served[slot] {
+			continue
+		}
+		if n := len(unassigned); n > 0 && unassigned[n-1].End == slot-1 {
+			unassigned[n-1].End = slot
+			continue
+		}
+		unassigned = append(unassigned, SlotRange{Start: slot, End: slot})
+	}
+	return unassigned
+}
@@ -0,0 +1,235 @@
+package redis
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return string(raw)
+}
+
+func nodeByID(t *testing.T, nodes []ClusterNode, id string) ClusterNode {
+	t.Helper()
+	for _, node := range nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	t.Fatalf("node %s not parsed", id)
+	return ClusterNode{}
+}
+
+func TestParseClusterNodes(t *testing.T) {
+	tests := []struct {
+		fixture string
+		nodes   int
+		id      string
+		want    ClusterNode
+	}{
+		{
+			fixture: "cluster-nodes-standby.txt",
+			nodes:   8,
+			id:      "07c37dfeb235213a872192d90877d0cd55635b91",
+			want: ClusterNode{
+				ID:          "07c37dfeb235213a872192d90877d0cd55635b91",
+				IP:          "10.244.1.12",
+				Port:        6379,
+				Flags:       []string{"myself", "master"},
+				ConfigEpoch: 1,
+				LinkState:   "connected",
+				Slots:       5461,
+				SlotRanges:  []SlotRange{{Start: 0, End: 5460}},
+			},
+		},
+		{
+			fixture: "cluster-nodes-standby.txt",
+			nodes:   8,
+			id:      "6ec23923021cf3ffec47632106199cb7f496ce01",
+			want: ClusterNode{
+				ID:          "6ec23923021cf3ffec47632106199cb7f496ce01",
+				IP:          "10.244.1.13",
+				Port:        6379,
+				Flags:       []string{"slave"},
+				MasterID:    "07c37dfeb235213a872192d90877d0cd55635b91",
+				ConfigEpoch: 1,
+				LinkState:   "connected",
+			},
+		},
+		{
+			// The standby: a master without slots.
+			fixture: "cluster-nodes-standby.txt",
+			nodes:   8,
+			id:      "a2cd0b4f8e4d1f1c3b0b6c2c4e2a8b9f0e1d2c3b",
+			want: ClusterNode{
+				ID:        "a2cd0b4f8e4d1f1c3b0b6c2c4e2a8b9f0e1d2c3b",
+				IP:        "10.244.1.14",
+				Port:      6379,
+				Flags:     []string{"master"},
+				LinkState: "connected",
+			},
+		},
+		{
+			// Redis 7 appends the hostname to the address; the migrating entry is not a slot of its own.
+			fixture: "cluster-nodes-migrating.txt",
+			nodes:   4,
+			id:      "07c37dfeb235213a872192d90877d0cd55635b91",
+			want: ClusterNode{
+				ID:          "07c37dfeb235213a872192d90877d0cd55635b91",
+				IP:          "10.244.1.12",
+				Port:        6379,
+				Flags:       []string{"myself", "master"},
+				ConfigEpoch: 7,
+				LinkState:   "connected",
+				Slots:       5461,
+				SlotRanges:  []SlotRange{{Start: 0, End: 5460}},
+			},
+		},
+		{
+			// The importing side owns nothing until the migration completes.
+			fixture: "cluster-nodes-migrating.txt",
+			nodes:   4,
+			id:      "a2cd0b4f8e4d1f1c3b0b6c2c4e2a8b9f0e1d2c3b",
+			want: ClusterNode{
+				ID:          "a2cd0b4f8e4d1f1c3b0b6c2c4e2a8b9f0e1d2c3b",
+				IP:          "10.244.1.14",
+				Port:        6379,
+				Flags:       []string{"master"},
+				ConfigEpoch: 8,
+				LinkState:   "connected",
+			},
+		},
+		{
+			fixture: "cluster-nodes-failed.txt",
+			nodes:   5,
+			id:      "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca",
+			want: ClusterNode{
+				ID:          "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca",
+				IP:          "10.244.2.7",
+				Port:        6379,
+				Flags:       []string{"master", "fail"},
+				ConfigEpoch: 2,
+				LinkState:   "disconnected",
+				Slots:       5462,
+				SlotRanges:  []SlotRange{{Start: 5461, End: 10922}},
+			},
+		},
+		{
+			// A forgotten-but-gossiped node has no address.
+			fixture: "cluster-nodes-failed.txt",
+			nodes:   5,
+			id:      "5d3b1a9c7e8f6a4b2c0d9e8f7a6b5c4d3e2f1a0b",
+			want: ClusterNode{
+				ID:          "5d3b1a9c7e8f6a4b2c0d9e8f7a6b5c4d3e2f1a0b",
+				Flags:       []string{"master", "noaddr"},
+				ConfigEpoch: 4,
+				LinkState:   "disconnected",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture+"/"+tt.id[:8], func(t *testing.T) {
+			nodes := ParseClusterNodes(readFixture(t, tt.fixture))
+			if len(nodes) != tt.nodes {
+				t.Fatalf("parsed %d nodes, want %d", len(nodes), tt.nodes)
+			}
+			if got := nodeByID(t, nodes, tt.id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsed %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseClusterNodesSkipsMalformedLines(t *testing.T) {
+	raw := "\n" +
+		"not a cluster nodes line\n" +
+		"07c37dfeb235213a872192d90877d0cd55635b91 10.244.1.12:6379@16379 myself,master - 0 0 1 connected 0-16383 junk 99-1\n"
+	nodes := ParseClusterNodes(raw)
+	if len(nodes) != 1 {
+		t.Fatalf("parsed %d nodes, want 1", len(nodes))
+	}
+	if nodes[0].Slots != TotalSlots {
+		t.Errorf("counted %d slots, want %d (malformed and reversed ranges ignored)", nodes[0].Slots, TotalSlots)
+	}
+}
+
+func TestClusterNodeRoles(t *testing.T) {
+	nodes := ParseClusterNodes(readFixture(t, "cluster-nodes-failed.txt"))
+
+	tests := []struct {
+		id      string
+		master  bool
+		replica bool
+		failed  bool
+	}{
+		{id: "07c37dfeb235213a872192d90877d0cd55635b91", master: true},
+		{id: "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", master: true, failed: true},
+		{id: "5d3b1a9c7e8f6a4b2c0d9e8f7a6b5c4d3e2f1a0b", master: true, failed: true},
+		// fail? is only a suspicion (PFAIL) and does not count as failed.
+		{id: "6ec23923021cf3ffec47632106199cb7f496ce01", replica: true},
+	}
+	for _, tt := range tests {
+		node := nodeByID(t, nodes, tt.id)
+		if node.IsMaster() != tt.master || node.IsReplica() != tt.replica || node.IsFailed() != tt.failed {
+			t.Errorf("node %s: master=%v replica=%v failed=%v, want %v %v %v", tt.id[:8],
+				node.IsMaster(), node.IsReplica(), node.IsFailed(), tt.master, tt.replica, tt.failed)
+		}
+	}
+}
+
+func TestUnassignedSlots(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []SlotRange
+	}{
+		{fixture: "cluster-nodes-standby.txt"},
+		// The migrating slot still belongs to its source until the migration completes.
+		{fixture: "cluster-nodes-migrating.txt"},
+		// Slots of the failed master and the gap left by a lost slot are both unserved.
+		{fixture: "cluster-nodes-failed.txt", want: []SlotRange{{Start: 5461, End: 10922}, {Start: 16001, End: 16001}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got := UnassignedSlots(ParseClusterNodes(readFixture(t, tt.fixture)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unassigned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServingMasters(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    int
+	}{
+		// The standby owns no slots.
+		{fixture: "cluster-nodes-standby.txt", want: 3},
+		// The failed master and the noaddr entry are not counted.
+		{fixture: "cluster-nodes-failed.txt", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			if got := ServingMasters(ParseClusterNodes(readFixture(t, tt.fixture))); got != tt.want {
+				t.Errorf("serving masters %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlotRangeString(t *testing.T) {
+	if got := (SlotRange{Start: 5, End: 5}).String(); got != "5" {
+		t.Errorf("single slot formatted as %q", got)
+	}
+	if got := (SlotRange{Start: 0, End: 5460}).String(); got != "0-5460" {
+		t.Errorf("range formatted as %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+package redis
+
+import "strings"
+
+// The snippets below are the shell helpers shared by the operator's Job scripts. Each one only
+// defines functions, so Script can place them in front of a script without changing what the
+// script runs. They expect REDIS_PORT in the environment; ResolveIPScript also reads IP_FAMILY,
+// PollScript needs POLL_TIMEOUT_SECONDS and MigrateScript the variables listed on it.
+
+// ResolveIPScript defines resolve_ip. On dual-stack clusters a name resolves to both an IPv4 and
+// an IPv6 address, and only the one of the family Redis reports in CLUSTER NODES matches the
+// node's entry, so the address of IP_FAMILY (IPv4 or IPv6, default IPv4) is preferred.
+const ResolveIPScript = `
+# resolve_ip FQDN prints an address FQDN resolves to, preferring the IP_FAMILY family and falling
+# back to any address, or nothing when it does not resolve.
+resolve_ip() {
+  { getent ahosts "$1" || getent hosts "$1"; } 2>/dev/null | awk -v family="${IP_FAMILY:-IPv4}" '
+    { ipv6 = index($1, ":") > 0 }
+    (family == "IPv6") == ipv6 { print $1; found = 1; exit }
+    first == "" { first = $1 }
+    END { if (!found && first != "") print first }'
+}
+`
+
+// NodeLookupScript defines node_id, master_id and node_ips, which read the output of CLUSTER NODES.
+// Addresses are compared as whole fields, so 10.0.0.1 never matches the entry of 10.0.0.11.
+const NodeLookupScript = `
+# node_id NODES IP prints the ID of the node at IP:REDIS_PORT in the CLUSTER NODES output NODES.
+node_id() {
+  echo "$1" | awk -v addr="$2:$REDIS_PORT" '{ split($2, a, "@") } a[1] == addr { print $1; exit }'
+}
+
+# master_id NODES IP is node_id restricted to nodes flagged master.
+master_id() {
+  echo "$1" | awk -v addr="$2:$REDIS_PORT" '{ split($2, a, "@") } a[1] == addr && $3 ~ /(^|,)master(,|$)/ { print $1; exit }'
+}
+
+# node_ips NODES prints the distinct IPs listed in the CLUSTER NODES output NODES.
+node_ips() {
+  echo "$1" | awk '{ split($2, a, "@"); sub(/:[0-9]+$/, "", a[1]); if (a[1] != "") print a[1] }' | sort -u
+}
+`
+
+// SlotCountScript defines slot_count. The first eight fields of a CLUSTER NODES line are fixed, so
+// slots start at the ninth; entries of slots being imported or migrated ("[...]") are not counted,
+// matching ParseClusterNodes.
+const SlotCountScript = `
+# slot_count NODES ID prints the number of hash slots node ID owns in the CLUSTER NODES output
+# NODES, or 0 when ID is not listed.
+slot_count() {
+  echo "$1" | awk -v id="$2" '$1 == id {
+    for (i = 9; i <= NF; i++) {
+      if ($i ~ /^[0-9]+-[0-9]+$/) {
+        split($i, range, "-")
+        slots += range[2] - range[1] + 1
+      } else if ($i ~ /^[0-9]+$/) {
+        slots++
+      }
+    }
+  }
+  END { print slots + 0 }'
+}
+`
+
+// PollScript defines wait_cluster_ok and wait_node_known. Instead of sleeping a fixed time for
+// cluster changes to propagate, scripts wait with these helpers, which poll once a second for at
+// most POLL_TIMEOUT_SECONDS attempts. On timeout they print CLUSTER NODES as seen from the polled
+// host and exit non-zero, so the Job fails with a diagnostic.
+const PollScript = `
+# poll_timeout HOST WHAT reports a timed-out wait with the cluster view of HOST and exits.
+poll_timeout() {
+  echo "ERROR: timed out after ${POLL_TIMEOUT_SECONDS}s waiting for $2"
+  echo "=== cluster nodes as seen by $1 ==="
+  redis-cli -h "$1" -p $REDIS_PORT cluster nodes || true
+  exit 1
+}
+
+# wait_cluster_ok HOST waits until HOST reports cluster_state:ok.
+wait_cluster_ok() {
+  attempt=0
+  while [ "$attempt" -lt "$POLL_TIMEOUT_SECONDS" ]; do
+    state=$(redis-cli -h "$1" -p $REDIS_PORT cluster info 2>/dev/null | grep cluster_state | cut -d: -f2 | tr -d '\r')
+    if [ "$state" = "ok" ]; then
+      return 0
+    fi
+    attempt=$((attempt + 1))
+    sleep 1
+  done
+  poll_timeout "$1" "cluster_state ok (last: ${state:-unreachable})"
+}
+
+# wait_node_known HOST IP waits until CLUSTER NODES on HOST lists IP:REDIS_PORT as a connected node.
+wait_node_known() {
+  attempt=0
+  while [ "$attempt" -lt "$POLL_TIMEOUT_SECONDS" ]; do
+    if redis-cli -h "$1" -p $REDIS_PORT cluster nodes 2>/dev/null | grep " $2:$REDIS_PORT@" | grep -qv -e handshake -e noaddr; then
+      return 0
+    fi
+    attempt=$((attempt + 1))
+    sleep 1
+  done
+  poll_timeout "$1" "node $2:$REDIS_PORT to join"
+}
+`
+
+// MigrateScript defines count_slots and migrate_slots, the retrying reshard of the drain and
+// scale-up Jobs. It calls slot_count and wait_cluster_ok, so it must be composed with
+// SlotCountScript and PollScript, and it reads ENTRYPOINT (the host:port passed to redis-cli
+// --cluster), ENTRYPOINT_HOST, MAX_ATTEMPTS and MIGRATE_TIMEOUT_MS.
+const MigrateScript = `
+# count_slots ID prints the number of slots owned by node ID as seen from ENTRYPOINT_HOST.
+count_slots() {
+  slot_count "$(redis-cli -h $ENTRYPOINT_HOST -p $REDIS_PORT cluster nodes)" $1
+}
+
+# migrate_slots SOURCE_ID TARGET_ID COUNT moves COUNT slots from SOURCE_ID to TARGET_ID, retrying up
+# to MAX_ATTEMPTS times. --cluster reshard is not idempotent, so after a failure the open slots are
+# closed and only the slots that did not make it across are requested on the next attempt.
+migrate_slots() {
+  source_id=$1
+  target_id=$2
+  count=$3
+  start_slots=$(count_slots $source_id)
+  attempt=1
+  remaining=$count
+
+  while [ "$remaining" -gt 0 ]; do
+    echo "Migration attempt $attempt/$MAX_ATTEMPTS: moving $remaining slots from $source_id to $target_id"
+    if redis-cli --cluster reshard $ENTRYPOINT \
+      --cluster-from $source_id \
+      --cluster-to $target_id \
+      --cluster-slots $remaining \
+      --cluster-yes \
+      --cluster-timeout $MIGRATE_TIMEOUT_MS \
+      --cluster-pipeline 10; then
+      return 0
+    fi
+
+    echo "WARNING: reshard exited non-zero, closing open slots before re-checking"
+    timeout 300 redis-cli --cluster fix $ENTRYPOINT --cluster-yes || true
+    wait_cluster_ok $ENTRYPOINT_HOST
+
+    moved=$((start_slots - $(count_slots $source_id)))
+    remaining=$((count - moved))
+    echo "Moved $moved/$count slots so far, $remaining remaining"
+
+    if [ "$attempt" -ge "$MAX_ATTEMPTS" ] && [ "$remaining" -gt 0 ]; then
+      echo "ERROR: $remaining slots still on $source_id after $MAX_ATTEMPTS attempts"
+      return 1
+    fi
+    attempt=$((attempt + 1))
+  done
+}
+`
+
+// Script returns body preceded by the given helper snippets.
+func Script(body string, snippets ...string) string {
+	return strings.Join(snippets, "") + body
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	cachev1 "github.com/myuser/redis-operator/api/v1"
+)
+
+// log is for logging in this package.
+var redisclusterlog = logf.Log.WithName("rediscluster-resource")
+
+// SetupRedisClusterWebhookWithManager registers the defaulting and validating webhooks for
+// RedisCluster, and the validating webhook of its scale subresource, in the manager.
+func SetupRedisClusterWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(scaleValidationPath, &webhook.Admission{
+		Handler: &RedisClusterScaleValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
+	return ctrl.NewWebhookManagedBy(mgr).For(&cachev1.RedisCluster{}).
+		WithDefaulter(&RedisClusterCustomDefaulter{}).
+		WithValidator(&RedisClusterCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-cache-example-com-v1-rediscluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=cache.example.com,resources=redisclusters,verbs=create;update,versions=v1,name=mrediscluster-v1.kb.io,admissionReviewVersions=v1
+
+// RedisClusterCustomDefaulter fills in the defaults of a RedisCluster at admission time, so they are
+// stored with the object and show in kubectl get -o yaml and GitOps diffs before the first
+// reconcile. It applies RedisCluster.SetDefaults, which Reconcile still applies in memory too, so
+// clusters created while the webhook is not installed behave the same. Once stored, a default
+// derived from another field, such as tls.port from port, no longer follows later edits of it.
+type RedisClusterCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &RedisClusterCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *RedisClusterCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rediscluster, ok := obj.(*cachev1.RedisCluster)
+	if !ok {
+		return fmt.Errorf("expected a RedisCluster object but got %T", obj)
+	}
+	redisclusterlog.Info("Defaulting for RedisCluster", "name", rediscluster.GetName())
+
+	rediscluster.SetDefaults()
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-cache-example-com-v1-rediscluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=cache.example.com,resources=redisclusters,verbs=update,versions=v1,name=vrediscluster-v1.kb.io,admissionReviewVersions=v1
+
+// RedisClusterCustomValidator rejects spec.masters changes that no scaling operation carries out.
+// The controller only moves slots for the scale-up and scale-down it runs itself, so a master
+// count edited directly would resize the StatefulSet without resharding.
+type RedisClusterCustomValidator struct{}
+
+var _ webhook.CustomValidator = &RedisClusterCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *RedisClusterCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *RedisClusterCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCluster, ok := oldObj.(*cachev1.RedisCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a RedisCluster object but got %T", oldObj)
+	}
+	newCluster, ok := newObj.(*cachev1.RedisCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a RedisCluster object but got %T", newObj)
+	}
+	return nil, validateMastersChange(oldCluster, newCluster.Spec.Masters)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *RedisClusterCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// scaleValidationPath serves the validating webhook of the redisclusters/scale subresource.
+const scaleValidationPath = "/validate-cache-example-com-v1-rediscluster-scale"
+
+// +kubebuilder:webhook:path=/validate-cache-example-com-v1-rediscluster-scale,mutating=false,failurePolicy=fail,sideEffects=None,groups=cache.example.com,resources=redisclusters/scale,verbs=update,versions=v1,name=vredisclusterscale-v1.kb.io,admissionReviewVersions=v1
+
+// RedisClusterScaleValidator applies the spec.masters check of RedisClusterCustomValidator to
+// kubectl scale and HPA writes, which go through the scale subresource and reach the webhook as an
+// autoscaling/v1 Scale rather than a RedisCluster.
+type RedisClusterScaleValidator struct {
+	Client  client.Reader
+	Decoder admission.Decoder
+}
+
+// Handle implements admission.Handler.
+func (v *RedisClusterScaleValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	scale := &autoscalingv1.Scale{}
+	if err := v.Decoder.Decode(req, scale); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	cluster := &cachev1.RedisCluster{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: req.Name, Namespace: req.Namespace}, cluster); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if err := validateMastersChange(cluster, scale.Spec.Replicas); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// validateMastersChange rejects setting spec.masters of old to masters unless the change is carried
+// out by the operator: during a bootstrap or scaling operation, or to match the masters observed in
+// the Redis cluster (Status.CurrentMasters). Before the cluster is initialized any count is allowed.
+func validateMastersChange(old *cachev1.RedisCluster, masters int32) error {
+	status := old.Status
+	switch {
+	case masters == old.Spec.Masters, !status.Initialized, masters == status.CurrentMasters:
+		return nil
+	case status.ActiveOperation != "", status.IsResharding, status.IsDraining, status.IsRollingBack,
+		status.IsProvisioningStandby, status.IsRebalancing:
+		return nil
+	}
+	return fmt.Errorf("spec.masters cannot be changed from %d to %d directly: the operator only reshards for "+
+		"the scaling operations it runs; annotate the RedisCluster with rediscluster.cache.example.com/scale-up "+
+		"or rediscluster.cache.example.com/scale-down to add or remove a master", old.Spec.Masters, masters)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cachev1 "github.com/myuser/redis-operator/api/v1"
+)
+
+var _ = Describe("RedisCluster Webhook", func() {
+	var (
+		obj       *cachev1.RedisCluster
+		defaulter RedisClusterCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &cachev1.RedisCluster{ObjectMeta: metav1.ObjectMeta{Name: "defaulted", Namespace: "default"}}
+		defaulter = RedisClusterCustomDefaulter{}
+	})
+
+	Context("When creating RedisCluster under Defaulting Webhook", func() {
+		It("should apply the same defaults as SetDefaults", func() {
+			expected := obj.DeepCopy()
+			expected.SetDefaults()
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec).To(Equal(expected.Spec))
+		})
+
+		It("should keep values the user set", func() {
+			obj.Spec.Masters = 5
+			obj.Spec.StatefulSetName = "legacy"
+
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Masters).To(Equal(int32(5)))
+			Expect(obj.Spec.StatefulSetName).To(Equal("legacy"))
+		})
+
+		It("should reject objects of another kind", func() {
+			Expect(defaulter.Default(ctx, &corev1.Pod{})).NotTo(Succeed())
+		})
+
+		It("should store the defaults before any reconcile runs", func() {
+			// No controller runs in this suite, so every default seen here was set at admission.
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+			})
+
+			stored := &cachev1.RedisCluster{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "defaulted", Namespace: "default"}, stored)).To(Succeed())
+			Expect(stored.Spec.StatefulSetName).To(Equal("defaulted"))
+			Expect(stored.Spec.ServiceName).To(Equal("defaulted-headless"))
+			Expect(stored.Spec.RedisResources.Requests.Memory().Cmp(resource.MustParse("512Mi"))).To(Equal(0))
+		})
+	})
+
+	Context("When updating RedisCluster under Validating Webhook", func() {
+		var validator RedisClusterCustomValidator
+
+		BeforeEach(func() {
+			obj.Spec.Masters = 3
+			obj.Status.Initialized = true
+			obj.Status.CurrentMasters = 3
+		})
+
+		withMasters := func(masters int32) *cachev1.RedisCluster {
+			updated := obj.DeepCopy()
+			updated.Spec.Masters = masters
+			return updated
+		}
+
+		It("should reject a direct change of spec.masters", func() {
+			_, err := validator.ValidateUpdate(ctx, obj, withMasters(5))
+			Expect(err).To(MatchError(ContainSubstring("scale-up")))
+		})
+
+		It("should allow the operator's changes during a scaling operation", func() {
+			obj.Status.IsDraining = true
+			_, err := validator.ValidateUpdate(ctx, obj, withMasters(2))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow matching the observed masters", func() {
+			obj.Status.CurrentMasters = 4
+			_, err := validator.ValidateUpdate(ctx, obj, withMasters(4))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow any count before the cluster is initialized", func() {
+			obj.Status.Initialized = false
+			_, err := validator.ValidateUpdate(ctx, obj, withMasters(6))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow updates that keep spec.masters", func() {
+			updated := withMasters(3)
+			updated.Spec.AutoScaleEnabled = true
+			_, err := validator.ValidateUpdate(ctx, obj, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})